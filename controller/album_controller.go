@@ -0,0 +1,409 @@
+package controller
+
+import (
+	"mygram/model"
+	"mygram/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AlbumController struct {
+	AlbumService service.AlbumService
+}
+
+func NewAlbumController(albumService service.AlbumService) *AlbumController {
+	return &AlbumController{
+		AlbumService: albumService,
+	}
+}
+
+// GetAlbumsByUsername godoc
+//
+//	@Summary		Get albums by username
+//	@Description	List albums of a user. Private albums are only listed for their owner.
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/albums [get]
+func (ac *AlbumController) GetAlbumsByUsername(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	albums, err := ac.AlbumService.GetByUsername(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "User"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: albums,
+	})
+	return
+}
+
+// GetAlbumByID godoc
+//
+//	@Summary		Get album by ID
+//	@Description	View specific album with its photos in order.
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Album ID"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/album/{id} [get]
+func (ac *AlbumController) GetAlbumByID(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	album, err := ac.AlbumService.GetById(id, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: album,
+	})
+	return
+}
+
+// CreateAlbum godoc
+//
+//	@Summary		Create album
+//	@Description	Add new album
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		model.AlbumCreateRequest	true	"Album request is required"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/album [post]
+func (ac *AlbumController) CreateAlbum(ctx *gin.Context) {
+	albumRequest := model.AlbumCreateRequest{}
+
+	if !bindJSONRequest(ctx, &albumRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := ac.AlbumService.Add(albumRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// UpdateAlbum godoc
+//
+//	@Summary		Update album
+//	@Description	Update title, description and visibility of an album. Visibility is kept when left out.
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Album ID"
+//	@Param			request	body		model.AlbumUpdateRequest	true	"Album request is required"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/album/{id} [put]
+func (ac *AlbumController) UpdateAlbum(ctx *gin.Context) {
+	albumRequest := model.AlbumUpdateRequest{}
+
+	if !bindJSONRequest(ctx, &albumRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	result, err := ac.AlbumService.UpdateById(albumRequest, id, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// DeleteAlbum godoc
+//
+//	@Summary		Delete album
+//	@Description	Delete album. Photos in the album are kept.
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Album ID"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/album/{id} [delete]
+func (ac *AlbumController) DeleteAlbum(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	err := ac.AlbumService.DeleteById(id, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Delete album success.",
+	})
+	return
+}
+
+// AddPhotoToAlbum godoc
+//
+//	@Summary		Add photo to album
+//	@Description	Append one of your photos to the end of the album.
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Album ID"
+//	@Param			request	body		model.AlbumPhotoRequest	true	"Photo request is required"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/album/{id}/photos [post]
+func (ac *AlbumController) AddPhotoToAlbum(ctx *gin.Context) {
+	photoRequest := model.AlbumPhotoRequest{}
+
+	if !bindJSONRequest(ctx, &photoRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	result, err := ac.AlbumService.AddPhoto(photoRequest, id, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// RemovePhotoFromAlbum godoc
+//
+//	@Summary		Remove photo from album
+//	@Description	Remove photo from album. The photo itself is kept.
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Album ID"
+//	@Param			photo_id	path		string	true	"Photo ID"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/album/{id}/photos/{photo_id} [delete]
+func (ac *AlbumController) RemovePhotoFromAlbum(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	photoId := ctx.Param("photo_id")
+	result, err := ac.AlbumService.RemovePhoto(id, photoId, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// ReorderAlbumPhotos godoc
+//
+//	@Summary		Reorder album photos
+//	@Description	Set the order of photos in the album. Every photo must be listed exactly once.
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Album ID"
+//	@Param			request	body		model.AlbumReorderRequest	true	"Reorder request is required"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/album/{id}/photos/order [put]
+func (ac *AlbumController) ReorderAlbumPhotos(ctx *gin.Context) {
+	reorderRequest := model.AlbumReorderRequest{}
+
+	if !bindJSONRequest(ctx, &reorderRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	result, err := ac.AlbumService.ReorderPhotos(reorderRequest, id, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// SetAlbumCover godoc
+//
+//	@Summary		Set album cover
+//	@Description	Select one of the album photos as its cover.
+//	@Tags			Album
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Album ID"
+//	@Param			request	body		model.AlbumPhotoRequest	true	"Photo request is required"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/album/{id}/cover [put]
+func (ac *AlbumController) SetAlbumCover(ctx *gin.Context) {
+	photoRequest := model.AlbumPhotoRequest{}
+
+	if !bindJSONRequest(ctx, &photoRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	result, err := ac.AlbumService.SetCover(photoRequest, id, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
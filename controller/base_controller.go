@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"errors"
 	"mygram/model"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,3 +24,59 @@ func BaseContoller(ctx *gin.Context) {
 		},
 	})
 }
+
+// bindJSONRequest binds and validates the JSON body, aborting with 400 on failure.
+func bindJSONRequest(ctx *gin.Context, request interface{}) bool {
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		abortWithError(ctx, model.BindError(err))
+		return false
+	}
+
+	return validateRequest(ctx, request)
+}
+
+// bindQueryRequest binds and validates the query string, aborting with 400 on failure.
+func bindQueryRequest(ctx *gin.Context, request interface{}) bool {
+	if err := ctx.ShouldBindQuery(request); err != nil {
+		abortWithError(ctx, model.BindError(err))
+		return false
+	}
+
+	return validateRequest(ctx, request)
+}
+
+// bindFormRequest binds and validates a multipart or urlencoded form, aborting with 400 on failure.
+func bindFormRequest(ctx *gin.Context, request interface{}) bool {
+	if err := ctx.ShouldBind(request); err != nil {
+		abortWithError(ctx, model.BindError(err))
+		return false
+	}
+
+	return validateRequest(ctx, request)
+}
+
+func validateRequest(ctx *gin.Context, request interface{}) bool {
+	if err := model.ValidateRequest(request); err != nil {
+		abortWithError(ctx, err)
+		return false
+	}
+
+	return true
+}
+
+// abortWithError stops the request and leaves err to the error middleware,
+// which answers it with the status and code of the MyError it wraps.
+func abortWithError(ctx *gin.Context, err error) {
+	ctx.Error(err)
+	ctx.Abort()
+}
+
+// notFoundAs names the resource a not found error is about, as in
+// "Comment Not Found!", with the message under error.not_found.<resource>.
+// Other errors are returned as they are.
+func notFoundAs(err error, resource string) error {
+	if errors.Is(err, model.ErrorNotFound) {
+		return model.ErrorNotFound.WithKey("error.not_found." + strings.ToLower(resource))
+	}
+	return err
+}
@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"mygram/model"
+	"mygram/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BlockController struct {
+	BlockService service.BlockService
+}
+
+func NewBlockController(blockService service.BlockService) *BlockController {
+	return &BlockController{
+		BlockService: blockService,
+	}
+}
+
+// BlockUser godoc
+//
+//	@Summary		Block user
+//	@Description	Block a user. Blocked users cannot see each other's content, comment on each other's photos or follow each other, and existing follows are removed.
+//	@Tags			Block
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/block [post]
+func (bc *BlockController) BlockUser(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	result, err := bc.BlockService.Block(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// UnblockUser godoc
+//
+//	@Summary		Unblock user
+//	@Description	Unblock a user you blocked.
+//	@Tags			Block
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/block [delete]
+func (bc *BlockController) UnblockUser(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	err := bc.BlockService.Unblock(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Unblock success.",
+	})
+	return
+}
+
+// GetBlockedUsers godoc
+//
+//	@Summary		Get blocked users
+//	@Description	List users you blocked, newest first.
+//	@Tags			Block
+//	@Accept			json
+//	@Produce		json
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/blocks [get]
+func (bc *BlockController) GetBlockedUsers(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := bc.BlockService.GetBlocked(userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// MuteUser godoc
+//
+//	@Summary		Mute user
+//	@Description	Mute a user. Their photos and comments are hidden from your feed, they are not notified.
+//	@Tags			Block
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/mute [post]
+func (bc *BlockController) MuteUser(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	result, err := bc.BlockService.Mute(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// UnmuteUser godoc
+//
+//	@Summary		Unmute user
+//	@Description	Unmute a user you muted.
+//	@Tags			Block
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/mute [delete]
+func (bc *BlockController) UnmuteUser(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	err := bc.BlockService.Unmute(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Unmute success.",
+	})
+	return
+}
+
+// GetMutedUsers godoc
+//
+//	@Summary		Get muted users
+//	@Description	List users you muted, newest first.
+//	@Tags			Block
+//	@Accept			json
+//	@Produce		json
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/mutes [get]
+func (bc *BlockController) GetMutedUsers(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := bc.BlockService.GetMuted(userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"mygram/model"
+	"mygram/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkController struct {
+	BookmarkService service.BookmarkService
+}
+
+func NewBookmarkController(bookmarkService service.BookmarkService) *BookmarkController {
+	return &BookmarkController{
+		BookmarkService: bookmarkService,
+	}
+}
+
+// BookmarkPhoto godoc
+//
+//	@Summary		Bookmark photo
+//	@Description	Save photo into one of your private collections. The body is optional, the default collection is "Saved".
+//	@Tags			Bookmark
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Photo ID"
+//	@Param			request	body		model.BookmarkCreateRequest	false	"Bookmark request"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/photo/{id}/bookmark [post]
+func (bc *BookmarkController) BookmarkPhoto(ctx *gin.Context) {
+	bookmarkRequest := model.BookmarkCreateRequest{}
+
+	if ctx.Request.ContentLength != 0 && !bindJSONRequest(ctx, &bookmarkRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	photoId := ctx.Param("id")
+	result, err := bc.BookmarkService.Add(bookmarkRequest, photoId, userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Photo"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// UnbookmarkPhoto godoc
+//
+//	@Summary		Remove bookmark
+//	@Description	Remove photo from your bookmarks.
+//	@Tags			Bookmark
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Photo ID"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/photo/{id}/bookmark [delete]
+func (bc *BookmarkController) UnbookmarkPhoto(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	photoId := ctx.Param("id")
+	err := bc.BookmarkService.DeleteByPhotoId(photoId, userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Bookmark"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Delete bookmark success.",
+	})
+	return
+}
+
+// GetMyBookmarks godoc
+//
+//	@Summary		Get my bookmarks
+//	@Description	List your bookmarked photos, newest first.
+//	@Tags			Bookmark
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	query		string	false	"Collection name"
+//	@Param			page		query		int		false	"Page number"
+//	@Param			limit		query		int		false	"Page size"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/bookmarks [get]
+func (bc *BookmarkController) GetMyBookmarks(ctx *gin.Context) {
+	listRequest := model.BookmarkListRequest{}
+
+	if !bindQueryRequest(ctx, &listRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := bc.BookmarkService.GetMine(listRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
@@ -5,7 +5,6 @@ import (
 	"mygram/service"
 	"net/http"
 
-	valid "github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,26 +21,27 @@ func NewCommentController(commentService service.CommentService) *CommentControl
 // GetListComments godoc
 //
 //	@Summary		Get all comment
-//	@Description	View all comment
+//	@Description	Admins only. View all comment
 //	@Tags			Comment
 //	@Accept			json
 //	@Produce		json
 //	@Success		200		{object}	model.ResponseSuccess
 //	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
 //	@Failure		500		{object}	model.ResponseFailed
 //	@Security		Bearer
 //	@Router			/comment [get]
 func (cc *CommentController) GetListComments(ctx *gin.Context) {
-	comments, err := cc.CommentService.GetAll()
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	comments, err := cc.CommentService.GetAll(userId.(string))
 
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -70,27 +70,17 @@ func (cc *CommentController) GetListComments(ctx *gin.Context) {
 //	@Security		Bearer
 //	@Router			/comment/:id [get]
 func (cc *CommentController) GetOneCommentsByID(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
 	id := ctx.Param("id")
 
-	comment, err := cc.CommentService.GetById(id)
+	comment, err := cc.CommentService.GetById(id, userId.(string))
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: "Comment " + err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, notFoundAs(err, "Comment"))
 		return
 	}
 
@@ -104,92 +94,133 @@ func (cc *CommentController) GetOneCommentsByID(ctx *gin.Context) {
 	return
 }
 
-// CreateCommentByPhotoID godoc
+// GetPhotoComments godoc
 //
-//	@Summary		Create comment
-//	@Description	Add new comment
+//	@Summary		Get comments of a photo
+//	@Description	List the comments on a photo you can see, pinned comments first, then oldest first.
 //	@Tags			Comment
 //	@Accept			json
 //	@Produce		json
-//	@Param			photo_id	path		string	true	"Photo ID"
-//	@Param			request	body		model.CommentCreateRequest	true	"Comment request is required"
-//	@Success		201		{object}	model.ResponseSuccess
+//	@Param			id		path		string	true	"Photo ID"
+//	@Param			page	query		int		false	"Page"
+//	@Param			limit	query		int		false	"Limit"
+//	@Success		200		{object}	model.ResponseSuccess
 //	@Failure		400		{object}	model.ResponseFailed
 //	@Failure		401		{object}	model.ResponseFailed
 //	@Failure		404		{object}	model.ResponseFailed
 //	@Failure		500		{object}	model.ResponseFailed
 //	@Security		Bearer
-//	@Router			/comment/:photo_id [post]
-func (cc *CommentController) CreateCommentByPhotoID(ctx *gin.Context) {
-	commentRequest := model.CommentCreateRequest{}
+//	@Router			/photo/{id}/comments [get]
+func (cc *CommentController) GetPhotoComments(ctx *gin.Context) {
+	paginationRequest := model.PaginationRequest{}
 
-	if err := ctx.ShouldBindJSON(&commentRequest); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	if !bindQueryRequest(ctx, &paginationRequest) {
 		return
 	}
 
-	valid, err := valid.ValidateStruct(commentRequest)
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	comments, err := cc.CommentService.GetByPhoto(paginationRequest, ctx.Param("id"), userId.(string))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, notFoundAs(err, "Photo"))
 		return
 	}
 
-	if !valid {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: comments,
+	})
+	return
+}
+
+// GetMyComments godoc
+//
+//	@Summary		Get my comments
+//	@Description	List the comments you wrote, newest first.
+//	@Tags			Comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page"
+//	@Param			limit	query		int	false	"Limit"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/comments [get]
+func (cc *CommentController) GetMyComments(ctx *gin.Context) {
+	paginationRequest := model.PaginationRequest{}
+
+	if !bindQueryRequest(ctx, &paginationRequest) {
 		return
 	}
 
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: model.ErrorInvalidToken.Err,
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	comments, err := cc.CommentService.Mine(paginationRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
 		return
 	}
 
-	photoId := ctx.Param("photo_id")
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: comments,
+	})
+	return
+}
+
+// CreateCommentByPhotoID godoc
+//
+//	@Summary		Create comment
+//	@Description	Add new comment to the photo with the given ID
+//	@Tags			Comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Photo ID"
+//	@Param			request	body		model.CommentCreateRequest	true	"Comment request is required"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		429		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/comment/{id} [post]
+func (cc *CommentController) CreateCommentByPhotoID(ctx *gin.Context) {
+	commentRequest := model.CommentCreateRequest{}
+
+	if !bindJSONRequest(ctx, &commentRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	// The path segment shares its :id name with the other comment routes, but holds a photo ID here.
+	photoId := ctx.Param("id")
 	result, err := cc.CommentService.Add(commentRequest, userId.(string), photoId)
 
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusInternalServerError,
-					Message: http.StatusText(http.StatusInternalServerError),
-				},
-				Error: err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusNotFound,
-				Message: http.StatusText(http.StatusNotFound),
-			},
-			Error: "Comment " + err.Error(),
-		})
+		abortWithError(ctx, notFoundAs(err, "Photo"))
 		return
 	}
 
@@ -218,55 +249,20 @@ func (cc *CommentController) CreateCommentByPhotoID(ctx *gin.Context) {
 //	@Failure		401		{object}	model.ResponseFailed
 //	@Failure		403		{object}	model.ResponseFailed
 //	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		429		{object}	model.ResponseFailed
 //	@Failure		500		{object}	model.ResponseFailed
 //	@Security		Bearer
 //	@Router			/comment/:id [put]
 func (cc *CommentController) UpdateComment(ctx *gin.Context) {
 	commentRequest := model.CommentUpdateRequest{}
 
-	if err := ctx.ShouldBindJSON(&commentRequest); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	valid, err := valid.ValidateStruct(commentRequest)
-	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	if !valid {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	if !bindJSONRequest(ctx, &commentRequest) {
 		return
 	}
 
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: model.ErrorInvalidToken.Err,
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
 
@@ -274,32 +270,7 @@ func (cc *CommentController) UpdateComment(ctx *gin.Context) {
 	result, err := cc.CommentService.UpdateById(commentRequest, userId.(string), id)
 
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: "Comment " + err.Error(),
-			})
-			return
-		} else if err == model.ErrorForbiddenAccess {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusForbidden,
-					Message: http.StatusText(http.StatusForbidden),
-				},
-				Error: err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, notFoundAs(err, "Comment"))
 		return
 	}
 
@@ -317,7 +288,7 @@ func (cc *CommentController) UpdateComment(ctx *gin.Context) {
 // DeleteComment godoc
 //
 //	@Summary		Delete comment
-//	@Description	Delete comment
+//	@Description	Delete your own comment, or any comment on your own photo
 //	@Tags			Comment
 //	@Accept			json
 //	@Produce		json
@@ -334,47 +305,15 @@ func (cc *CommentController) DeleteComment(ctx *gin.Context) {
 
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: model.ErrorInvalidToken.Err,
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
-
 	}
 
 	id := ctx.Param("id")
 	err := cc.CommentService.DeleteById(userId.(string), id)
 
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: "Comment " + err.Error(),
-			})
-			return
-		} else if err == model.ErrorForbiddenAccess {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusForbidden,
-					Message: http.StatusText(http.StatusForbidden),
-				},
-				Error: err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, notFoundAs(err, "Comment"))
 		return
 	}
 
@@ -387,3 +326,124 @@ func (cc *CommentController) DeleteComment(ctx *gin.Context) {
 	})
 	return
 }
+
+// GetCommentRevisions godoc
+//
+//	@Summary		Get comment revisions
+//	@Description	View previous versions of a comment, only for its author and moderators
+//	@Tags			Comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Comment ID"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/comment/:id/revisions [get]
+func (cc *CommentController) GetCommentRevisions(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	revisions, err := cc.CommentService.GetRevisions(id, userId.(string))
+
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Comment"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: revisions,
+	})
+	return
+}
+
+// PinComment godoc
+//
+//	@Summary		Pin comment
+//	@Description	Pin a comment to the top of your own photo
+//	@Tags			Comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Comment ID"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/comment/{id}/pin [post]
+func (cc *CommentController) PinComment(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	result, err := cc.CommentService.Pin(id, userId.(string))
+
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Comment"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// UnpinComment godoc
+//
+//	@Summary		Unpin comment
+//	@Description	Unpin a comment on your own photo
+//	@Tags			Comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Comment ID"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/comment/{id}/pin [delete]
+func (cc *CommentController) UnpinComment(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	result, err := cc.CommentService.Unpin(id, userId.(string))
+
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Comment"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
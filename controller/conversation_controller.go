@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"mygram/model"
+	"mygram/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConversationController struct {
+	ConversationService service.ConversationService
+}
+
+func NewConversationController(conversationService service.ConversationService) *ConversationController {
+	return &ConversationController{
+		ConversationService: conversationService,
+	}
+}
+
+// GetConversations godoc
+//
+//	@Summary		Get conversations
+//	@Description	List your conversations, the latest active first, with their last message and your unread count.
+//	@Tags			Conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page"
+//	@Param			limit	query		int	false	"Limit"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/conversations [get]
+func (cc *ConversationController) GetConversations(ctx *gin.Context) {
+	paginationRequest := model.PaginationRequest{}
+
+	if !bindQueryRequest(ctx, &paginationRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := cc.ConversationService.List(paginationRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// CreateConversation godoc
+//
+//	@Summary		Create conversation
+//	@Description	Start a conversation with up to 9 other users. A single user reopens your existing one-to-one conversation with them.
+//	@Tags			Conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		model.ConversationCreateRequest	true	"Participants"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/conversations [post]
+func (cc *ConversationController) CreateConversation(ctx *gin.Context) {
+	createRequest := model.ConversationCreateRequest{}
+
+	if !bindJSONRequest(ctx, &createRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := cc.ConversationService.Create(createRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetMessages godoc
+//
+//	@Summary		Get messages
+//	@Description	List the messages of a conversation you take part in, newest first, with who read them.
+//	@Tags			Conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Conversation ID"
+//	@Param			page	query		int		false	"Page"
+//	@Param			limit	query		int		false	"Limit"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/conversations/{id}/messages [get]
+func (cc *ConversationController) GetMessages(ctx *gin.Context) {
+	paginationRequest := model.PaginationRequest{}
+
+	if !bindQueryRequest(ctx, &paginationRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := cc.ConversationService.Messages(paginationRequest, ctx.Param("id"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// SendMessage godoc
+//
+//	@Summary		Send message
+//	@Description	Send a message with a body, a shared photo or both.
+//	@Tags			Conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Conversation ID"
+//	@Param			request	body		model.MessageCreateRequest	true	"Message"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/conversations/{id}/messages [post]
+func (cc *ConversationController) SendMessage(ctx *gin.Context) {
+	createRequest := model.MessageCreateRequest{}
+
+	if !bindJSONRequest(ctx, &createRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := cc.ConversationService.Send(createRequest, ctx.Param("id"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// ReadConversation godoc
+//
+//	@Summary		Mark conversation read
+//	@Description	Mark every message of the conversation up to now as read.
+//	@Tags			Conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Conversation ID"
+//	@Success		200	{object}	model.ResponseSuccess
+//	@Failure		401	{object}	model.ResponseFailed
+//	@Failure		404	{object}	model.ResponseFailed
+//	@Failure		500	{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/conversations/{id}/read [post]
+func (cc *ConversationController) ReadConversation(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	err := cc.ConversationService.MarkRead(ctx.Param("id"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Conversation marked as read.",
+	})
+	return
+}
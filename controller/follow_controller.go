@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"mygram/model"
+	"mygram/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FollowController struct {
+	FollowService service.FollowService
+}
+
+func NewFollowController(followService service.FollowService) *FollowController {
+	return &FollowController{
+		FollowService: followService,
+	}
+}
+
+// FollowUser godoc
+//
+//	@Summary		Follow user
+//	@Description	Follow a user. Following a private account sends a follow request instead.
+//	@Tags			Follow
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/follow [post]
+func (fc *FollowController) FollowUser(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	result, err := fc.FollowService.Follow(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// UnfollowUser godoc
+//
+//	@Summary		Unfollow user
+//	@Description	Unfollow a user or cancel a pending follow request.
+//	@Tags			Follow
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/follow [delete]
+func (fc *FollowController) UnfollowUser(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	err := fc.FollowService.Unfollow(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Unfollow success.",
+	})
+	return
+}
+
+// GetFollowRequests godoc
+//
+//	@Summary		Get follow requests
+//	@Description	List pending follow requests to your account, oldest first.
+//	@Tags			Follow
+//	@Accept			json
+//	@Produce		json
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/follow-requests [get]
+func (fc *FollowController) GetFollowRequests(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := fc.FollowService.GetPendingRequests(userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// ApproveFollowRequest godoc
+//
+//	@Summary		Approve follow request
+//	@Description	Approve a pending follow request from a user.
+//	@Tags			Follow
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username of the requester"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/follow-requests/{username}/approve [post]
+func (fc *FollowController) ApproveFollowRequest(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	err := fc.FollowService.Approve(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Approve follow request success.",
+	})
+	return
+}
+
+// RejectFollowRequest godoc
+//
+//	@Summary		Reject follow request
+//	@Description	Reject a pending follow request from a user.
+//	@Tags			Follow
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username of the requester"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/follow-requests/{username}/reject [post]
+func (fc *FollowController) RejectFollowRequest(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	err := fc.FollowService.Reject(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Reject follow request success.",
+	})
+	return
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"mygram/model"
+	"mygram/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MediaController struct {
+	MediaService service.MediaService
+}
+
+func NewMediaController(mediaService service.MediaService) *MediaController {
+	return &MediaController{
+		MediaService: mediaService,
+	}
+}
+
+// GetMedia godoc
+//
+//	@Summary		Get uploaded media
+//	@Description	Get the file of an uploaded photo or story. Only viewers who may see the photo or story get the file.
+//	@Tags			Media
+//	@Produce		image/jpeg
+//	@Param			name	path		string	true	"File name"
+//	@Success		200		{file}		binary
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/uploads/{name} [get]
+func (mc *MediaController) GetMedia(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	path, err := mc.MediaService.GetFile(ctx.Param("name"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Media"))
+		return
+	}
+
+	ctx.Header("Cache-Control", "private")
+	ctx.File(path)
+}
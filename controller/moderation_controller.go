@@ -0,0 +1,451 @@
+package controller
+
+import (
+	"mygram/model"
+	"mygram/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationController struct {
+	ModerationService service.ModerationService
+}
+
+func NewModerationController(moderationService service.ModerationService) *ModerationController {
+	return &ModerationController{
+		ModerationService: moderationService,
+	}
+}
+
+// ReportPhoto godoc
+//
+//	@Summary		Report photo
+//	@Description	Flag a photo for moderator review. Reason is one of spam, harassment, hate_speech, nudity, violence, misinformation or other.
+//	@Tags			Report
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Photo ID"
+//	@Param			request	body		model.ReportCreateRequest	true	"Report request is required"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/photo/{id}/report [post]
+func (mc *ModerationController) ReportPhoto(ctx *gin.Context) {
+	reportRequest := model.ReportCreateRequest{}
+
+	if !bindJSONRequest(ctx, &reportRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	photoId := ctx.Param("id")
+	result, err := mc.ModerationService.ReportPhoto(reportRequest, photoId, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// ReportComment godoc
+//
+//	@Summary		Report comment
+//	@Description	Flag a comment for moderator review. Reason is one of spam, harassment, hate_speech, nudity, violence, misinformation or other.
+//	@Tags			Report
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Comment ID"
+//	@Param			request	body		model.ReportCreateRequest	true	"Report request is required"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/comment/{id}/report [post]
+func (mc *ModerationController) ReportComment(ctx *gin.Context) {
+	reportRequest := model.ReportCreateRequest{}
+
+	if !bindJSONRequest(ctx, &reportRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	commentId := ctx.Param("id")
+	result, err := mc.ModerationService.ReportComment(reportRequest, commentId, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// ReportUser godoc
+//
+//	@Summary		Report user
+//	@Description	Flag an account for moderator review. Reason is one of spam, harassment, hate_speech, nudity, violence, misinformation or other.
+//	@Tags			Report
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Param			request	body		model.ReportCreateRequest	true	"Report request is required"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/report [post]
+func (mc *ModerationController) ReportUser(ctx *gin.Context) {
+	reportRequest := model.ReportCreateRequest{}
+
+	if !bindJSONRequest(ctx, &reportRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	result, err := mc.ModerationService.ReportUser(reportRequest, username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetMyWarnings godoc
+//
+//	@Summary		Get my warnings
+//	@Description	List warnings moderators issued to you, newest first.
+//	@Tags			Report
+//	@Accept			json
+//	@Produce		json
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/warnings [get]
+func (mc *ModerationController) GetMyWarnings(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := mc.ModerationService.GetWarnings(userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetReportQueue godoc
+//
+//	@Summary		Get report queue
+//	@Description	Moderators only. List reports oldest first. Without status, open and claimed reports are listed.
+//	@Tags			Moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			status	query		string	false	"open, claimed, resolved or dismissed"
+//	@Param			page	query		int		false	"Page number"
+//	@Param			limit	query		int		false	"Page size"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/moderation/reports [get]
+func (mc *ModerationController) GetReportQueue(ctx *gin.Context) {
+	listRequest := model.ReportListRequest{}
+
+	if !bindQueryRequest(ctx, &listRequest) {
+		return
+	}
+
+	result, err := mc.ModerationService.GetQueue(listRequest)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// ClaimReport godoc
+//
+//	@Summary		Claim report
+//	@Description	Moderators only. Assign an open report to yourself.
+//	@Tags			Moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Report ID"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/moderation/reports/{id}/claim [post]
+func (mc *ModerationController) ClaimReport(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	reportId := ctx.Param("id")
+	result, err := mc.ModerationService.Claim(reportId, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// ResolveReport godoc
+//
+//	@Summary		Resolve report
+//	@Description	Moderators only. Hide the content, warn its owner or suspend its owner, closing every pending report on the same target.
+//	@Tags			Moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Report ID"
+//	@Param			request	body		model.ReportResolveRequest	true	"Resolve request is required"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/moderation/reports/{id}/resolve [post]
+func (mc *ModerationController) ResolveReport(ctx *gin.Context) {
+	resolveRequest := model.ReportResolveRequest{}
+
+	if !bindJSONRequest(ctx, &resolveRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	reportId := ctx.Param("id")
+	result, err := mc.ModerationService.Resolve(resolveRequest, reportId, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// DismissReport godoc
+//
+//	@Summary		Dismiss report
+//	@Description	Moderators only. Close every pending report on the same target as unfounded, restoring content hidden automatically.
+//	@Tags			Moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Report ID"
+//	@Param			request	body		model.ReportDismissRequest	false	"Dismiss request"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/moderation/reports/{id}/dismiss [post]
+func (mc *ModerationController) DismissReport(ctx *gin.Context) {
+	dismissRequest := model.ReportDismissRequest{}
+
+	if ctx.Request.ContentLength != 0 && !bindJSONRequest(ctx, &dismissRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	reportId := ctx.Param("id")
+	result, err := mc.ModerationService.Dismiss(dismissRequest, reportId, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetModerationActions godoc
+//
+//	@Summary		Get moderation actions
+//	@Description	Moderators only. List every recorded moderation decision, newest first.
+//	@Tags			Moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int		false	"Page number"
+//	@Param			limit	query		int		false	"Page size"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/moderation/actions [get]
+func (mc *ModerationController) GetModerationActions(ctx *gin.Context) {
+	paginationRequest := model.PaginationRequest{}
+
+	if !bindQueryRequest(ctx, &paginationRequest) {
+		return
+	}
+
+	result, err := mc.ModerationService.GetActions(paginationRequest)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetPhotoReposts godoc
+//
+//	@Summary		Get photo reposts
+//	@Description	Moderators only. List photos of other accounts that look like the given uploaded photo, closest first.
+//	@Tags			Moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Photo ID"
+//	@Param			max_distance	query		int		false	"Hamming distance between perceptual hashes, 10 by default"
+//	@Success		200				{object}	model.ResponseSuccess
+//	@Failure		400				{object}	model.ResponseFailed
+//	@Failure		401				{object}	model.ResponseFailed
+//	@Failure		403				{object}	model.ResponseFailed
+//	@Failure		404				{object}	model.ResponseFailed
+//	@Failure		500				{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/moderation/photos/{id}/reposts [get]
+func (mc *ModerationController) GetPhotoReposts(ctx *gin.Context) {
+	repostRequest := model.PhotoRepostRequest{}
+
+	if !bindQueryRequest(ctx, &repostRequest) {
+		return
+	}
+
+	photoId := ctx.Param("id")
+	result, err := mc.ModerationService.GetReposts(repostRequest, photoId)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
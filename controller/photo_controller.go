@@ -1,11 +1,11 @@
 package controller
 
 import (
+	"io"
 	"mygram/model"
 	"mygram/service"
 	"net/http"
 
-	valid "github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 )
 
@@ -32,16 +32,16 @@ func NewPhotoController(photoService service.PhotoService) *PhotoController {
 //	@Security		Bearer
 //	@Router			/photo [get]
 func (pc *PhotoController) GetListPhotos(ctx *gin.Context) {
-	photos, err := pc.PhotoService.GetAll()
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	photos, err := pc.PhotoService.GetAll(userId.(string))
 
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -70,29 +70,18 @@ func (pc *PhotoController) GetListPhotos(ctx *gin.Context) {
 //	@Security		Bearer
 //	@Router			/photo/{id} [get]
 func (pc *PhotoController) GetPhotoByID(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
 	id := ctx.Param("id")
-	photo, err := pc.PhotoService.GetById(id)
+	photo, err := pc.PhotoService.GetById(id, userId.(string))
 
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: "Photo " + err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, notFoundAs(err, "Photo"))
 		return
-
 	}
 
 	ctx.JSON(http.StatusOK, model.ResponseSuccess{
@@ -109,7 +98,7 @@ func (pc *PhotoController) GetPhotoByID(ctx *gin.Context) {
 // CreatePhoto godoc
 //
 //	@Summary		Create Photo
-//	@Description	Add new Photo
+//	@Description	Add new Photo, either a single photo_url or a carousel of 1 to 10 media items. Photos are published right away unless saved as a draft or scheduled for publish_at.
 //	@Tags			Photo
 //	@Accept			json
 //	@Produce		json
@@ -123,63 +112,102 @@ func (pc *PhotoController) GetPhotoByID(ctx *gin.Context) {
 func (pc *PhotoController) CreatePhoto(ctx *gin.Context) {
 	newPhoto := model.PhotoCreateRequest{}
 
-	if err := ctx.ShouldBindJSON(&newPhoto); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	if !bindJSONRequest(ctx, &newPhoto) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
-	valid, err := valid.ValidateStruct(newPhoto)
 
+	res, err := pc.PhotoService.Add(newPhoto, userId.(string))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
-	if !valid {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	ctx.JSON(http.StatusCreated, res)
+	return
+
+}
+
+// UploadPhoto godoc
+//
+//	@Summary		Upload Photo
+//	@Description	Upload a JPEG as a new Photo. EXIF is stripped from the stored file and its orientation applied, the camera information is kept as metadata shown to others only with share_metadata.
+//	@Tags			Photo
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			photo			formData	file	true	"JPEG image"
+//	@Param			title			formData	string	true	"Title"
+//	@Param			caption			formData	string	false	"Caption"
+//	@Param			alt_text		formData	string	false	"Alt text, suggested when empty"
+//	@Param			share_metadata	formData	bool	false	"Show camera information to others"
+//	@Param			status			formData	string	false	"draft, scheduled or published"
+//	@Param			publish_at		formData	string	false	"RFC 3339 publish time of a scheduled photo"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		413		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/photo/upload [post]
+func (pc *PhotoController) UploadPhoto(ctx *gin.Context) {
+	uploadRequest := model.PhotoUploadRequest{}
+
+	// Leave room for the other form fields on top of the file itself.
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, pc.PhotoService.MaxUploadSize+1<<20)
+
+	if !bindFormRequest(ctx, &uploadRequest) {
 		return
 	}
 
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
 
-	res, err := pc.PhotoService.Add(newPhoto, userId.(string))
+	fileHeader, err := ctx.FormFile("photo")
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.MyError{
-			Err: err.Error(),
-		})
+		abortWithError(ctx, model.ErrorPhotoFileRequired)
+		return
+	}
+	if fileHeader.Size > pc.PhotoService.MaxUploadSize {
+		abortWithError(ctx, model.ErrorImageTooLarge)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, res)
-	return
+	file, err := fileHeader.Open()
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, pc.PhotoService.MaxUploadSize+1))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	res, err := pc.PhotoService.Upload(uploadRequest, data, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
 
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: res,
+	})
+	return
 }
 
 // UpdatePhoto godoc
@@ -203,83 +231,20 @@ func (pc *PhotoController) UpdatePhoto(ctx *gin.Context) {
 	updatePhoto := model.PhotoUpdateRequest{}
 	id := ctx.Param("id")
 
-	if err := ctx.ShouldBindJSON(&updatePhoto); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-	valid, err := valid.ValidateStruct(updatePhoto)
-
-	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	if !valid {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	if !bindJSONRequest(ctx, &updatePhoto) {
 		return
 	}
 
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
 
 	updated, err := pc.PhotoService.UpdateById(updatePhoto, id, userId.(string))
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: "Photo " + err.Error(),
-			})
-			return
-		} else if err == model.ErrorForbiddenAccess {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusForbidden,
-					Message: http.StatusText(http.StatusForbidden),
-				},
-				Error: err.Error(),
-			})
-			return
-
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, notFoundAs(err, "Photo"))
 		return
-
 	}
 	ctx.JSON(http.StatusOK, model.ResponseSuccess{
 		Meta: model.Meta{
@@ -311,45 +276,14 @@ func (pc *PhotoController) DeletePhoto(ctx *gin.Context) {
 	id := ctx.Param("id")
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: model.ErrorInvalidToken.Err,
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
 
 	err := pc.PhotoService.DeleteById(id, userId.(string))
 
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: "Photo " + err.Error(),
-			})
-			return
-		} else if err == model.ErrorForbiddenAccess {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusForbidden,
-					Message: http.StatusText(http.StatusForbidden),
-				},
-				Error: err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, notFoundAs(err, "Photo"))
 		return
 	}
 
@@ -363,3 +297,185 @@ func (pc *PhotoController) DeletePhoto(ctx *gin.Context) {
 	return
 
 }
+
+// GetNearbyPhotos godoc
+//
+//	@Summary		Get nearby photos
+//	@Description	Get geotagged photos within radius_km (default 10, at most 100) of a point, closest first.
+//	@Tags			Photo
+//	@Accept			json
+//	@Produce		json
+//	@Param			lat			query		number	true	"Latitude"
+//	@Param			lng			query		number	true	"Longitude"
+//	@Param			radius_km	query		number	false	"Radius in km"
+//	@Param			page		query		int		false	"Page"
+//	@Param			limit		query		int		false	"Limit"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/photo/nearby [get]
+func (pc *PhotoController) GetNearbyPhotos(ctx *gin.Context) {
+	nearbyRequest := model.PhotoNearbyRequest{}
+
+	if !bindQueryRequest(ctx, &nearbyRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := pc.PhotoService.Nearby(nearbyRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetMissingAltText godoc
+//
+//	@Summary		Get my photos missing alt text
+//	@Description	List your photos with media lacking alt text, or only carrying a suggestion worth reviewing, newest first.
+//	@Tags			Photo
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page"
+//	@Param			limit	query		int	false	"Limit"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/photos/missing-alt-text [get]
+func (pc *PhotoController) GetMissingAltText(ctx *gin.Context) {
+	paginationRequest := model.PaginationRequest{}
+
+	if !bindQueryRequest(ctx, &paginationRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := pc.PhotoService.MissingAltText(paginationRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// UpdateCommentSettings godoc
+//
+//	@Summary		Update photo comment settings
+//	@Description	Choose who can comment on your photo: everyone, followers or nobody (off).
+//	@Tags			Photo
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Photo ID"
+//	@Param			request	body		model.PhotoCommentSettingsRequest	true	"Comment settings request"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/photo/{id}/comment-settings [put]
+func (pc *PhotoController) UpdateCommentSettings(ctx *gin.Context) {
+	settingsRequest := model.PhotoCommentSettingsRequest{}
+
+	if !bindJSONRequest(ctx, &settingsRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	id := ctx.Param("id")
+	result, err := pc.PhotoService.UpdateCommentSettings(settingsRequest, id, userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Photo"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetDrafts godoc
+//
+//	@Summary		Get my drafts
+//	@Description	List your drafts and scheduled photos, the ones due first. They are hidden from everyone else until published.
+//	@Tags			Photo
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int	false	"Page"
+//	@Param			limit	query		int	false	"Limit"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/drafts [get]
+func (pc *PhotoController) GetDrafts(ctx *gin.Context) {
+	paginationRequest := model.PaginationRequest{}
+
+	if !bindQueryRequest(ctx, &paginationRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := pc.PhotoService.Drafts(paginationRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"mygram/model"
+	"mygram/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReactionController struct {
+	ReactionService service.ReactionService
+}
+
+func NewReactionController(reactionService service.ReactionService) *ReactionController {
+	return &ReactionController{
+		ReactionService: reactionService,
+	}
+}
+
+// ReactToComment godoc
+//
+//	@Summary		React to comment
+//	@Description	Add an emoji reaction to a comment. Each user can react once per emoji.
+//	@Tags			Comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Comment ID"
+//	@Param			emoji	path		string	true	"Emoji"
+//	@Success		201		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/comment/{id}/reactions/{emoji} [post]
+func (rc *ReactionController) ReactToComment(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := rc.ReactionService.React(ctx.Param("id"), ctx.Param("emoji"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Reaction"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// RemoveCommentReaction godoc
+//
+//	@Summary		Remove comment reaction
+//	@Description	Remove your emoji reaction from a comment.
+//	@Tags			Comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Comment ID"
+//	@Param			emoji	path		string	true	"Emoji"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/comment/{id}/reactions/{emoji} [delete]
+func (rc *ReactionController) RemoveCommentReaction(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := rc.ReactionService.Unreact(ctx.Param("id"), ctx.Param("emoji"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Reaction"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
@@ -1,14 +1,34 @@
 package controller
 
 import (
+	"bytes"
+	"html/template"
+	"mygram/i18n"
 	"mygram/model"
 	"mygram/service"
 	"net/http"
 
-	valid "github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 )
 
+// leavingPage is shown instead of redirecting to links outside the platform
+// catalog, naming the site the visitor is about to open.
+var leavingPage = template.Must(template.New("leaving").Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Body}}</p>
+<p><code>{{.URL}}</code></p>
+<p><a href="{{.URL}}" rel="noopener noreferrer nofollow">{{.Continue}}</a></p>
+</body>
+</html>
+`))
+
 type SocialMediaController struct {
 	SocialMediaService service.SocialMediaService
 }
@@ -19,28 +39,123 @@ func NewSocialMediaController(socialMediaService service.SocialMediaService) *So
 	}
 }
 
+// GetSocialMediaPlatforms godoc
+//
+//	@Summary		Get Social Media platforms
+//	@Description	Get the platforms links can be added for. Links of other sites are custom.
+//	@Tags			Social Media
+//	@Accept			json
+//	@Produce		json
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/social_media/platforms [get]
+func (smc *SocialMediaController) GetSocialMediaPlatforms(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: smc.SocialMediaService.Platforms(),
+	})
+	return
+}
+
 // GetListSocialMedias godoc
 //
 //	@Summary		Get All Social Media
-//	@Description	Get All Social Media.
+//	@Description	Admins only. Get All Social Media.
 //	@Tags			Social Media
 //	@Accept			json
 //	@Produce		json
 //	@Success		200		{object}		model.ResponseSuccess
 //	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
 //	@Failure		500		{object}	model.ResponseFailed
 //	@Security		Bearer
 //	@Router			/social_media [get]
 func (smc *SocialMediaController) GetListSocialMedias(ctx *gin.Context) {
-	socialMedias, err := smc.SocialMediaService.GetAll()
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	socialMedias, err := smc.SocialMediaService.GetAll(userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: socialMedias,
+	})
+	return
+}
+
+// GetUserSocialMedia godoc
+//
+//	@Summary		Get Social Media of a user
+//	@Description	List the Social Media of a user you are allowed to see, in the order the user chose.
+//	@Tags			Social Media
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username or user ID"
+//	@Success		200			{object}	model.ResponseSuccess
+//	@Failure		401			{object}	model.ResponseFailed
+//	@Failure		404			{object}	model.ResponseFailed
+//	@Failure		500			{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username}/social_media [get]
+func (smc *SocialMediaController) GetUserSocialMedia(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	socialMedias, err := smc.SocialMediaService.GetByUser(ctx.Param("username"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "User"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: socialMedias,
+	})
+	return
+}
+
+// GetMySocialMedia godoc
+//
+//	@Summary		Get my Social Media
+//	@Description	List all of your Social Media in order, private ones included.
+//	@Tags			Social Media
+//	@Accept			json
+//	@Produce		json
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/social_media [get]
+func (smc *SocialMediaController) GetMySocialMedia(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	socialMedias, err := smc.SocialMediaService.Mine(userId.(string))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -70,26 +185,16 @@ func (smc *SocialMediaController) GetListSocialMedias(ctx *gin.Context) {
 //	@Router			/social_media/{id} [get]
 func (smc *SocialMediaController) GetOneSocialMediaByID(ctx *gin.Context) {
 	id := ctx.Param("id")
-	socialMedia, err := smc.SocialMediaService.GetById(id)
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	socialMedia, err := smc.SocialMediaService.GetById(id, userId.(string))
 
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -114,67 +219,26 @@ func (smc *SocialMediaController) GetOneSocialMediaByID(ctx *gin.Context) {
 //	@Success		201		{object}	model.ResponseSuccess
 //	@Failure		400		{object}	model.ResponseFailed
 //	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
 //	@Failure		500		{object}	model.ResponseFailed
 //	@Security		Bearer
 //	@Router			/social_media [post]
 func (smc *SocialMediaController) CreateSocialMedia(ctx *gin.Context) {
 	newSocialMedia := model.SocialMediaCreateRequest{}
 
-	if err := ctx.ShouldBindJSON(&newSocialMedia); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-	valid, err := valid.ValidateStruct(newSocialMedia)
-
-	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	if !valid {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	if !bindJSONRequest(ctx, &newSocialMedia) {
 		return
 	}
 
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: model.ErrorInvalidToken.Err,
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
 
 	res, err := smc.SocialMediaService.Add(newSocialMedia, userId.(string))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -202,6 +266,7 @@ func (smc *SocialMediaController) CreateSocialMedia(ctx *gin.Context) {
 //	@Failure		401		{object}	model.ResponseFailed
 //	@Failure		403		{object}	model.ResponseFailed
 //	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		409		{object}	model.ResponseFailed
 //	@Failure		500		{object}	model.ResponseFailed
 //	@Security		Bearer
 //	@Router			/social_media/{id} [put]
@@ -209,81 +274,19 @@ func (smc *SocialMediaController) UpdateSocialMedia(ctx *gin.Context) {
 	updateSocialMedia := model.SocialMediaUpdateRequest{}
 	id := ctx.Param("id")
 
-	if err := ctx.ShouldBindJSON(&updateSocialMedia); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-	valid, err := valid.ValidateStruct(updateSocialMedia)
-
-	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	if !valid {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	if !bindJSONRequest(ctx, &updateSocialMedia) {
 		return
 	}
 
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: model.ErrorInvalidToken.Err,
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
 
 	res, err := smc.SocialMediaService.UpdateById(updateSocialMedia, id, userId.(string))
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: err.Error(),
-			})
-			return
-
-		} else if err == model.ErrorForbiddenAccess {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusForbidden,
-					Message: http.StatusText(http.StatusForbidden),
-				},
-				Error: err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, model.ResponseSuccess{
@@ -316,46 +319,14 @@ func (smc *SocialMediaController) DeleteSocialMedia(ctx *gin.Context) {
 	id := ctx.Param("id")
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: model.ErrorInvalidToken.Err,
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
 
 	err := smc.SocialMediaService.DeleteById(id, userId.(string))
 
 	if err != nil {
-		if err == model.ErrorNotFound {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusNotFound,
-					Message: http.StatusText(http.StatusNotFound),
-				},
-				Error: err.Error(),
-			})
-			return
-
-		} else if err == model.ErrorForbiddenAccess {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusForbidden,
-					Message: http.StatusText(http.StatusForbidden),
-				},
-				Error: err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -369,3 +340,213 @@ func (smc *SocialMediaController) DeleteSocialMedia(ctx *gin.Context) {
 	return
 
 }
+
+// StartSocialMediaVerification godoc
+//
+//	@Summary		Start Social Media verification
+//	@Description	Get a challenge to place on the linked page, then call verify. Starting again replaces the challenge and drops an earlier verification.
+//	@Tags			Social Media
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Social Media ID"
+//	@Success		200	{object}	model.ResponseSuccess
+//	@Failure		401	{object}	model.ResponseFailed
+//	@Failure		403	{object}	model.ResponseFailed
+//	@Failure		404	{object}	model.ResponseFailed
+//	@Failure		500	{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/social_media/{id}/verification [post]
+func (smc *SocialMediaController) StartSocialMediaVerification(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := smc.SocialMediaService.StartVerification(ctx.Param("id"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// VerifySocialMedia godoc
+//
+//	@Summary		Verify Social Media
+//	@Description	Fetch the linked page and mark the link verified when it shows the challenge.
+//	@Tags			Social Media
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Social Media ID"
+//	@Success		200	{object}	model.ResponseSuccess
+//	@Failure		400	{object}	model.ResponseFailed
+//	@Failure		401	{object}	model.ResponseFailed
+//	@Failure		403	{object}	model.ResponseFailed
+//	@Failure		404	{object}	model.ResponseFailed
+//	@Failure		500	{object}	model.ResponseFailed
+//	@Failure		502	{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/social_media/{id}/verify [post]
+func (smc *SocialMediaController) VerifySocialMedia(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := smc.SocialMediaService.Verify(ctx.Param("id"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// ReorderSocialMedia godoc
+//
+//	@Summary		Reorder Social Media
+//	@Description	Put all your links in a new order.
+//	@Tags			Social Media
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		model.SocialMediaOrderRequest	true	"All your link IDs in the new order"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/social_media/order [put]
+func (smc *SocialMediaController) ReorderSocialMedia(ctx *gin.Context) {
+	orderRequest := model.SocialMediaOrderRequest{}
+
+	if !bindJSONRequest(ctx, &orderRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := smc.SocialMediaService.Reorder(orderRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// RedirectSocialMedia godoc
+//
+//	@Summary		Open Social Media link
+//	@Description	Redirect to the linked page and count the click. Works without a token for public links. Only daily counts are kept, nothing about who clicked. Custom links, and links no longer matching their platform, get a page to continue from instead of a redirect.
+//	@Tags			Social Media
+//	@Produce		html
+//	@Param			social_media_id	path	string	true	"Social Media ID"
+//	@Success		200
+//	@Success		302
+//	@Failure		404	{object}	model.ResponseFailed
+//	@Failure		500	{object}	model.ResponseFailed
+//	@Router			/l/{social_media_id} [get]
+func (smc *SocialMediaController) RedirectSocialMedia(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	redirect, err := smc.SocialMediaService.Click(ctx.Param("social_media_id"), userId)
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	if !redirect.Interstitial {
+		ctx.Redirect(http.StatusFound, redirect.URL)
+		return
+	}
+
+	// Links are opened from browsers that rarely carry a token, so the page
+	// follows Accept-Language alone.
+	language := i18n.Negotiate(ctx.GetHeader("Accept-Language"))
+	page := bytes.Buffer{}
+	err = leavingPage.Execute(&page, map[string]string{
+		"Language": language,
+		"Title":    i18n.Translate(language, "page.leaving.title"),
+		"Body":     i18n.Translate(language, "page.leaving.body"),
+		"Continue": i18n.Translate(language, "page.leaving.continue"),
+		"URL":      redirect.URL,
+	})
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.Header("Referrer-Policy", "no-referrer")
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// GetSocialMediaStats godoc
+//
+//	@Summary		Get Social Media click stats
+//	@Description	Get the daily clicks of each of your links, for the last 30 days unless days is given (at most 90).
+//	@Tags			Social Media
+//	@Accept			json
+//	@Produce		json
+//	@Param			days	query		int	false	"Days"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/social_media/stats [get]
+func (smc *SocialMediaController) GetSocialMediaStats(ctx *gin.Context) {
+	statsRequest := model.SocialMediaStatsRequest{}
+
+	if !bindQueryRequest(ctx, &statsRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := smc.SocialMediaService.Stats(statsRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
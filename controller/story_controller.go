@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"io"
+	"mygram/model"
+	"mygram/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StoryController struct {
+	StoryService service.StoryService
+}
+
+func NewStoryController(storyService service.StoryService) *StoryController {
+	return &StoryController{
+		StoryService: storyService,
+	}
+}
+
+// UploadStory godoc
+//
+//	@Summary		Upload story
+//	@Description	Upload a JPEG as a story. It is shown to your followers for 24 hours and then deleted.
+//	@Tags			Story
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			photo		formData	file	true	"JPEG image"
+//	@Param			caption		formData	string	false	"Caption"
+//	@Param			alt_text	formData	string	false	"Alt text"
+//	@Success		201			{object}	model.ResponseSuccess
+//	@Failure		400			{object}	model.ResponseFailed
+//	@Failure		401			{object}	model.ResponseFailed
+//	@Failure		413			{object}	model.ResponseFailed
+//	@Failure		500			{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/stories [post]
+func (sc *StoryController) UploadStory(ctx *gin.Context) {
+	uploadRequest := model.StoryUploadRequest{}
+
+	// Leave room for the other form fields on top of the file itself.
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, sc.StoryService.MaxUploadSize+1<<20)
+
+	if !bindFormRequest(ctx, &uploadRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("photo")
+	if err != nil {
+		abortWithError(ctx, model.ErrorPhotoFileRequired)
+		return
+	}
+	if fileHeader.Size > sc.StoryService.MaxUploadSize {
+		abortWithError(ctx, model.ErrorImageTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Story"))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, sc.StoryService.MaxUploadSize+1))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Story"))
+		return
+	}
+
+	result, err := sc.StoryService.Upload(uploadRequest, data, userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Story"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusCreated,
+			Message: http.StatusText(http.StatusCreated),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetStoryFeed godoc
+//
+//	@Summary		Get story feed
+//	@Description	Get the unexpired stories of you and the users you follow, grouped by user. Your own come first, then users with stories you have not seen.
+//	@Tags			Story
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	model.ResponseSuccess
+//	@Failure		401	{object}	model.ResponseFailed
+//	@Failure		500	{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/stories/feed [get]
+func (sc *StoryController) GetStoryFeed(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := sc.StoryService.Feed(userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Story"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetStoryByID godoc
+//
+//	@Summary		Get story
+//	@Description	Get an unexpired story and mark it as seen.
+//	@Tags			Story
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Story ID"
+//	@Success		200	{object}	model.ResponseSuccess
+//	@Failure		401	{object}	model.ResponseFailed
+//	@Failure		404	{object}	model.ResponseFailed
+//	@Failure		500	{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/stories/{id} [get]
+func (sc *StoryController) GetStoryByID(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := sc.StoryService.GetById(ctx.Param("id"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Story"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// GetStoryViewers godoc
+//
+//	@Summary		Get story viewers
+//	@Description	Owner only. List who saw the story, most recent first.
+//	@Tags			Story
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Story ID"
+//	@Param			page	query		int		false	"Page"
+//	@Param			limit	query		int		false	"Limit"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/stories/{id}/viewers [get]
+func (sc *StoryController) GetStoryViewers(ctx *gin.Context) {
+	paginationRequest := model.PaginationRequest{}
+
+	if !bindQueryRequest(ctx, &paginationRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	result, err := sc.StoryService.Viewers(paginationRequest, ctx.Param("id"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Story"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: result,
+	})
+	return
+}
+
+// DeleteStory godoc
+//
+//	@Summary		Delete story
+//	@Description	Delete your story before it expires.
+//	@Tags			Story
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Story ID"
+//	@Success		200	{object}	model.ResponseSuccess
+//	@Failure		401	{object}	model.ResponseFailed
+//	@Failure		403	{object}	model.ResponseFailed
+//	@Failure		404	{object}	model.ResponseFailed
+//	@Failure		500	{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/stories/{id} [delete]
+func (sc *StoryController) DeleteStory(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	err := sc.StoryService.DeleteById(ctx.Param("id"), userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "Story"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: "Delete story success.",
+	})
+	return
+}
@@ -5,7 +5,6 @@ import (
 	"mygram/service"
 	"net/http"
 
-	valid "github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 )
 
@@ -34,50 +33,13 @@ func NewUserController(userService service.UserService) *UserController {
 func (uc *UserController) Register(ctx *gin.Context) {
 	newUser := model.UserRegisterRequest{}
 
-	if err := ctx.ShouldBindJSON(&newUser); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	valid, err := valid.ValidateStruct(newUser)
-
-	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	if !valid {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	if !bindJSONRequest(ctx, &newUser) {
 		return
 	}
 
 	res, err := uc.UserService.Add(newUser)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -101,74 +63,20 @@ func (uc *UserController) Register(ctx *gin.Context) {
 //	@Param			request	body		model.UserLoginRequest	true	"User request is required"
 //	@Success		200		{object}	model.ResponseSuccess
 //	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		403		{object}	model.ResponseFailed
 //	@Failure		500		{object}	model.ResponseFailed
 //	@Router			/auth/login [post]
 func (uc *UserController) Login(ctx *gin.Context) {
 	newUser := model.UserLoginRequest{}
 
-	if err := ctx.ShouldBindJSON(&newUser); err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	valid, err := valid.ValidateStruct(newUser)
-	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
-		return
-	}
-
-	if !valid {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-			},
-			Error: err.Error(),
-		})
+	if !bindJSONRequest(ctx, &newUser) {
 		return
 	}
 
 	res, err := uc.UserService.Login(newUser)
 
 	if err != nil {
-		if err == model.ErrorInvalidEmailOrPassword {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusUnauthorized,
-					Message: http.StatusText(http.StatusUnauthorized),
-				},
-				Error: err.Error(),
-			})
-			return
-		} else if err == model.ErrorInvalidToken {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-				Meta: model.Meta{
-					Code:    http.StatusInternalServerError,
-					Message: http.StatusText(http.StatusInternalServerError),
-				},
-				Error: err.Error(),
-			})
-			return
-		}
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
 		return
 	}
 
@@ -198,28 +106,140 @@ func (uc *UserController) Login(ctx *gin.Context) {
 func (uc *UserController) MyGram(ctx *gin.Context) {
 	userId, isExist := ctx.Get("user_id")
 	if !isExist {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: model.ErrorInvalidToken.Err,
-		})
+		abortWithError(ctx, model.ErrorInvalidToken)
 		return
 	}
 
 	res, err := uc.UserService.MyGram(userId.(string))
 
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		abortWithError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: res,
+	})
+	return
+}
+
+// GetProfile godoc
+//
+//	@Summary		Get user profile
+//	@Description	View a user's profile. Photos and social medias of private accounts are only shown to accepted followers.
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		404		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/users/{username} [get]
+func (uc *UserController) GetProfile(ctx *gin.Context) {
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	username := ctx.Param("username")
+	res, err := uc.UserService.GetProfile(username, userId.(string))
+	if err != nil {
+		abortWithError(ctx, notFoundAs(err, "User"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: res,
+	})
+	return
+}
+
+// UpdatePrivacy godoc
+//
+//	@Summary		Update account privacy
+//	@Description	Make your account private or public. Going public approves all pending follow requests.
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		model.UserPrivacyRequest	true	"Privacy request is required"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/privacy [put]
+func (uc *UserController) UpdatePrivacy(ctx *gin.Context) {
+	privacyRequest := model.UserPrivacyRequest{}
+
+	if !bindJSONRequest(ctx, &privacyRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	res, err := uc.UserService.UpdatePrivacy(privacyRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
 		return
 	}
+
+	ctx.JSON(http.StatusOK, model.ResponseSuccess{
+		Meta: model.Meta{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+		},
+		Data: res,
+	})
+	return
+}
+
+// UpdateLocale godoc
+//
+//	@Summary		Update account locale
+//	@Description	Set the language errors are answered in, en or id. An empty locale follows the Accept-Language header.
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		model.UserLocaleRequest	true	"Locale request is required"
+//	@Success		200		{object}	model.ResponseSuccess
+//	@Failure		400		{object}	model.ResponseFailed
+//	@Failure		401		{object}	model.ResponseFailed
+//	@Failure		500		{object}	model.ResponseFailed
+//	@Security		Bearer
+//	@Router			/me/locale [put]
+func (uc *UserController) UpdateLocale(ctx *gin.Context) {
+	localeRequest := model.UserLocaleRequest{}
+
+	if !bindJSONRequest(ctx, &localeRequest) {
+		return
+	}
+
+	userId, isExist := ctx.Get("user_id")
+	if !isExist {
+		abortWithError(ctx, model.ErrorInvalidToken)
+		return
+	}
+
+	res, err := uc.UserService.UpdateLocale(localeRequest, userId.(string))
+	if err != nil {
+		abortWithError(ctx, err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, model.ResponseSuccess{
 		Meta: model.Meta{
 			Code:    http.StatusOK,
@@ -41,7 +41,7 @@ func StartDB() {
 		panic(err)
 	}
 
-	db.AutoMigrate(&model.User{}, &model.Photo{}, &model.Comment{}, &model.SocialMedia{})
+	db.AutoMigrate(&model.User{}, &model.Photo{}, &model.PhotoMetadata{}, &model.PhotoMedia{}, &model.Comment{}, &model.CommentRevision{}, &model.CommentReaction{}, &model.SocialMedia{}, &model.Album{}, &model.AlbumPhoto{}, &model.Bookmark{}, &model.Follow{}, &model.Block{}, &model.Mute{}, &model.Report{}, &model.ModerationAction{}, &model.Story{}, &model.StoryView{}, &model.Conversation{}, &model.ConversationParticipant{}, &model.Message{}, &model.SocialMediaClick{})
 }
 
 func GetDB() *gorm.DB {
@@ -3,6 +3,8 @@ package helper
 import (
 	"mygram/model"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -47,3 +49,36 @@ func VerifyToken(token string) (*jwt.Token, error) {
 	})
 	return jwtToken, err
 }
+
+// GetEnv reads a setting from the environment, falling back to fallback when
+// it is unset.
+func GetEnv(key string, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// GetEnvInt reads an integer setting from the environment, falling back to
+// fallback when it is unset or not a number.
+func GetEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// GetEnvList reads a comma separated setting from the environment, skipping
+// empty items.
+func GetEnvList(key string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,127 @@
+package i18n
+
+var en = Catalog{
+	// Errors, keyed by error.<code> of their model.MyError
+	"error.internal_error":                  "Something went wrong, try again later!",
+	"error.invalid_request":                 "Invalid request!",
+	"error.validation_failed":               "Some fields are invalid!",
+	"error.invalid_email_or_password":       "Invalid email or password!",
+	"error.invalid_token":                   "Invalid token!",
+	"error.not_authorized":                  "Not Authorized!",
+	"error.not_found":                       "Not Found!",
+	"error.forbidden_access":                "Forbidden Access!",
+	"error.invalid_search_type":             "Invalid search type!",
+	"error.photo_already_in_album":          "Photo already in album!",
+	"error.photo_not_in_album":              "Photo is not in album!",
+	"error.invalid_album_order":             "Photo order must list every photo in the album exactly once!",
+	"error.already_bookmarked":              "Photo already bookmarked!",
+	"error.cannot_follow_self":              "You cannot follow yourself!",
+	"error.already_following":               "Already following or requested!",
+	"error.private_account":                 "This account is private!",
+	"error.cannot_block_self":               "You cannot block yourself!",
+	"error.cannot_mute_self":                "You cannot mute yourself!",
+	"error.already_blocked":                 "User already blocked!",
+	"error.already_muted":                   "User already muted!",
+	"error.blocked":                         "You cannot interact with this user!",
+	"error.cannot_report_self":              "You cannot report yourself or your own content!",
+	"error.already_reported":                "You already reported this!",
+	"error.report_closed":                   "Report is already closed!",
+	"error.report_claimed":                  "Report is claimed by another moderator!",
+	"error.invalid_moderation_action":       "Action does not apply to this report!",
+	"error.account_suspended":               "Account is suspended!",
+	"error.content_rejected":                "Content is not allowed!",
+	"error.blocked_words":                   "Text contains words that are not allowed!",
+	"error.duplicate_message":               "You already posted this message!",
+	"error.posting_too_fast":                "You are posting too fast, try again later!",
+	"error.edit_window_closed":              "Comment can no longer be edited!",
+	"error.invalid_reaction":                "Reaction is not supported!",
+	"error.already_reacted":                 "You already reacted with this emoji!",
+	"error.comments_disabled":               "Comments are turned off for this photo!",
+	"error.comments_followers_only":         "Only followers can comment on this photo!",
+	"error.pin_limit_reached":               "Pinned comments limit reached!",
+	"error.incomplete_location":             "Latitude and longitude must be set together!",
+	"error.invalid_coordinates":             "Latitude must be between -90 and 90 and longitude between -180 and 180!",
+	"error.invalid_radius":                  "Radius must be greater than 0 and at most 100 km!",
+	"error.unsupported_image":               "Photo must be a JPEG image!",
+	"error.image_too_large":                 "Photo is too large!",
+	"error.photo_url_required":              "Photo URL is required",
+	"error.invalid_media_count":             "A post must have between 1 and 10 media items!",
+	"error.photo_url_mismatch":              "Photo URL must be the first media item!",
+	"error.media_url_too_long":              "Media URL must be at most 255 characters!",
+	"error.alt_text_too_long":               "Alt text must be at most 255 characters!",
+	"error.invalid_alt_text":                "Alt text should describe the photo, not its file name or link!",
+	"error.duplicate_photo":                 "You already posted this photo!",
+	"error.photo_not_hashed":                "Only uploaded photos can be compared!",
+	"error.invalid_repost_distance":         "Max distance must be between 0 and 20!",
+	"error.invalid_photo_status":            "Status must be draft, scheduled or published!",
+	"error.invalid_publish_at":              "Scheduled photos need a publish time in the future!",
+	"error.photo_already_published":         "Published photos cannot go back to draft or scheduled!",
+	"error.invalid_participants":            "A conversation needs 1 to 9 other users!",
+	"error.cannot_message_self":             "You cannot start a conversation with yourself!",
+	"error.recipient_unavailable":           "This user can no longer receive messages!",
+	"error.empty_message":                   "Message needs a body or a photo!",
+	"error.message_too_long":                "Message must be at most 1000 characters!",
+	"error.invalid_social_media_platform":   "Platform must be one of instagram, github, linkedin, x, youtube, discord or custom!",
+	"error.invalid_social_media_url":        "Social Media URL does not match the platform!",
+	"error.duplicate_social_media":          "You already have a link for this platform!",
+	"error.verification_not_started":        "Request a verification challenge for this link first!",
+	"error.challenge_not_found":             "The verification challenge was not found on the linked page!",
+	"error.verification_fetch":              "The linked page could not be fetched!",
+	"error.invalid_social_media_visibility": "Visibility must be public, followers or private!",
+	"error.invalid_social_media_order":      "The new order must list each of your links exactly once!",
+	"error.photo_file_required":             "Photo file is required",
+	"error.unsupported_locale":              "Locale must be one of en or id!",
+
+	// Not found errors naming their resource
+	"error.not_found.bookmark": "Bookmark Not Found!",
+	"error.not_found.comment":  "Comment Not Found!",
+	"error.not_found.media":    "Media Not Found!",
+	"error.not_found.photo":    "Photo Not Found!",
+	"error.not_found.reaction": "Reaction Not Found!",
+	"error.not_found.story":    "Story Not Found!",
+	"error.not_found.user":     "User Not Found!",
+
+	// Page for links outside the platform catalog
+	"page.leaving.title":    "Leaving Mygram",
+	"page.leaving.body":     "This link was added by a user and leads to another site. Continue only if you trust it.",
+	"page.leaving.continue": "Continue to the site",
+
+	// Field errors, keyed by the messages of valid tags
+	"validation.invalid":                     "Invalid value",
+	"validation.type":                        "Must be of type {type}",
+	"validation.action_invalid":              "Action is invalid",
+	"validation.action_required":             "Action is required",
+	"validation.age_out_of_range":            "Age minimum is 8",
+	"validation.age_required":                "Age is required",
+	"validation.alt_text_too_long":           "Alt text must be at most 255 characters",
+	"validation.caption_too_long":            "Caption must be at most 255 characters",
+	"validation.collection_too_long":         "Collection name at most 50 characters",
+	"validation.comment_policy_invalid":      "Comment policy must be everyone, followers or off",
+	"validation.comment_policy_required":     "Comment policy is required",
+	"validation.email_invalid":               "Invalid email address",
+	"validation.email_required":              "Email is required",
+	"validation.media_url_invalid":           "Media URL must be a valid URL",
+	"validation.message_required":            "Message is required",
+	"validation.note_too_long":               "Note is too long",
+	"validation.password_required":           "Password is required",
+	"validation.password_too_short":          "Password atleast 6 characters",
+	"validation.photo_id_required":           "Photo ID is required",
+	"validation.photo_url_invalid":           "Photo URL must be a valid URL",
+	"validation.photo_url_too_long":          "Photo URL must be at most 255 characters",
+	"validation.place_name_too_long":         "Place name at most 100 characters",
+	"validation.query_required":              "Query is required",
+	"validation.reason_invalid":              "Reason is invalid",
+	"validation.reason_required":             "Reason is required",
+	"validation.social_media_name_too_long":  "Social Media name must be at most 255 characters",
+	"validation.social_media_url_invalid":    "Social Media URL must be a valid URL",
+	"validation.social_media_url_required":   "Social Media URL is required",
+	"validation.social_media_url_too_long":   "Social Media URL must be at most 255 characters",
+	"validation.status_invalid":              "Status is invalid",
+	"validation.suspend_days_out_of_range":   "Suspend days must be between 1 and 365",
+	"validation.title_required":              "Title is required",
+	"validation.title_too_long":              "Title must be at most 100 characters",
+	"validation.username_invalid_characters": "Username may only contain letters and digits and the characters . and _",
+	"validation.username_required":           "Username is required",
+	"validation.username_too_long":           "Username must be at most 30 characters",
+	"validation.visibility_invalid":          "Visibility must be public or private",
+}
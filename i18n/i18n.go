@@ -0,0 +1,117 @@
+// Package i18n holds the catalogs of messages clients see, keyed by stable
+// translation keys, and picks the language a request is answered in.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is answered when a client asks for no supported language.
+// Its catalog is the fallback for keys missing elsewhere.
+const DefaultLanguage = "en"
+
+// Catalog maps translation keys to messages. Messages may hold placeholders
+// in braces, as in {type}, replaced by the arguments given to Translate.
+type Catalog map[string]string
+
+var catalogs = map[string]Catalog{
+	"en": en,
+	"id": id,
+}
+
+// Languages lists the languages there is a catalog for.
+func Languages() []string {
+	languages := make([]string, 0, len(catalogs))
+	for language := range catalogs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Supported reports whether there is a catalog for language.
+func Supported(language string) bool {
+	_, ok := catalogs[language]
+	return ok
+}
+
+// Keys lists the translation keys of the catalog for language.
+func Keys(language string) []string {
+	keys := make([]string, 0, len(catalogs[language]))
+	for key := range catalogs[language] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Lookup finds the message for key in language, or in the default language
+// when that catalog misses it.
+func Lookup(language, key string) (string, bool) {
+	if message, ok := catalogs[language][key]; ok {
+		return message, true
+	}
+	message, ok := catalogs[DefaultLanguage][key]
+	return message, ok
+}
+
+// Translate is the message for key in language with its placeholders
+// replaced by args, given as name and value pairs. Unknown keys are returned
+// as they are.
+func Translate(language, key string, args ...string) string {
+	message, ok := Lookup(language, key)
+	if !ok {
+		message = key
+	}
+	if len(args) < 2 {
+		return message
+	}
+
+	pairs := make([]string, 0, len(args))
+	for i := 0; i+1 < len(args); i += 2 {
+		pairs = append(pairs, "{"+args[i]+"}", args[i+1])
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// Negotiate picks the supported language the client prefers most in an
+// Accept-Language header, as in "id-ID,id;q=0.9,en;q=0.8". Regions are
+// ignored and DefaultLanguage is picked when nothing else matches.
+func Negotiate(acceptLanguage string) string {
+	type preference struct {
+		language string
+		quality  float64
+	}
+
+	preferences := []preference{}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if language == "*" {
+			language = DefaultLanguage
+		}
+		if quality <= 0 || !Supported(language) {
+			continue
+		}
+		preferences = append(preferences, preference{language: language, quality: quality})
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+	if len(preferences) == 0 {
+		return DefaultLanguage
+	}
+	return preferences[0].language
+}
@@ -0,0 +1,127 @@
+package i18n
+
+var id = Catalog{
+	// Errors, keyed by error.<code> of their model.MyError
+	"error.internal_error":                  "Terjadi kesalahan, coba lagi nanti!",
+	"error.invalid_request":                 "Permintaan tidak valid!",
+	"error.validation_failed":               "Beberapa isian tidak valid!",
+	"error.invalid_email_or_password":       "Email atau kata sandi salah!",
+	"error.invalid_token":                   "Token tidak valid!",
+	"error.not_authorized":                  "Tidak Terotorisasi!",
+	"error.not_found":                       "Tidak Ditemukan!",
+	"error.forbidden_access":                "Akses Ditolak!",
+	"error.invalid_search_type":             "Jenis pencarian tidak valid!",
+	"error.photo_already_in_album":          "Foto sudah ada di album!",
+	"error.photo_not_in_album":              "Foto tidak ada di album!",
+	"error.invalid_album_order":             "Urutan foto harus memuat setiap foto di album tepat satu kali!",
+	"error.already_bookmarked":              "Foto sudah ditandai!",
+	"error.cannot_follow_self":              "Anda tidak dapat mengikuti diri sendiri!",
+	"error.already_following":               "Sudah mengikuti atau sudah meminta!",
+	"error.private_account":                 "Akun ini privat!",
+	"error.cannot_block_self":               "Anda tidak dapat memblokir diri sendiri!",
+	"error.cannot_mute_self":                "Anda tidak dapat membisukan diri sendiri!",
+	"error.already_blocked":                 "Pengguna sudah diblokir!",
+	"error.already_muted":                   "Pengguna sudah dibisukan!",
+	"error.blocked":                         "Anda tidak dapat berinteraksi dengan pengguna ini!",
+	"error.cannot_report_self":              "Anda tidak dapat melaporkan diri sendiri atau konten Anda sendiri!",
+	"error.already_reported":                "Anda sudah melaporkan ini!",
+	"error.report_closed":                   "Laporan sudah ditutup!",
+	"error.report_claimed":                  "Laporan sedang ditangani moderator lain!",
+	"error.invalid_moderation_action":       "Tindakan tidak berlaku untuk laporan ini!",
+	"error.account_suspended":               "Akun sedang ditangguhkan!",
+	"error.content_rejected":                "Konten tidak diizinkan!",
+	"error.blocked_words":                   "Teks berisi kata yang tidak diizinkan!",
+	"error.duplicate_message":               "Anda sudah mengirim pesan ini!",
+	"error.posting_too_fast":                "Anda mengirim terlalu cepat, coba lagi nanti!",
+	"error.edit_window_closed":              "Komentar sudah tidak dapat diubah!",
+	"error.invalid_reaction":                "Reaksi tidak didukung!",
+	"error.already_reacted":                 "Anda sudah bereaksi dengan emoji ini!",
+	"error.comments_disabled":               "Komentar dinonaktifkan untuk foto ini!",
+	"error.comments_followers_only":         "Hanya pengikut yang dapat mengomentari foto ini!",
+	"error.pin_limit_reached":               "Batas komentar yang disematkan tercapai!",
+	"error.incomplete_location":             "Lintang dan bujur harus diisi bersamaan!",
+	"error.invalid_coordinates":             "Lintang harus antara -90 dan 90 dan bujur antara -180 dan 180!",
+	"error.invalid_radius":                  "Radius harus lebih dari 0 dan paling banyak 100 km!",
+	"error.unsupported_image":               "Foto harus berupa gambar JPEG!",
+	"error.image_too_large":                 "Foto terlalu besar!",
+	"error.photo_url_required":              "URL foto wajib diisi",
+	"error.invalid_media_count":             "Sebuah unggahan harus memiliki 1 sampai 10 media!",
+	"error.photo_url_mismatch":              "URL foto harus sama dengan media pertama!",
+	"error.media_url_too_long":              "URL media paling banyak 255 karakter!",
+	"error.alt_text_too_long":               "Teks alternatif paling banyak 255 karakter!",
+	"error.invalid_alt_text":                "Teks alternatif harus menggambarkan foto, bukan nama file atau tautannya!",
+	"error.duplicate_photo":                 "Anda sudah mengunggah foto ini!",
+	"error.photo_not_hashed":                "Hanya foto yang diunggah yang dapat dibandingkan!",
+	"error.invalid_repost_distance":         "Jarak maksimum harus antara 0 dan 20!",
+	"error.invalid_photo_status":            "Status harus draft, scheduled atau published!",
+	"error.invalid_publish_at":              "Foto terjadwal membutuhkan waktu terbit di masa depan!",
+	"error.photo_already_published":         "Foto yang sudah terbit tidak dapat kembali ke draft atau scheduled!",
+	"error.invalid_participants":            "Percakapan membutuhkan 1 sampai 9 pengguna lain!",
+	"error.cannot_message_self":             "Anda tidak dapat memulai percakapan dengan diri sendiri!",
+	"error.recipient_unavailable":           "Pengguna ini tidak dapat lagi menerima pesan!",
+	"error.empty_message":                   "Pesan membutuhkan isi atau foto!",
+	"error.message_too_long":                "Pesan paling banyak 1000 karakter!",
+	"error.invalid_social_media_platform":   "Platform harus salah satu dari instagram, github, linkedin, x, youtube, discord atau custom!",
+	"error.invalid_social_media_url":        "URL media sosial tidak sesuai dengan platformnya!",
+	"error.duplicate_social_media":          "Anda sudah memiliki tautan untuk platform ini!",
+	"error.verification_not_started":        "Minta tantangan verifikasi untuk tautan ini terlebih dahulu!",
+	"error.challenge_not_found":             "Tantangan verifikasi tidak ditemukan di halaman yang ditautkan!",
+	"error.verification_fetch":              "Halaman yang ditautkan tidak dapat diambil!",
+	"error.invalid_social_media_visibility": "Visibilitas harus public, followers atau private!",
+	"error.invalid_social_media_order":      "Urutan baru harus memuat setiap tautan Anda tepat satu kali!",
+	"error.photo_file_required":             "File foto wajib diisi",
+	"error.unsupported_locale":              "Bahasa harus salah satu dari en atau id!",
+
+	// Not found errors naming their resource
+	"error.not_found.bookmark": "Penanda Tidak Ditemukan!",
+	"error.not_found.comment":  "Komentar Tidak Ditemukan!",
+	"error.not_found.media":    "Media Tidak Ditemukan!",
+	"error.not_found.photo":    "Foto Tidak Ditemukan!",
+	"error.not_found.reaction": "Reaksi Tidak Ditemukan!",
+	"error.not_found.story":    "Cerita Tidak Ditemukan!",
+	"error.not_found.user":     "Pengguna Tidak Ditemukan!",
+
+	// Page for links outside the platform catalog
+	"page.leaving.title":    "Meninggalkan Mygram",
+	"page.leaving.body":     "Tautan ini ditambahkan oleh pengguna dan mengarah ke situs lain. Lanjutkan hanya jika Anda mempercayainya.",
+	"page.leaving.continue": "Lanjutkan ke situs",
+
+	// Field errors, keyed by the messages of valid tags
+	"validation.invalid":                     "Nilai tidak valid",
+	"validation.type":                        "Harus bertipe {type}",
+	"validation.action_invalid":              "Tindakan tidak valid",
+	"validation.action_required":             "Tindakan wajib diisi",
+	"validation.age_out_of_range":            "Umur minimal 8 tahun",
+	"validation.age_required":                "Umur wajib diisi",
+	"validation.alt_text_too_long":           "Teks alternatif paling banyak 255 karakter",
+	"validation.caption_too_long":            "Keterangan paling banyak 255 karakter",
+	"validation.collection_too_long":         "Nama koleksi paling banyak 50 karakter",
+	"validation.comment_policy_invalid":      "Kebijakan komentar harus everyone, followers atau off",
+	"validation.comment_policy_required":     "Kebijakan komentar wajib diisi",
+	"validation.email_invalid":               "Alamat email tidak valid",
+	"validation.email_required":              "Email wajib diisi",
+	"validation.media_url_invalid":           "URL media harus berupa URL yang valid",
+	"validation.message_required":            "Pesan wajib diisi",
+	"validation.note_too_long":               "Catatan terlalu panjang",
+	"validation.password_required":           "Kata sandi wajib diisi",
+	"validation.password_too_short":          "Kata sandi minimal 6 karakter",
+	"validation.photo_id_required":           "ID foto wajib diisi",
+	"validation.photo_url_invalid":           "URL foto harus berupa URL yang valid",
+	"validation.photo_url_too_long":          "URL foto paling banyak 255 karakter",
+	"validation.place_name_too_long":         "Nama tempat paling banyak 100 karakter",
+	"validation.query_required":              "Kata kunci wajib diisi",
+	"validation.reason_invalid":              "Alasan tidak valid",
+	"validation.reason_required":             "Alasan wajib diisi",
+	"validation.social_media_name_too_long":  "Nama media sosial paling banyak 255 karakter",
+	"validation.social_media_url_invalid":    "URL media sosial harus berupa URL yang valid",
+	"validation.social_media_url_required":   "URL media sosial wajib diisi",
+	"validation.social_media_url_too_long":   "URL media sosial paling banyak 255 karakter",
+	"validation.status_invalid":              "Status tidak valid",
+	"validation.suspend_days_out_of_range":   "Lama penangguhan harus antara 1 dan 365 hari",
+	"validation.title_required":              "Judul wajib diisi",
+	"validation.title_too_long":              "Judul paling banyak 100 karakter",
+	"validation.username_invalid_characters": "Nama pengguna hanya boleh berisi huruf, angka, titik dan garis bawah",
+	"validation.username_required":           "Nama pengguna wajib diisi",
+	"validation.username_too_long":           "Nama pengguna paling banyak 30 karakter",
+	"validation.visibility_invalid":          "Visibilitas harus public atau private",
+}
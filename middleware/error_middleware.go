@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"mygram/helper"
+	"mygram/i18n"
+	"mygram/model"
+	"mygram/repository"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorMiddleware answers requests a handler aborted with ctx.Error. A MyError
+// is answered with its status, code and details. Any other error is logged
+// under a correlation ID and answered as an internal error carrying only that
+// ID, so database and library messages never reach clients. Errors use the
+// RFC 7807 problem format when problemJSON is set or the client accepts it.
+//
+// Messages are answered in the locale of the signed in user, or else the
+// language picked from the Accept-Language header.
+func ErrorMiddleware(problemJSON bool, userRepository repository.IUserRepository) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+		err := ctx.Errors.Last().Err
+
+		myError := model.ErrorInternal
+		correlationId := ""
+		if !errors.As(err, &myError) {
+			correlationId = helper.GenerateID()
+			log.Printf("error %s: %s %s: %v", correlationId, ctx.Request.Method, ctx.Request.URL.Path, err)
+			ctx.Header("X-Correlation-ID", correlationId)
+		}
+		status := myError.Status
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		myError = translateError(myError, requestLanguage(ctx, userRepository))
+
+		if problemJSON || strings.Contains(ctx.GetHeader("Accept"), model.ProblemContentType) {
+			ctx.Header("Content-Type", model.ProblemContentType)
+			ctx.JSON(status, model.ResponseProblem{
+				Type:          "about:blank",
+				Title:         http.StatusText(status),
+				Status:        status,
+				Detail:        myError.Err,
+				Instance:      ctx.Request.URL.Path,
+				Code:          myError.Code,
+				Details:       myError.Details,
+				CorrelationID: correlationId,
+			})
+			return
+		}
+
+		ctx.JSON(status, model.ResponseFailed{
+			Meta: model.Meta{
+				Code:    status,
+				Message: http.StatusText(status),
+			},
+			Error:         myError.Err,
+			ErrorCode:     myError.Code,
+			Details:       myError.Details,
+			CorrelationID: correlationId,
+		})
+	}
+}
+
+// requestLanguage is the locale stored for the signed in user, or the
+// language the client prefers in its Accept-Language header.
+func requestLanguage(ctx *gin.Context, userRepository repository.IUserRepository) string {
+	if userId, isExist := ctx.Get("user_id"); isExist {
+		if id, ok := userId.(string); ok {
+			user, err := userRepository.GetOne(id)
+			if err == nil && i18n.Supported(user.Locale) {
+				return user.Locale
+			}
+		}
+	}
+	return i18n.Negotiate(ctx.GetHeader("Accept-Language"))
+}
+
+// translateError returns myError with its message and field messages in
+// language. Messages without a catalog key are kept as they are.
+func translateError(myError model.MyError, language string) model.MyError {
+	if message, ok := i18n.Lookup(language, myError.MessageKey()); ok {
+		myError.Err = message
+	}
+	if len(myError.Details) == 0 {
+		return myError
+	}
+
+	details := make([]model.FieldError, len(myError.Details))
+	for i, detail := range myError.Details {
+		if detail.Key != "" {
+			detail.Message = i18n.Translate(language, detail.Key, detail.Args...)
+		}
+		details[i] = detail
+	}
+	myError.Details = details
+	return myError
+}
@@ -3,7 +3,6 @@ package middleware
 import (
 	"mygram/helper"
 	"mygram/model"
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -13,51 +12,24 @@ import (
 func AuthMiddleware(ctx *gin.Context) {
 	auth := ctx.GetHeader("Authorization")
 
-	if auth == "" {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusUnauthorized,
-				Message: http.StatusText(http.StatusUnauthorized),
-			},
-			Error: model.ErrorNotAuthorized.Err,
-		})
+	parts := strings.Split(auth, " ")
+	if auth == "" || len(parts) != 2 || parts[1] == "" {
+		ctx.Error(model.ErrorNotAuthorized)
+		ctx.Abort()
 		return
 	}
-	token := strings.Split(auth, " ")[1]
-
-	if token == "" {
-		ctx.AbortWithStatusJSON(http.StatusUnauthorized, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusUnauthorized,
-				Message: http.StatusText(http.StatusUnauthorized),
-			},
-			Error: model.ErrorNotAuthorized.Err,
-		})
-		return
-	}
-
-	jwtToken, err := helper.VerifyToken(token)
 
+	jwtToken, err := helper.VerifyToken(parts[1])
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		ctx.Error(model.ErrorInvalidToken)
+		ctx.Abort()
 		return
 	}
 
 	claims, ok := jwtToken.Claims.(jwt.MapClaims)
 	if !ok {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.ResponseFailed{
-			Meta: model.Meta{
-				Code:    http.StatusInternalServerError,
-				Message: http.StatusText(http.StatusInternalServerError),
-			},
-			Error: err.Error(),
-		})
+		ctx.Error(model.ErrorInvalidToken)
+		ctx.Abort()
 		return
 	}
 
@@ -65,3 +37,22 @@ func AuthMiddleware(ctx *gin.Context) {
 
 	ctx.Next()
 }
+
+// OptionalAuthMiddleware sets user_id like AuthMiddleware when a valid token is
+// given and lets the request through anonymously otherwise.
+func OptionalAuthMiddleware(ctx *gin.Context) {
+	parts := strings.Split(ctx.GetHeader("Authorization"), " ")
+	if len(parts) != 2 || parts[1] == "" {
+		ctx.Next()
+		return
+	}
+
+	jwtToken, err := helper.VerifyToken(parts[1])
+	if err == nil {
+		if claims, ok := jwtToken.Claims.(jwt.MapClaims); ok {
+			ctx.Set("user_id", claims["user_id"])
+		}
+	}
+
+	ctx.Next()
+}
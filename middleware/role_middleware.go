@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleMiddleware only lets users holding one of roles through. It has to run
+// after AuthMiddleware, which sets the user_id it looks up.
+func RoleMiddleware(userRepository repository.IUserRepository, roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId, isExist := ctx.Get("user_id")
+		if !isExist {
+			ctx.Error(model.ErrorInvalidToken)
+			ctx.Abort()
+			return
+		}
+
+		user, err := userRepository.GetOne(userId.(string))
+		if err != nil {
+			if errors.Is(err, model.ErrorNotFound) {
+				err = model.ErrorNotAuthorized
+			}
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		for _, role := range roles {
+			if user.Role == role {
+				ctx.Set("user_role", user.Role)
+				ctx.Next()
+				return
+			}
+		}
+
+		ctx.Error(model.ErrorForbiddenAccess)
+		ctx.Abort()
+	}
+}
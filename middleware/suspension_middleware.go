@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SuspensionMiddleware refuses writes from suspended users, whose tokens stay
+// valid until they expire even though login is refused. Reads go through. It
+// has to run after AuthMiddleware, which sets the user_id it looks up.
+func SuspensionMiddleware(userRepository repository.IUserRepository) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead {
+			ctx.Next()
+			return
+		}
+
+		userId, isExist := ctx.Get("user_id")
+		if !isExist {
+			ctx.Error(model.ErrorInvalidToken)
+			ctx.Abort()
+			return
+		}
+
+		user, err := userRepository.GetOne(userId.(string))
+		if err != nil {
+			if errors.Is(err, model.ErrorNotFound) {
+				err = model.ErrorNotAuthorized
+			}
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		if user.IsSuspended(time.Now()) {
+			ctx.Error(model.ErrorAccountSuspended)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,107 @@
+package model
+
+import "time"
+
+const (
+	AlbumVisibilityPublic  = "public"
+	AlbumVisibilityPrivate = "private"
+)
+
+type Album struct {
+	ID           string `gorm:"primaryKey"`
+	UserID       string `gorm:"not null;index"`
+	Title        string `gorm:"not null;type:varchar(100)"`
+	Description  string `gorm:"not null;type:varchar(255)"`
+	Visibility   string `gorm:"not null;type:varchar(10);default:public"`
+	CoverPhotoID *string
+	AlbumPhotos  []AlbumPhoto
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type AlbumPhoto struct {
+	AlbumID   string `gorm:"primaryKey"`
+	PhotoID   string `gorm:"primaryKey;index"`
+	Position  int    `gorm:"not null"`
+	Photo     Photo
+	CreatedAt time.Time
+}
+
+// Request
+type AlbumCreateRequest struct {
+	Title       string `json:"title" valid:"required~validation.title_required"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility" valid:"in(public|private)~validation.visibility_invalid"`
+}
+
+type AlbumUpdateRequest struct {
+	Title       string `json:"title" valid:"required~validation.title_required"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility" valid:"in(public|private)~validation.visibility_invalid"`
+}
+
+type AlbumPhotoRequest struct {
+	PhotoID string `json:"photo_id" valid:"required~validation.photo_id_required"`
+}
+
+type AlbumReorderRequest struct {
+	PhotoIDs []string `json:"photo_ids"`
+}
+
+// Response
+type AlbumResponse struct {
+	ID           string                 `json:"id"`
+	UserID       string                 `json:"user_id"`
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	Visibility   string                 `json:"visibility"`
+	CoverPhotoID string                 `json:"cover_photo_id"`
+	Photos       []PhotoInAlbumResponse `json:"photos"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+type PhotoInAlbumResponse struct {
+	ID       string `json:"id"`
+	Position int    `json:"position"`
+	Title    string `json:"title"`
+	Caption  string `json:"caption"`
+	PhotoURL string `json:"photo_url"`
+}
+
+type DeleteAlbumResponse struct {
+	Message string `json:"message"`
+}
+
+// ToAlbumResponse falls back to the first photo as cover when none was selected.
+func ToAlbumResponse(album Album) AlbumResponse {
+	photos := make([]PhotoInAlbumResponse, 0)
+	for _, albumPhoto := range album.AlbumPhotos {
+		photos = append(photos, PhotoInAlbumResponse{
+			ID:       albumPhoto.PhotoID,
+			Position: albumPhoto.Position,
+			Title:    albumPhoto.Photo.Title,
+			Caption:  albumPhoto.Photo.Caption,
+			PhotoURL: albumPhoto.Photo.PhotoURL,
+		})
+	}
+
+	coverPhotoID := ""
+	if album.CoverPhotoID != nil {
+		coverPhotoID = *album.CoverPhotoID
+	} else if len(photos) > 0 {
+		coverPhotoID = photos[0].ID
+	}
+
+	return AlbumResponse{
+		ID:           album.ID,
+		UserID:       album.UserID,
+		Title:        album.Title,
+		Description:  album.Description,
+		Visibility:   album.Visibility,
+		CoverPhotoID: coverPhotoID,
+		Photos:       photos,
+		CreatedAt:    album.CreatedAt,
+		UpdatedAt:    album.UpdatedAt,
+	}
+}
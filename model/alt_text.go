@@ -0,0 +1,96 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const MaxAltTextLength = 255
+
+// fileNamePattern matches alt text that is only an image file name, which
+// screen readers would read out instead of a description.
+var fileNamePattern = regexp.MustCompile(`(?i)^[\w\-. ]+\.(jpe?g|png|gif|webp|heic|bmp|tiff?)$`)
+
+// NormalizeAltText trims the alt text and collapses runs of whitespace.
+func NormalizeAltText(altText string) string {
+	return strings.Join(strings.Fields(altText), " ")
+}
+
+// ValidateAltText checks normalized alt text. Empty alt text is valid, it
+// is filled with a suggestion or reported as missing.
+func ValidateAltText(altText string) error {
+	if len([]rune(altText)) > MaxAltTextLength {
+		return ErrorAltTextTooLong
+	}
+	for _, r := range altText {
+		if unicode.IsControl(r) {
+			return ErrorInvalidAltText
+		}
+	}
+	if fileNamePattern.MatchString(altText) || strings.HasPrefix(strings.ToLower(altText), "http") {
+		return ErrorInvalidAltText
+	}
+	return nil
+}
+
+// JoinAltText is the alt text of every media item, one line per item, as kept
+// on the photo for search.
+func JoinAltText(media []PhotoMedia) string {
+	lines := make([]string, 0, len(media))
+	for _, item := range media {
+		if item.AltText != "" {
+			lines = append(lines, item.AltText)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CaptionRequest is what a captioner knows about the media item it describes.
+type CaptionRequest struct {
+	URL      string
+	Title    string
+	Caption  string
+	Position int
+	Count    int
+}
+
+// Response
+type PhotoMissingAltTextResponse struct {
+	PhotoID  string `json:"photo_id"`
+	Title    string `json:"title"`
+	PhotoURL string `json:"photo_url"`
+	// MissingPositions have no alt text, SuggestedPositions only an
+	// automatic suggestion worth reviewing.
+	MissingPositions   []int     `json:"missing_positions"`
+	SuggestedPositions []int     `json:"suggested_positions"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type PhotoMissingAltTextListResponse struct {
+	Photos     []PhotoMissingAltTextResponse `json:"photos"`
+	Pagination PaginationResponse            `json:"pagination"`
+}
+
+func ToPhotoMissingAltTextResponse(photo Photo) PhotoMissingAltTextResponse {
+	missing := make([]int, 0)
+	suggested := make([]int, 0)
+	for _, item := range ToPhotoMediaResponse(photo) {
+		switch {
+		case item.AltText == "":
+			missing = append(missing, item.Position)
+		case item.AltTextGenerated:
+			suggested = append(suggested, item.Position)
+		}
+	}
+
+	return PhotoMissingAltTextResponse{
+		PhotoID:            photo.ID,
+		Title:              photo.Title,
+		PhotoURL:           photo.PhotoURL,
+		MissingPositions:   missing,
+		SuggestedPositions: suggested,
+		CreatedAt:          photo.CreatedAt,
+	}
+}
@@ -18,6 +18,26 @@ type ResponseSuccess struct {
 }
 
 type ResponseFailed struct {
-	Meta  Meta   `json:"meta"`
-	Error string `json:"error"`
+	Meta          Meta         `json:"meta"`
+	Error         string       `json:"error"`
+	ErrorCode     string       `json:"error_code,omitempty"`
+	Details       []FieldError `json:"details,omitempty"`
+	CorrelationID string       `json:"correlation_id,omitempty"`
+}
+
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// ResponseProblem is an error response in the RFC 7807 problem details
+// format, with the error code, field details and correlation ID as extension
+// members.
+type ResponseProblem struct {
+	Type          string       `json:"type"`
+	Title         string       `json:"title"`
+	Status        int          `json:"status"`
+	Detail        string       `json:"detail"`
+	Instance      string       `json:"instance,omitempty"`
+	Code          string       `json:"code"`
+	Details       []FieldError `json:"details,omitempty"`
+	CorrelationID string       `json:"correlation_id,omitempty"`
 }
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Block hides both users' content from each other and stops any interaction
+// between them in either direction.
+type Block struct {
+	BlockerID string `gorm:"primaryKey"`
+	BlockedID string `gorm:"primaryKey;index"`
+	Blocked   User   `gorm:"foreignKey:BlockedID"`
+	CreatedAt time.Time
+}
+
+// Mute only hides the muted user's content from the muter's feed.
+type Mute struct {
+	MuterID   string `gorm:"primaryKey"`
+	MutedID   string `gorm:"primaryKey;index"`
+	Muted     User   `gorm:"foreignKey:MutedID"`
+	CreatedAt time.Time
+}
+
+// Response
+type UserRelationResponse struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
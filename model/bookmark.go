@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+const DefaultBookmarkCollection = "Saved"
+
+type Bookmark struct {
+	ID         string `gorm:"primaryKey"`
+	UserID     string `gorm:"not null;uniqueIndex:idx_bookmarks_user_photo"`
+	PhotoID    string `gorm:"not null;uniqueIndex:idx_bookmarks_user_photo;index"`
+	Collection string `gorm:"not null;type:varchar(50)"`
+	Photo      Photo
+	CreatedAt  time.Time
+}
+
+// Request
+type BookmarkCreateRequest struct {
+	Collection string `json:"collection" valid:"maxstringlength(50)~validation.collection_too_long"`
+}
+
+type BookmarkListRequest struct {
+	Collection string `form:"collection"`
+	PaginationRequest
+}
+
+// Response
+type BookmarkResponse struct {
+	ID         string                  `json:"id"`
+	PhotoID    string                  `json:"photo_id"`
+	Collection string                  `json:"collection"`
+	Photo      PhotoInBookmarkResponse `json:"photo"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+type PhotoInBookmarkResponse struct {
+	ID       string `json:"id"`
+	UserID   string `json:"user_id"`
+	Title    string `json:"title"`
+	Caption  string `json:"caption"`
+	PhotoURL string `json:"photo_url"`
+}
+
+type BookmarkListResponse struct {
+	Bookmarks  []BookmarkResponse `json:"bookmarks"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+func ToBookmarkResponse(bookmark Bookmark) BookmarkResponse {
+	return BookmarkResponse{
+		ID:         bookmark.ID,
+		PhotoID:    bookmark.PhotoID,
+		Collection: bookmark.Collection,
+		Photo: PhotoInBookmarkResponse{
+			ID:       bookmark.Photo.ID,
+			UserID:   bookmark.Photo.UserID,
+			Title:    bookmark.Photo.Title,
+			Caption:  bookmark.Photo.Caption,
+			PhotoURL: bookmark.Photo.PhotoURL,
+		},
+		CreatedAt: bookmark.CreatedAt,
+	}
+}
@@ -4,59 +4,104 @@ import "time"
 
 type Comment struct {
 	ID        string `gorm:"primaryKey"`
-	UserID    string
-	PhotoID   string
+	UserID    string `gorm:"index:idx_comments_user_created_at"`
+	PhotoID   string `gorm:"index:idx_comments_photo_created_at"`
 	Message   string `gorm:"not null"`
-	CreatedAt time.Time
+	Hidden    bool   `gorm:"not null;default:false"`
+	EditedAt  *time.Time
+	PinnedAt  *time.Time
+	Revisions []CommentRevision
+	Reactions []CommentReaction
+	CreatedAt time.Time `gorm:"index:idx_comments_user_created_at;index:idx_comments_photo_created_at"`
 	UpdatedAt time.Time
+
+	SearchVector string `gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(message, ''))) STORED;index:idx_comments_search_vector,type:gin"`
+}
+
+// CommentRevision keeps a message as it was before an edit replaced it.
+type CommentRevision struct {
+	ID        string `gorm:"primaryKey"`
+	CommentID string `gorm:"not null;index"`
+	Message   string `gorm:"not null"`
+	CreatedAt time.Time
 }
 
 // Request
 type CommentCreateRequest struct {
-	Message string `json:"message" valid:"required~Message is required"`
+	Message string `json:"message" valid:"required~validation.message_required"`
 }
 
 type CommentUpdateRequest struct {
-	Message string `json:"message" valid:"required~Message is required"`
+	Message string `json:"message" valid:"required~validation.message_required"`
 }
 
 // Response
 type CommentCreateResponse struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	PhotoID   string    `json:"photo_id"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            string    `json:"id"`
+	UserID        string    `json:"user_id"`
+	PhotoID       string    `json:"photo_id"`
+	Message       string    `json:"message"`
+	PendingReview bool      `json:"pending_review"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 type CommentResponse struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	PhotoID   string    `json:"photo_id"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string           `json:"id"`
+	UserID    string           `json:"user_id"`
+	PhotoID   string           `json:"photo_id"`
+	Message   string           `json:"message"`
+	Edited    bool             `json:"edited"`
+	EditedAt  *time.Time       `json:"edited_at"`
+	Pinned    bool             `json:"pinned"`
+	Reactions map[string]int64 `json:"reactions"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
 }
 
 type CommentUpdateResponse struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"user_id"`
+	PhotoID       string     `json:"photo_id"`
+	Message       string     `json:"message"`
+	PendingReview bool       `json:"pending_review"`
+	Edited        bool       `json:"edited"`
+	EditedAt      *time.Time `json:"edited_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// CommentRevisionResponse is a previous version of a comment, CreatedAt is
+// when the edit replaced it.
+type CommentRevisionResponse struct {
 	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	PhotoID   string    `json:"photo_id"`
+	CommentID string    `json:"comment_id"`
 	Message   string    `json:"message"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CommentListResponse struct {
+	Comments   []CommentResponse  `json:"comments"`
+	Pagination PaginationResponse `json:"pagination"`
 }
 
 type DeleteCommentResponse struct {
 	Message string `json:"message"`
 }
 
-func ToCommentResponse(comment Comment) CommentResponse {
+// ToCommentResponse maps a comment, reactions holds its counts by emoji.
+func ToCommentResponse(comment Comment, reactions map[string]int64) CommentResponse {
+	if reactions == nil {
+		reactions = make(map[string]int64)
+	}
 	return CommentResponse{
 		ID:        comment.ID,
-		UserID:    comment.ID,
+		UserID:    comment.UserID,
 		PhotoID:   comment.PhotoID,
 		Message:   comment.Message,
+		Edited:    comment.EditedAt != nil,
+		EditedAt:  comment.EditedAt,
+		Pinned:    comment.PinnedAt != nil,
+		Reactions: reactions,
 		CreatedAt: comment.CreatedAt,
 		UpdatedAt: comment.UpdatedAt,
 	}
@@ -64,11 +109,23 @@ func ToCommentResponse(comment Comment) CommentResponse {
 
 func ToCommentUpdateResponse(comment Comment) CommentUpdateResponse {
 	return CommentUpdateResponse{
-		ID:        comment.ID,
-		UserID:    comment.UserID,
-		PhotoID:   comment.PhotoID,
-		Message:   comment.Message,
-		CreatedAt: comment.CreatedAt,
-		UpdatedAt: comment.UpdatedAt,
+		ID:            comment.ID,
+		UserID:        comment.UserID,
+		PhotoID:       comment.PhotoID,
+		Message:       comment.Message,
+		PendingReview: comment.Hidden,
+		Edited:        comment.EditedAt != nil,
+		EditedAt:      comment.EditedAt,
+		CreatedAt:     comment.CreatedAt,
+		UpdatedAt:     comment.UpdatedAt,
+	}
+}
+
+func ToCommentRevisionResponse(revision CommentRevision) CommentRevisionResponse {
+	return CommentRevisionResponse{
+		ID:        revision.ID,
+		CommentID: revision.CommentID,
+		Message:   revision.Message,
+		CreatedAt: revision.CreatedAt,
 	}
 }
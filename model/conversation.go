@@ -0,0 +1,127 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxConversationParticipants caps group conversations, the creator
+	// included.
+	MaxConversationParticipants = 10
+	MaxMessageLength            = 1000
+)
+
+// Conversation is a one-to-one or small group chat. One-to-one conversations
+// carry a DirectKey built from both user ids, so a pair of users only ever
+// has one.
+type Conversation struct {
+	ID            string  `gorm:"primaryKey"`
+	IsGroup       bool    `gorm:"not null;default:false"`
+	Title         string  `gorm:"not null;type:varchar(100);default:''"`
+	DirectKey     *string `gorm:"uniqueIndex;type:varchar(100)"`
+	Participants  []ConversationParticipant
+	LastMessageAt *time.Time `gorm:"index"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ConversationParticipant is a member of a conversation. LastReadAt is the
+// read receipt, every message up to it counts as read.
+type ConversationParticipant struct {
+	ConversationID string `gorm:"primaryKey"`
+	UserID         string `gorm:"primaryKey;index"`
+	LastReadAt     *time.Time
+	CreatedAt      time.Time
+}
+
+// Message is sent to a conversation with a body, a shared photo or both.
+// Messages stay when their sender's account is gone.
+type Message struct {
+	ID             string  `gorm:"primaryKey"`
+	ConversationID string  `gorm:"not null;index:idx_messages_conversation_created"`
+	SenderID       string  `gorm:"not null"`
+	Body           string  `gorm:"not null;type:text;default:''"`
+	PhotoID        *string `gorm:"index"`
+	Photo          *Photo
+	CreatedAt      time.Time `gorm:"index:idx_messages_conversation_created"`
+}
+
+// UnreadCount is the number of unread messages of a conversation.
+type UnreadCount struct {
+	ConversationID string
+	Count          int64
+}
+
+// DirectConversationKey identifies the one-to-one conversation of two users
+// regardless of who started it.
+func DirectConversationKey(userId string, otherId string) string {
+	ids := []string{userId, otherId}
+	sort.Strings(ids)
+	return strings.Join(ids, ":")
+}
+
+// Request
+type ConversationCreateRequest struct {
+	// Usernames are the other participants. A single one starts or reopens a
+	// one-to-one conversation, more start a group.
+	Usernames []string `json:"usernames"`
+	Title     string   `json:"title" valid:"maxstringlength(100)~validation.title_too_long"`
+}
+
+type MessageCreateRequest struct {
+	Body string `json:"body"`
+	// PhotoID optionally shares a photo the sender can see.
+	PhotoID string `json:"photo_id"`
+}
+
+// Response
+type ConversationParticipantResponse struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	// Deleted marks participants whose account no longer exists.
+	Deleted    bool       `json:"deleted"`
+	LastReadAt *time.Time `json:"last_read_at"`
+}
+
+type ConversationResponse struct {
+	ID           string                            `json:"id"`
+	IsGroup      bool                              `json:"is_group"`
+	Title        string                            `json:"title"`
+	Participants []ConversationParticipantResponse `json:"participants"`
+	LastMessage  *MessageResponse                  `json:"last_message"`
+	UnreadCount  int64                             `json:"unread_count"`
+	CreatedAt    time.Time                         `json:"created_at"`
+	UpdatedAt    time.Time                         `json:"updated_at"`
+}
+
+type ConversationListResponse struct {
+	Conversations []ConversationResponse `json:"conversations"`
+	Pagination    PaginationResponse     `json:"pagination"`
+}
+
+// MessagePhotoResponse is a shared photo. It is left out of messages whose
+// viewer may not see the photo.
+type MessagePhotoResponse struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	PhotoURL string `json:"photo_url"`
+}
+
+type MessageResponse struct {
+	ID             string                `json:"id"`
+	ConversationID string                `json:"conversation_id"`
+	SenderID       string                `json:"sender_id"`
+	Body           string                `json:"body"`
+	Photo          *MessagePhotoResponse `json:"photo"`
+	// ReadBy lists the other participants who read the message.
+	ReadBy    []string  `json:"read_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MessageListResponse struct {
+	ConversationID string             `json:"conversation_id"`
+	Messages       []MessageResponse  `json:"messages"`
+	Pagination     PaginationResponse `json:"pagination"`
+}
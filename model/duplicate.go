@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+const (
+	DuplicatePhotoWarn   = "warn"
+	DuplicatePhotoReject = "reject"
+
+	// DefaultRepostDistance is the Hamming distance between perceptual
+	// hashes under which moderators see photos as likely reposts.
+	DefaultRepostDistance = 10
+	MaxRepostDistance     = 20
+	MaxRepostResults      = 50
+)
+
+// PhotoSimilarity is a photo with the Hamming distance of its perceptual hash
+// to the one searched for.
+type PhotoSimilarity struct {
+	Photo    Photo
+	Distance int
+}
+
+// Request
+type PhotoRepostRequest struct {
+	MaxDistance int `form:"max_distance"`
+}
+
+// Response
+type PhotoRepostResponse struct {
+	ID       string `json:"id"`
+	UserID   string `json:"user_id"`
+	Title    string `json:"title"`
+	PhotoURL string `json:"photo_url"`
+	Hidden   bool   `json:"hidden"`
+	Distance int    `json:"distance"`
+	// PostedBefore marks reposts older than the photo they were compared to,
+	// which is then the likely copy.
+	PostedBefore bool      `json:"posted_before"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type PhotoRepostListResponse struct {
+	PhotoID string                `json:"photo_id"`
+	Reposts []PhotoRepostResponse `json:"reposts"`
+}
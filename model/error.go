@@ -1,30 +1,498 @@
 package model
 
+import (
+	"mygram/i18n"
+	"net/http"
+)
+
+// MyError is an error meant for the client. Status is the HTTP status it is
+// answered with and Code a stable identifier clients can match on, while Err
+// may be reworded. Errors are matched by Code with errors.Is, so copies
+// carrying another message or field details still match their sentinel.
+//
+// Err is the English message. Clients are answered in their language with the
+// catalog message under Key, or under error.<code> when Key is empty.
 type MyError struct {
-	Err string `json:"error"`
+	Err     string       `json:"error"`
+	Status  int          `json:"-"`
+	Code    string       `json:"code"`
+	Key     string       `json:"-"`
+	Details []FieldError `json:"details,omitempty"`
+
+	// literal marks messages that have no translation, such as those of
+	// decoding errors.
+	literal bool
+}
+
+// FieldError points at the request field an error is about. Message is in
+// English, clients get the catalog message under Key with Args filled in.
+type FieldError struct {
+	Field   string   `json:"field"`
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Key     string   `json:"-"`
+	Args    []string `json:"-"`
 }
 
 func (me MyError) Error() string {
 	return me.Err
 }
 
+func (me MyError) Is(target error) bool {
+	other, ok := target.(MyError)
+	return ok && other.Code == me.Code
+}
+
+// WithMessage returns the error with message as its text, which is then
+// answered untranslated.
+func (me MyError) WithMessage(message string) MyError {
+	me.Err = message
+	me.literal = true
+	return me
+}
+
+// WithKey returns the error with the catalog message under key as its text.
+func (me MyError) WithKey(key string) MyError {
+	me.Err = i18n.Translate(i18n.DefaultLanguage, key)
+	me.Key = key
+	me.literal = false
+	return me
+}
+
+// MessageKey is the catalog key of the error message, empty when the message
+// has no translation.
+func (me MyError) MessageKey() string {
+	switch {
+	case me.literal:
+		return ""
+	case me.Key != "":
+		return me.Key
+	case me.Code != "":
+		return "error." + me.Code
+	}
+	return ""
+}
+
+// WithDetails returns the error listing the fields it is about.
+func (me MyError) WithDetails(details ...FieldError) MyError {
+	me.Details = details
+	return me
+}
+
 var (
+	// ErrorInternal is answered for errors that are not a MyError, their
+	// text stays in the logs.
+	ErrorInternal = MyError{
+		Err:    "Something went wrong, try again later!",
+		Status: http.StatusInternalServerError,
+		Code:   "internal_error",
+	}
+
+	ErrorInvalidRequest = MyError{
+		Err:    "Invalid request!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_request",
+	}
+
+	// ErrorValidation lists the fields failing validation in its details.
+	ErrorValidation = MyError{
+		Err:    "Some fields are invalid!",
+		Status: http.StatusBadRequest,
+		Code:   "validation_failed",
+	}
+
 	ErrorInvalidEmailOrPassword = MyError{
-		Err: "Invalid email or password!",
+		Err:    "Invalid email or password!",
+		Status: http.StatusUnauthorized,
+		Code:   "invalid_email_or_password",
 	}
 	ErrorInvalidToken = MyError{
-		Err: "Invalid token!",
+		Err:    "Invalid token!",
+		Status: http.StatusUnauthorized,
+		Code:   "invalid_token",
 	}
 
 	ErrorNotAuthorized = MyError{
-		Err: "Not Authorized!",
+		Err:    "Not Authorized!",
+		Status: http.StatusUnauthorized,
+		Code:   "not_authorized",
 	}
 
 	ErrorNotFound = MyError{
-		Err: "Not Found!",
+		Err:    "Not Found!",
+		Status: http.StatusNotFound,
+		Code:   "not_found",
 	}
 
 	ErrorForbiddenAccess = MyError{
-		Err: "Forbidden Access!",
+		Err:    "Forbidden Access!",
+		Status: http.StatusForbidden,
+		Code:   "forbidden_access",
+	}
+
+	ErrorInvalidSearchType = MyError{
+		Err:    "Invalid search type!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_search_type",
+	}
+
+	ErrorPhotoAlreadyInAlbum = MyError{
+		Err:    "Photo already in album!",
+		Status: http.StatusConflict,
+		Code:   "photo_already_in_album",
+	}
+
+	ErrorPhotoNotInAlbum = MyError{
+		Err:    "Photo is not in album!",
+		Status: http.StatusBadRequest,
+		Code:   "photo_not_in_album",
+	}
+
+	ErrorInvalidAlbumOrder = MyError{
+		Err:    "Photo order must list every photo in the album exactly once!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_album_order",
+	}
+
+	ErrorAlreadyBookmarked = MyError{
+		Err:    "Photo already bookmarked!",
+		Status: http.StatusConflict,
+		Code:   "already_bookmarked",
+	}
+
+	ErrorCannotFollowSelf = MyError{
+		Err:    "You cannot follow yourself!",
+		Status: http.StatusBadRequest,
+		Code:   "cannot_follow_self",
+	}
+
+	ErrorAlreadyFollowing = MyError{
+		Err:    "Already following or requested!",
+		Status: http.StatusConflict,
+		Code:   "already_following",
+	}
+
+	ErrorPrivateAccount = MyError{
+		Err:    "This account is private!",
+		Status: http.StatusForbidden,
+		Code:   "private_account",
+	}
+
+	ErrorCannotBlockSelf = MyError{
+		Err:    "You cannot block yourself!",
+		Status: http.StatusBadRequest,
+		Code:   "cannot_block_self",
+	}
+
+	ErrorCannotMuteSelf = MyError{
+		Err:    "You cannot mute yourself!",
+		Status: http.StatusBadRequest,
+		Code:   "cannot_mute_self",
+	}
+
+	ErrorAlreadyBlocked = MyError{
+		Err:    "User already blocked!",
+		Status: http.StatusConflict,
+		Code:   "already_blocked",
+	}
+
+	ErrorAlreadyMuted = MyError{
+		Err:    "User already muted!",
+		Status: http.StatusConflict,
+		Code:   "already_muted",
+	}
+
+	ErrorBlocked = MyError{
+		Err:    "You cannot interact with this user!",
+		Status: http.StatusForbidden,
+		Code:   "blocked",
+	}
+
+	ErrorCannotReportSelf = MyError{
+		Err:    "You cannot report yourself or your own content!",
+		Status: http.StatusBadRequest,
+		Code:   "cannot_report_self",
+	}
+
+	ErrorAlreadyReported = MyError{
+		Err:    "You already reported this!",
+		Status: http.StatusConflict,
+		Code:   "already_reported",
+	}
+
+	ErrorReportClosed = MyError{
+		Err:    "Report is already closed!",
+		Status: http.StatusConflict,
+		Code:   "report_closed",
+	}
+
+	ErrorReportClaimed = MyError{
+		Err:    "Report is claimed by another moderator!",
+		Status: http.StatusConflict,
+		Code:   "report_claimed",
+	}
+
+	ErrorInvalidModerationAction = MyError{
+		Err:    "Action does not apply to this report!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_moderation_action",
+	}
+
+	ErrorAccountSuspended = MyError{
+		Err:    "Account is suspended!",
+		Status: http.StatusForbidden,
+		Code:   "account_suspended",
+	}
+
+	ErrorContentRejected = MyError{
+		Err:    "Content is not allowed!",
+		Status: http.StatusBadRequest,
+		Code:   "content_rejected",
+	}
+
+	ErrorBlockedWords = MyError{
+		Err:    "Text contains words that are not allowed!",
+		Status: http.StatusBadRequest,
+		Code:   "blocked_words",
+	}
+
+	ErrorDuplicateMessage = MyError{
+		Err:    "You already posted this message!",
+		Status: http.StatusBadRequest,
+		Code:   "duplicate_message",
+	}
+
+	ErrorPostingTooFast = MyError{
+		Err:    "You are posting too fast, try again later!",
+		Status: http.StatusTooManyRequests,
+		Code:   "posting_too_fast",
+	}
+
+	ErrorEditWindowClosed = MyError{
+		Err:    "Comment can no longer be edited!",
+		Status: http.StatusForbidden,
+		Code:   "edit_window_closed",
+	}
+
+	ErrorInvalidReaction = MyError{
+		Err:    "Reaction is not supported!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_reaction",
+	}
+
+	ErrorAlreadyReacted = MyError{
+		Err:    "You already reacted with this emoji!",
+		Status: http.StatusConflict,
+		Code:   "already_reacted",
+	}
+
+	ErrorCommentsDisabled = MyError{
+		Err:    "Comments are turned off for this photo!",
+		Status: http.StatusForbidden,
+		Code:   "comments_disabled",
+	}
+
+	ErrorCommentsFollowersOnly = MyError{
+		Err:    "Only followers can comment on this photo!",
+		Status: http.StatusForbidden,
+		Code:   "comments_followers_only",
+	}
+
+	ErrorPinLimitReached = MyError{
+		Err:    "Pinned comments limit reached!",
+		Status: http.StatusConflict,
+		Code:   "pin_limit_reached",
+	}
+
+	ErrorIncompleteLocation = MyError{
+		Err:    "Latitude and longitude must be set together!",
+		Status: http.StatusBadRequest,
+		Code:   "incomplete_location",
+	}
+
+	ErrorInvalidCoordinates = MyError{
+		Err:    "Latitude must be between -90 and 90 and longitude between -180 and 180!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_coordinates",
+	}
+
+	ErrorInvalidRadius = MyError{
+		Err:    "Radius must be greater than 0 and at most 100 km!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_radius",
+	}
+
+	ErrorUnsupportedImage = MyError{
+		Err:    "Photo must be a JPEG image!",
+		Status: http.StatusBadRequest,
+		Code:   "unsupported_image",
+	}
+
+	ErrorImageTooLarge = MyError{
+		Err:    "Photo is too large!",
+		Status: http.StatusRequestEntityTooLarge,
+		Code:   "image_too_large",
+	}
+
+	ErrorPhotoURLRequired = MyError{
+		Err:    "Photo URL is required",
+		Status: http.StatusBadRequest,
+		Code:   "photo_url_required",
+	}
+
+	ErrorInvalidMediaCount = MyError{
+		Err:    "A post must have between 1 and 10 media items!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_media_count",
+	}
+
+	ErrorPhotoURLMismatch = MyError{
+		Err:    "Photo URL must be the first media item!",
+		Status: http.StatusBadRequest,
+		Code:   "photo_url_mismatch",
+	}
+
+	ErrorMediaURLTooLong = MyError{
+		Err:    "Media URL must be at most 255 characters!",
+		Status: http.StatusBadRequest,
+		Code:   "media_url_too_long",
+	}
+
+	ErrorAltTextTooLong = MyError{
+		Err:    "Alt text must be at most 255 characters!",
+		Status: http.StatusBadRequest,
+		Code:   "alt_text_too_long",
+	}
+
+	ErrorInvalidAltText = MyError{
+		Err:    "Alt text should describe the photo, not its file name or link!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_alt_text",
+	}
+
+	ErrorDuplicatePhoto = MyError{
+		Err:    "You already posted this photo!",
+		Status: http.StatusConflict,
+		Code:   "duplicate_photo",
+	}
+
+	ErrorPhotoNotHashed = MyError{
+		Err:    "Only uploaded photos can be compared!",
+		Status: http.StatusBadRequest,
+		Code:   "photo_not_hashed",
+	}
+
+	ErrorInvalidRepostDistance = MyError{
+		Err:    "Max distance must be between 0 and 20!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_repost_distance",
+	}
+
+	ErrorInvalidPhotoStatus = MyError{
+		Err:    "Status must be draft, scheduled or published!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_photo_status",
+	}
+
+	ErrorInvalidPublishAt = MyError{
+		Err:    "Scheduled photos need a publish time in the future!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_publish_at",
+	}
+
+	ErrorPhotoAlreadyPublished = MyError{
+		Err:    "Published photos cannot go back to draft or scheduled!",
+		Status: http.StatusBadRequest,
+		Code:   "photo_already_published",
+	}
+
+	ErrorInvalidParticipants = MyError{
+		Err:    "A conversation needs 1 to 9 other users!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_participants",
+	}
+
+	ErrorCannotMessageSelf = MyError{
+		Err:    "You cannot start a conversation with yourself!",
+		Status: http.StatusBadRequest,
+		Code:   "cannot_message_self",
+	}
+
+	ErrorRecipientUnavailable = MyError{
+		Err:    "This user can no longer receive messages!",
+		Status: http.StatusConflict,
+		Code:   "recipient_unavailable",
+	}
+
+	ErrorEmptyMessage = MyError{
+		Err:    "Message needs a body or a photo!",
+		Status: http.StatusBadRequest,
+		Code:   "empty_message",
+	}
+
+	ErrorMessageTooLong = MyError{
+		Err:    "Message must be at most 1000 characters!",
+		Status: http.StatusBadRequest,
+		Code:   "message_too_long",
+	}
+
+	ErrorInvalidSocialMediaPlatform = MyError{
+		Err:    "Platform must be one of instagram, github, linkedin, x, youtube, discord or custom!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_social_media_platform",
+	}
+
+	ErrorInvalidSocialMediaURL = MyError{
+		Err:    "Social Media URL does not match the platform!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_social_media_url",
+	}
+
+	ErrorDuplicateSocialMedia = MyError{
+		Err:    "You already have a link for this platform!",
+		Status: http.StatusConflict,
+		Code:   "duplicate_social_media",
+	}
+
+	ErrorVerificationNotStarted = MyError{
+		Err:    "Request a verification challenge for this link first!",
+		Status: http.StatusBadRequest,
+		Code:   "verification_not_started",
+	}
+
+	ErrorChallengeNotFound = MyError{
+		Err:    "The verification challenge was not found on the linked page!",
+		Status: http.StatusBadRequest,
+		Code:   "challenge_not_found",
+	}
+
+	ErrorVerificationFetch = MyError{
+		Err:    "The linked page could not be fetched!",
+		Status: http.StatusBadGateway,
+		Code:   "verification_fetch",
+	}
+
+	ErrorInvalidSocialMediaVisibility = MyError{
+		Err:    "Visibility must be public, followers or private!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_social_media_visibility",
+	}
+
+	ErrorInvalidSocialMediaOrder = MyError{
+		Err:    "The new order must list each of your links exactly once!",
+		Status: http.StatusBadRequest,
+		Code:   "invalid_social_media_order",
+	}
+
+	ErrorPhotoFileRequired = MyError{
+		Err:    "Photo file is required",
+		Status: http.StatusBadRequest,
+		Code:   "photo_file_required",
+	}
+
+	ErrorUnsupportedLocale = MyError{
+		Err:    "Locale must be one of en or id!",
+		Status: http.StatusBadRequest,
+		Code:   "unsupported_locale",
 	}
 )
@@ -0,0 +1,30 @@
+package model
+
+const (
+	FilterVerdictAllow  = "allow"
+	FilterVerdictHold   = "hold"
+	FilterVerdictReject = "reject"
+)
+
+const (
+	FilterContentComment = "comment"
+	FilterContentCaption = "caption"
+)
+
+// FilterContent is the text a user is about to publish. ID is the content
+// being edited, empty for new content.
+type FilterContent struct {
+	ID     string
+	UserID string
+	Kind   string
+	Text   string
+}
+
+// FilterResult is a filter's decision. Err explains a rejection and Reason
+// is the report reason recorded when content is held for review.
+type FilterResult struct {
+	Verdict string
+	Err     error
+	Reason  string
+	Note    string
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+const (
+	FollowStatusPending  = "pending"
+	FollowStatusAccepted = "accepted"
+)
+
+type Follow struct {
+	FollowerID  string `gorm:"primaryKey"`
+	FollowingID string `gorm:"primaryKey;index"`
+	Status      string `gorm:"not null;type:varchar(10)"`
+	Follower    User   `gorm:"foreignKey:FollowerID"`
+	Following   User   `gorm:"foreignKey:FollowingID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Response
+type FollowResponse struct {
+	FollowerID  string    `json:"follower_id"`
+	FollowingID string    `json:"following_id"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type FollowRequestResponse struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToFollowResponse(follow Follow) FollowResponse {
+	return FollowResponse{
+		FollowerID:  follow.FollowerID,
+		FollowingID: follow.FollowingID,
+		Status:      follow.Status,
+		CreatedAt:   follow.CreatedAt,
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+const (
+	DefaultNearbyRadiusKm = 10
+	MaxNearbyRadiusKm     = 100
+	// MaxNearbyResults caps how many photos a nearby search ranks, the
+	// closest ones win.
+	MaxNearbyResults = 500
+)
+
+// PhotoDistance is a photo with its distance from the searched point.
+type PhotoDistance struct {
+	Photo      Photo
+	DistanceKm float64
+}
+
+// Request
+type PhotoLocationRequest struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	PlaceName string   `json:"place_name" valid:"maxstringlength(100)~validation.place_name_too_long"`
+}
+
+type PhotoNearbyRequest struct {
+	Latitude  *float64 `form:"lat"`
+	Longitude *float64 `form:"lng"`
+	RadiusKm  float64  `form:"radius_km"`
+	PaginationRequest
+}
+
+// IsEmpty reports whether the location carries nothing, which clears the
+// location of an updated photo.
+func (lr PhotoLocationRequest) IsEmpty() bool {
+	return lr.Latitude == nil && lr.Longitude == nil && lr.PlaceName == ""
+}
+
+// Validate requires both coordinates, in range, whenever any location field is set.
+func (lr PhotoLocationRequest) Validate() error {
+	if lr.Latitude == nil || lr.Longitude == nil {
+		return ErrorIncompleteLocation
+	}
+	return ValidateCoordinates(*lr.Latitude, *lr.Longitude)
+}
+
+func ValidateCoordinates(latitude float64, longitude float64) error {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return ErrorInvalidCoordinates
+	}
+	return nil
+}
+
+// Response
+type PhotoLocationResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	PlaceName string  `json:"place_name"`
+}
+
+type PhotoNearbyResponse struct {
+	ID         string                `json:"id"`
+	UserID     string                `json:"user_id"`
+	Title      string                `json:"title"`
+	Caption    string                `json:"caption"`
+	PhotoURL   string                `json:"photo_url"`
+	Location   PhotoLocationResponse `json:"location"`
+	DistanceKm float64               `json:"distance_km"`
+}
+
+type PhotoNearbyListResponse struct {
+	Photos     []PhotoNearbyResponse `json:"photos"`
+	Pagination PaginationResponse    `json:"pagination"`
+}
+
+// ToPhotoLocationResponse returns nil for photos without a location.
+func ToPhotoLocationResponse(photo Photo) *PhotoLocationResponse {
+	if photo.Latitude == nil || photo.Longitude == nil {
+		return nil
+	}
+	return &PhotoLocationResponse{
+		Latitude:  *photo.Latitude,
+		Longitude: *photo.Longitude,
+		PlaceName: photo.PlaceName,
+	}
+}
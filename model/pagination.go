@@ -0,0 +1,45 @@
+package model
+
+const (
+	DefaultPageLimit = 10
+	MaxPageLimit     = 100
+)
+
+// Request
+type PaginationRequest struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// Normalize fills missing values with defaults and caps the page size.
+func (pr PaginationRequest) Normalize() PaginationRequest {
+	if pr.Page < 1 {
+		pr.Page = 1
+	}
+	if pr.Limit < 1 {
+		pr.Limit = DefaultPageLimit
+	}
+	if pr.Limit > MaxPageLimit {
+		pr.Limit = MaxPageLimit
+	}
+	return pr
+}
+
+func (pr PaginationRequest) Offset() int {
+	return (pr.Page - 1) * pr.Limit
+}
+
+// Response
+type PaginationResponse struct {
+	Page  int   `json:"page"`
+	Limit int   `json:"limit"`
+	Total int64 `json:"total"`
+}
+
+func ToPaginationResponse(pagination PaginationRequest, total int64) PaginationResponse {
+	return PaginationResponse{
+		Page:  pagination.Page,
+		Limit: pagination.Limit,
+		Total: total,
+	}
+}
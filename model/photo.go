@@ -2,67 +2,175 @@ package model
 
 import "time"
 
+const (
+	CommentPolicyEveryone  = "everyone"
+	CommentPolicyFollowers = "followers"
+	CommentPolicyOff       = "off"
+)
+
 type Photo struct {
-	ID        string `gorm:"primaryKey"`
-	Title     string `gorm:"not null;type:varchar(100)"`
-	Caption   string `gorm:"not null;type:varchar(255)"`
-	PhotoURL  string `gorm:"not null;type:varchar(255);column:photo_url"`
-	UserID    string
+	ID       string `gorm:"primaryKey"`
+	Title    string `gorm:"not null;type:varchar(100)"`
+	Caption  string `gorm:"not null;type:varchar(255)"`
+	PhotoURL string `gorm:"not null;type:varchar(255);column:photo_url"`
+	UserID   string
+	Hidden   bool `gorm:"not null;default:false"`
+	// CommentPolicy is who may comment: everyone, followers of the owner or nobody.
+	CommentPolicy string `gorm:"not null;type:varchar(10);default:everyone"`
+	// Latitude and Longitude are both set or both nil. The composite index
+	// serves the bounding box of nearby searches.
+	Latitude  *float64 `gorm:"index:idx_photos_location"`
+	Longitude *float64 `gorm:"index:idx_photos_location"`
+	PlaceName string   `gorm:"not null;type:varchar(100);default:''"`
+	// ShareMetadata shows the EXIF camera information of uploaded photos to
+	// other users.
+	ShareMetadata bool           `gorm:"not null;default:false"`
+	Metadata      *PhotoMetadata `gorm:"foreignKey:PhotoID"`
+	Media         []PhotoMedia
+	// AltText is the alt text of every media item, one line per item,
+	// mirrored here so it is searchable together with the title and caption.
+	AltText string `gorm:"not null;type:text;default:''"`
+	// PerceptualHash is the dHash of uploaded photos, stored as the signed
+	// bit pattern. Photos posted by URL have none.
+	PerceptualHash *int64 `gorm:"index"`
+	// Status is draft, scheduled or published. PublishAt is when a scheduled
+	// photo goes live, or when a published one did.
+	Status    string     `gorm:"not null;type:varchar(10);default:published;index:idx_photos_status_publish_at"`
+	PublishAt *time.Time `gorm:"index:idx_photos_status_publish_at"`
 	Comments  []Comment
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	SearchVector string `gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(title, '')), 'A') || setweight(to_tsvector('simple', coalesce(caption, '')), 'B') || setweight(to_tsvector('simple', coalesce(alt_text, '')), 'C')) STORED;index:idx_photos_search_vector,type:gin"`
+}
+
+// PhotoUpdate is everything one photo update writes. Title and caption are
+// always written, the other fields only when set.
+type PhotoUpdate struct {
+	Title         string
+	Caption       string
+	Location      *PhotoLocationRequest
+	Media         []PhotoMedia
+	ShareMetadata *bool
+	Status        *string
+	PublishAt     *time.Time
+	// Hide hides the photo, as for captions held for review.
+	Hide bool
 }
 
 // Request
 type PhotoCreateRequest struct {
-	Title    string `json:"title" valid:"required~Title is required"`
-	Caption  string `json:"caption"`
-	PhotoURL string `json:"photo_url" valid:"required~Photo URL is required"`
+	Title   string `json:"title" valid:"required~validation.title_required"`
+	Caption string `json:"caption" valid:"maxstringlength(255)~validation.caption_too_long"`
+	// PhotoURL posts a single photo, Media a carousel of 1 to 10 items. One
+	// of them is required.
+	PhotoURL string              `json:"photo_url" valid:"url~validation.photo_url_invalid,maxstringlength(255)~validation.photo_url_too_long"`
+	Media    []PhotoMediaRequest `json:"media"`
+	// AltText describes the photo for screen readers, or the cover of a
+	// carousel whose first item has none. Left empty, a suggestion is used.
+	AltText string `json:"alt_text" valid:"maxstringlength(255)~validation.alt_text_too_long"`
+	// Location is optional. On update, leaving it out keeps the current
+	// location and an empty object removes it.
+	Location *PhotoLocationRequest `json:"location"`
+	// Status is draft, scheduled or published, the default. Scheduled photos
+	// go live at PublishAt.
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publish_at"`
 }
 
 type PhotoUpdateRequest struct {
-	Title    string `json:"title" valid:"required~Title is required"`
-	Caption  string `json:"caption"`
-	PhotoURL string `json:"photo_url" valid:"required~Photo URL is required"`
+	Title   string `json:"title" valid:"required~validation.title_required"`
+	Caption string `json:"caption" valid:"maxstringlength(255)~validation.caption_too_long"`
+	// Media replaces every item of the post. Without it, a PhotoURL other
+	// than the current one replaces the cover only.
+	PhotoURL string              `json:"photo_url" valid:"url~validation.photo_url_invalid,maxstringlength(255)~validation.photo_url_too_long"`
+	Media    []PhotoMediaRequest `json:"media"`
+	// AltText is optional, it replaces the alt text of the cover.
+	AltText *string `json:"alt_text"`
+	// Location is optional. On update, leaving it out keeps the current
+	// location and an empty object removes it.
+	Location *PhotoLocationRequest `json:"location"`
+	// ShareMetadata is optional, leaving it out keeps the current setting.
+	ShareMetadata *bool `json:"share_metadata"`
+	// Status is optional, leaving it out keeps drafts and scheduled photos
+	// as they are. Published photos stay published.
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+type PhotoCommentSettingsRequest struct {
+	CommentPolicy string `json:"comment_policy" valid:"required~validation.comment_policy_required,in(everyone|followers|off)~validation.comment_policy_invalid"`
 }
 
 // Response
 type PhotoCreateResponse struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Title     string    `json:"title"`
-	Caption   string    `json:"caption"`
-	PhotoURL  string    `json:"photo_url"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            string                 `json:"id"`
+	UserID        string                 `json:"user_id"`
+	Title         string                 `json:"title"`
+	Caption       string                 `json:"caption"`
+	PhotoURL      string                 `json:"photo_url"`
+	Media         []PhotoMediaResponse   `json:"media"`
+	AltText       string                 `json:"alt_text"`
+	Location      *PhotoLocationResponse `json:"location"`
+	Metadata      *PhotoMetadataResponse `json:"metadata,omitempty"`
+	ShareMetadata bool                   `json:"share_metadata"`
+	PendingReview bool                   `json:"pending_review"`
+	Status        string                 `json:"status"`
+	PublishAt     *time.Time             `json:"publish_at"`
+	// DuplicateOf lists earlier photos of the same user that look the same.
+	DuplicateOf []string  `json:"duplicate_of,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type PhotoUpdateResponse struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Title     string    `json:"title"`
-	Caption   string    `json:"caption"`
-	PhotoURL  string    `json:"photo_url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            string                 `json:"id"`
+	UserID        string                 `json:"user_id"`
+	Title         string                 `json:"title"`
+	Caption       string                 `json:"caption"`
+	PhotoURL      string                 `json:"photo_url"`
+	Media         []PhotoMediaResponse   `json:"media"`
+	AltText       string                 `json:"alt_text"`
+	Location      *PhotoLocationResponse `json:"location"`
+	ShareMetadata bool                   `json:"share_metadata"`
+	PendingReview bool                   `json:"pending_review"`
+	Status        string                 `json:"status"`
+	PublishAt     *time.Time             `json:"publish_at"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
 }
 
 type PhotoResponse struct {
-	ID        string                   `json:"id"`
-	UserID    string                   `json:"user_id"`
-	Title     string                   `json:"title"`
-	Caption   string                   `json:"caption"`
-	PhotoURL  string                   `json:"photo_url"`
-	Comments  []CommentInPhotoResponse `json:"comments"`
-	CreatedAt time.Time                `json:"created_at"`
-	UpdatedAt time.Time                `json:"updated_at"`
+	ID             string                   `json:"id"`
+	UserID         string                   `json:"user_id"`
+	Title          string                   `json:"title"`
+	Caption        string                   `json:"caption"`
+	PhotoURL       string                   `json:"photo_url"`
+	Media          []PhotoMediaResponse     `json:"media"`
+	AltText        string                   `json:"alt_text"`
+	BookmarkedByMe bool                     `json:"bookmarked_by_me"`
+	Location       *PhotoLocationResponse   `json:"location"`
+	Metadata       *PhotoMetadataResponse   `json:"metadata,omitempty"`
+	CommentPolicy  string                   `json:"comment_policy"`
+	Status         string                   `json:"status"`
+	PublishAt      *time.Time               `json:"publish_at"`
+	Comments       []CommentInPhotoResponse `json:"comments"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
 }
 
 type CommentInPhotoResponse struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string           `json:"id"`
+	UserID    string           `json:"user_id"`
+	Message   string           `json:"message"`
+	Pinned    bool             `json:"pinned"`
+	Reactions map[string]int64 `json:"reactions"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+type PhotoCommentSettingsResponse struct {
+	PhotoID       string `json:"photo_id"`
+	CommentPolicy string `json:"comment_policy"`
 }
 
 type DeletePhotoResponse struct {
@@ -0,0 +1,98 @@
+package model
+
+import "time"
+
+const MaxPhotoMedia = 10
+
+// PhotoMedia is one item of a carousel post, ordered by Position from 0. The
+// first item is the cover and is mirrored in Photo.PhotoURL for clients that
+// only know single photo posts.
+type PhotoMedia struct {
+	ID       string `gorm:"primaryKey"`
+	PhotoID  string `gorm:"not null;index:idx_photo_media_position"`
+	Position int    `gorm:"not null;index:idx_photo_media_position"`
+	URL      string `gorm:"not null;type:varchar(255)"`
+	AltText  string `gorm:"not null;type:varchar(255);default:''"`
+	// AltTextGenerated marks alt text suggested by the captioner rather than
+	// written by the owner.
+	AltTextGenerated bool `gorm:"not null;default:false"`
+	CreatedAt        time.Time
+}
+
+// Request
+type PhotoMediaRequest struct {
+	URL     string `json:"url" valid:"url~validation.media_url_invalid"`
+	AltText string `json:"alt_text"`
+}
+
+// ResolvePhotoMedia returns the media items of a create request: media when
+// given, otherwise the single photoURL of the original request shape. When
+// both are given photoURL must be the first item. altText describes the
+// cover unless the first item carries its own.
+func ResolvePhotoMedia(media []PhotoMediaRequest, photoURL string, altText string) ([]PhotoMediaRequest, error) {
+	if len(media) == 0 {
+		if photoURL == "" {
+			return nil, ErrorPhotoURLRequired
+		}
+		media = []PhotoMediaRequest{{URL: photoURL}}
+	} else if photoURL != "" && photoURL != media[0].URL {
+		return nil, ErrorPhotoURLMismatch
+	}
+
+	if len(media) > MaxPhotoMedia {
+		return nil, ErrorInvalidMediaCount
+	}
+
+	resolved := make([]PhotoMediaRequest, 0, len(media))
+	for i, item := range media {
+		item.AltText = NormalizeAltText(item.AltText)
+		if i == 0 && item.AltText == "" {
+			item.AltText = NormalizeAltText(altText)
+		}
+
+		if item.URL == "" {
+			return nil, ErrorPhotoURLRequired
+		}
+		if len([]rune(item.URL)) > 255 {
+			return nil, ErrorMediaURLTooLong
+		}
+		err := ValidateAltText(item.AltText)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, item)
+	}
+	return resolved, nil
+}
+
+// Response
+type PhotoMediaResponse struct {
+	Position         int    `json:"position"`
+	URL              string `json:"url"`
+	AltText          string `json:"alt_text"`
+	AltTextGenerated bool   `json:"alt_text_generated"`
+}
+
+// ToPhotoMediaResponse lists the media of a photo. Photos posted before
+// carousels have no media rows and are shown as a single item.
+func ToPhotoMediaResponse(photo Photo) []PhotoMediaResponse {
+	if len(photo.Media) == 0 {
+		return []PhotoMediaResponse{{Position: 0, URL: photo.PhotoURL, AltText: photo.AltText}}
+	}
+
+	media := make([]PhotoMediaResponse, 0, len(photo.Media))
+	for _, item := range photo.Media {
+		media = append(media, PhotoMediaResponse{
+			Position:         item.Position,
+			URL:              item.URL,
+			AltText:          item.AltText,
+			AltTextGenerated: item.AltTextGenerated,
+		})
+	}
+	return media
+}
+
+// CoverAltText is the alt text of the first media item.
+func CoverAltText(photo Photo) string {
+	return ToPhotoMediaResponse(photo)[0].AltText
+}
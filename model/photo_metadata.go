@@ -0,0 +1,73 @@
+package model
+
+import "time"
+
+// PhotoMetadata is the camera information read from the EXIF of an uploaded
+// JPEG. GPS tags are deliberately not kept, the stored file carries no EXIF
+// at all and a photo location is only ever set by its owner.
+type PhotoMetadata struct {
+	PhotoID      string `gorm:"primaryKey"`
+	CameraMake   string `gorm:"not null;type:varchar(100);default:''"`
+	CameraModel  string `gorm:"not null;type:varchar(100);default:''"`
+	LensModel    string `gorm:"not null;type:varchar(100);default:''"`
+	ExposureTime string `gorm:"not null;type:varchar(20);default:''"`
+	FNumber      *float64
+	ISO          *int
+	FocalLength  *float64
+	TakenAt      *time.Time
+	// Orientation is the EXIF orientation of the original, already applied
+	// to the stored pixels.
+	Orientation int `gorm:"not null;default:1"`
+	Width       int `gorm:"not null"`
+	Height      int `gorm:"not null"`
+	CreatedAt   time.Time
+}
+
+// Request
+type PhotoUploadRequest struct {
+	Title   string `form:"title" valid:"required~validation.title_required"`
+	Caption string `form:"caption" valid:"maxstringlength(255)~validation.caption_too_long"`
+	AltText string `form:"alt_text" valid:"maxstringlength(255)~validation.alt_text_too_long"`
+	// ShareMetadata shows the camera information to other users, the owner
+	// always sees it.
+	ShareMetadata bool `form:"share_metadata"`
+	// Status and PublishAt work as on PhotoCreateRequest.
+	Status    string     `form:"status"`
+	PublishAt *time.Time `form:"publish_at" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Response
+type PhotoMetadataResponse struct {
+	CameraMake   string     `json:"camera_make,omitempty"`
+	CameraModel  string     `json:"camera_model,omitempty"`
+	LensModel    string     `json:"lens_model,omitempty"`
+	ExposureTime string     `json:"exposure_time,omitempty"`
+	FNumber      *float64   `json:"f_number,omitempty"`
+	ISO          *int       `json:"iso,omitempty"`
+	FocalLength  *float64   `json:"focal_length,omitempty"`
+	TakenAt      *time.Time `json:"taken_at,omitempty"`
+	Width        int        `json:"width"`
+	Height       int        `json:"height"`
+}
+
+// ToPhotoMetadataResponse returns the photo metadata when viewerId may see it:
+// the owner always, others only when the owner opted in. Photos that were not
+// uploaded have none.
+func ToPhotoMetadataResponse(photo Photo, viewerId string) *PhotoMetadataResponse {
+	if photo.Metadata == nil || (!photo.ShareMetadata && photo.UserID != viewerId) {
+		return nil
+	}
+
+	return &PhotoMetadataResponse{
+		CameraMake:   photo.Metadata.CameraMake,
+		CameraModel:  photo.Metadata.CameraModel,
+		LensModel:    photo.Metadata.LensModel,
+		ExposureTime: photo.Metadata.ExposureTime,
+		FNumber:      photo.Metadata.FNumber,
+		ISO:          photo.Metadata.ISO,
+		FocalLength:  photo.Metadata.FocalLength,
+		TakenAt:      photo.Metadata.TakenAt,
+		Width:        photo.Metadata.Width,
+		Height:       photo.Metadata.Height,
+	}
+}
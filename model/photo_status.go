@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+const (
+	PhotoStatusDraft     = "draft"
+	PhotoStatusScheduled = "scheduled"
+	PhotoStatusPublished = "published"
+)
+
+// IsPublished reports whether the photo is live, which is anything but a
+// draft or a scheduled photo. Those are only visible to their owner.
+func (p Photo) IsPublished() bool {
+	return p.Status != PhotoStatusDraft && p.Status != PhotoStatusScheduled
+}
+
+// ResolvePhotoStatus validates the requested status of a photo and returns it
+// with its publish time. An empty status publishes right away, a scheduled
+// photo needs a publish time after now and a draft has none.
+func ResolvePhotoStatus(status string, publishAt *time.Time, now time.Time) (string, *time.Time, error) {
+	switch status {
+	case "", PhotoStatusPublished:
+		return PhotoStatusPublished, &now, nil
+	case PhotoStatusDraft:
+		return PhotoStatusDraft, nil, nil
+	case PhotoStatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return "", nil, ErrorInvalidPublishAt
+		}
+		return PhotoStatusScheduled, publishAt, nil
+	default:
+		return "", nil, ErrorInvalidPhotoStatus
+	}
+}
+
+// Response
+type PhotoDraftResponse struct {
+	ID        string               `json:"id"`
+	Title     string               `json:"title"`
+	Caption   string               `json:"caption"`
+	PhotoURL  string               `json:"photo_url"`
+	Media     []PhotoMediaResponse `json:"media"`
+	Status    string               `json:"status"`
+	PublishAt *time.Time           `json:"publish_at"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type PhotoDraftListResponse struct {
+	Photos     []PhotoDraftResponse `json:"photos"`
+	Pagination PaginationResponse   `json:"pagination"`
+}
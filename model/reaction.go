@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// DefaultReactionEmojis is the reaction set used when REACTION_EMOJIS is not
+// configured.
+var DefaultReactionEmojis = []string{"👍", "❤", "😂", "😮", "😢", "🔥"}
+
+type CommentReaction struct {
+	ID        string `gorm:"primaryKey"`
+	CommentID string `gorm:"not null;uniqueIndex:idx_comment_reactions_comment_user_emoji"`
+	UserID    string `gorm:"not null;uniqueIndex:idx_comment_reactions_comment_user_emoji"`
+	Emoji     string `gorm:"not null;type:varchar(32);uniqueIndex:idx_comment_reactions_comment_user_emoji"`
+	CreatedAt time.Time
+}
+
+// ReactionCount is the number of reactions with one emoji on one comment.
+type ReactionCount struct {
+	CommentID string
+	Emoji     string
+	Count     int64
+}
+
+// Response
+type ReactionResponse struct {
+	CommentID string           `json:"comment_id"`
+	Emoji     string           `json:"emoji"`
+	Reactions map[string]int64 `json:"reactions"`
+}
@@ -0,0 +1,180 @@
+package model
+
+import "time"
+
+const (
+	ReportTargetPhoto   = "photo"
+	ReportTargetComment = "comment"
+	ReportTargetUser    = "user"
+)
+
+const (
+	ReportReasonSpam           = "spam"
+	ReportReasonHarassment     = "harassment"
+	ReportReasonHateSpeech     = "hate_speech"
+	ReportReasonNudity         = "nudity"
+	ReportReasonViolence       = "violence"
+	ReportReasonMisinformation = "misinformation"
+	ReportReasonOther          = "other"
+)
+
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusClaimed   = "claimed"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+const (
+	ModerationActionClaim    = "claim"
+	ModerationActionHide     = "hide"
+	ModerationActionAutoHide = "auto_hide"
+	ModerationActionUnhide   = "unhide"
+	ModerationActionWarn     = "warn"
+	ModerationActionSuspend  = "suspend"
+	ModerationActionDismiss  = "dismiss"
+)
+
+const DefaultSuspendDays = 7
+
+// Report is one user's flag on a photo, comment or user. A user can report
+// the same target only once, so the open report count reflects distinct users.
+type Report struct {
+	ID           string  `gorm:"primaryKey"`
+	ReporterID   string  `gorm:"not null;uniqueIndex:idx_reports_reporter_target"`
+	TargetType   string  `gorm:"not null;type:varchar(10);uniqueIndex:idx_reports_reporter_target;index:idx_reports_target"`
+	TargetID     string  `gorm:"not null;uniqueIndex:idx_reports_reporter_target;index:idx_reports_target"`
+	TargetUserID string  `gorm:"not null;index"`
+	Excerpt      string  `gorm:"not null;type:varchar(255)"`
+	Reason       string  `gorm:"not null;type:varchar(20)"`
+	Note         string  `gorm:"not null;type:varchar(255)"`
+	Status       string  `gorm:"not null;type:varchar(10);index"`
+	ModeratorID  *string `gorm:"index"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ModerationAction records every moderation decision. ModeratorID is nil for
+// decisions taken automatically, such as hiding content past the report threshold.
+type ModerationAction struct {
+	ID           string  `gorm:"primaryKey"`
+	ReportID     *string `gorm:"index"`
+	ModeratorID  *string
+	TargetType   string `gorm:"not null;type:varchar(10)"`
+	TargetID     string `gorm:"not null"`
+	TargetUserID string `gorm:"not null;index"`
+	Action       string `gorm:"not null;type:varchar(10)"`
+	Note         string `gorm:"not null;type:varchar(255)"`
+	CreatedAt    time.Time
+}
+
+// Request
+type ReportCreateRequest struct {
+	Reason string `json:"reason" valid:"required~validation.reason_required,in(spam|harassment|hate_speech|nudity|violence|misinformation|other)~validation.reason_invalid"`
+	Note   string `json:"note" valid:"maxstringlength(255)~validation.note_too_long"`
+}
+
+type ReportListRequest struct {
+	Status string `form:"status" valid:"in(open|claimed|resolved|dismissed)~validation.status_invalid"`
+	PaginationRequest
+}
+
+type ReportResolveRequest struct {
+	Action      string `json:"action" valid:"required~validation.action_required,in(hide|warn|suspend)~validation.action_invalid"`
+	Note        string `json:"note" valid:"maxstringlength(255)~validation.note_too_long"`
+	SuspendDays int    `json:"suspend_days" valid:"range(1|365)~validation.suspend_days_out_of_range"`
+}
+
+type ReportDismissRequest struct {
+	Note string `json:"note" valid:"maxstringlength(255)~validation.note_too_long"`
+}
+
+// Response
+type ReportResponse struct {
+	ID           string    `json:"id"`
+	ReporterID   string    `json:"reporter_id"`
+	TargetType   string    `json:"target_type"`
+	TargetID     string    `json:"target_id"`
+	TargetUserID string    `json:"target_user_id"`
+	Excerpt      string    `json:"excerpt"`
+	Reason       string    `json:"reason"`
+	Note         string    `json:"note"`
+	Status       string    `json:"status"`
+	ModeratorID  string    `json:"moderator_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ReportListResponse struct {
+	Reports    []ReportResponse   `json:"reports"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type ModerationActionResponse struct {
+	ID           string    `json:"id"`
+	ReportID     string    `json:"report_id,omitempty"`
+	ModeratorID  string    `json:"moderator_id,omitempty"`
+	TargetType   string    `json:"target_type"`
+	TargetID     string    `json:"target_id"`
+	TargetUserID string    `json:"target_user_id"`
+	Action       string    `json:"action"`
+	Note         string    `json:"note"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ModerationActionListResponse struct {
+	Actions    []ModerationActionResponse `json:"actions"`
+	Pagination PaginationResponse         `json:"pagination"`
+}
+
+type WarningResponse struct {
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func ToReportResponse(report Report) ReportResponse {
+	moderatorID := ""
+	if report.ModeratorID != nil {
+		moderatorID = *report.ModeratorID
+	}
+
+	return ReportResponse{
+		ID:           report.ID,
+		ReporterID:   report.ReporterID,
+		TargetType:   report.TargetType,
+		TargetID:     report.TargetID,
+		TargetUserID: report.TargetUserID,
+		Excerpt:      report.Excerpt,
+		Reason:       report.Reason,
+		Note:         report.Note,
+		Status:       report.Status,
+		ModeratorID:  moderatorID,
+		CreatedAt:    report.CreatedAt,
+		UpdatedAt:    report.UpdatedAt,
+	}
+}
+
+func ToModerationActionResponse(action ModerationAction) ModerationActionResponse {
+	reportID := ""
+	if action.ReportID != nil {
+		reportID = *action.ReportID
+	}
+	moderatorID := ""
+	if action.ModeratorID != nil {
+		moderatorID = *action.ModeratorID
+	}
+
+	return ModerationActionResponse{
+		ID:           action.ID,
+		ReportID:     reportID,
+		ModeratorID:  moderatorID,
+		TargetType:   action.TargetType,
+		TargetID:     action.TargetID,
+		TargetUserID: action.TargetUserID,
+		Action:       action.Action,
+		Note:         action.Note,
+		CreatedAt:    action.CreatedAt,
+	}
+}
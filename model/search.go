@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+const (
+	SearchTypePhoto   = "photo"
+	SearchTypeUser    = "user"
+	SearchTypeComment = "comment"
+
+	// MaxSearchScan is how many ranked hits a search looks through for
+	// results the viewer may see. Pages past it come back empty.
+	MaxSearchScan = 5 * MaxPageLimit
+)
+
+// Request
+type SearchRequest struct {
+	Query string `form:"q" valid:"required~validation.query_required"`
+	Type  string `form:"type"`
+	PaginationRequest
+}
+
+// Response
+type SearchResult struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	UserID    string    `json:"user_id,omitempty"`
+	PhotoID   string    `json:"photo_id,omitempty"`
+	Rank      float64   `json:"rank"`
+	Highlight string    `json:"highlight"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SearchResponse struct {
+	Query      string             `json:"query"`
+	Type       string             `json:"type"`
+	Results    []SearchResult     `json:"results"`
+	Pagination PaginationResponse `json:"pagination"`
+}
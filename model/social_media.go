@@ -2,24 +2,70 @@ package model
 
 import "time"
 
+const (
+	SocialMediaVisibilityPublic    = "public"
+	SocialMediaVisibilityFollowers = "followers"
+	SocialMediaVisibilityPrivate   = "private"
+)
+
+// ValidSocialMediaVisibility reports whether visibility is one of the link
+// visibilities.
+func ValidSocialMediaVisibility(visibility string) bool {
+	switch visibility {
+	case SocialMediaVisibilityPublic, SocialMediaVisibilityFollowers, SocialMediaVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// SocialMedia is a link on a user's profile, stored under its canonical URL.
+// A user has at most one link per catalog platform, links saved before the
+// catalog count as custom.
 type SocialMedia struct {
 	ID             string `gorm:"primaryKey"`
 	Name           string `gorm:"not null;type:varchar(255)"`
 	SocialMediaURL string `gorm:"not null;type:varchar(255);column:social_media_url"`
-	UserID         string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	Platform       string `gorm:"not null;type:varchar(20);default:custom;uniqueIndex:idx_social_media_user_platform,where:platform <> 'custom'"`
+	Handle         string `gorm:"not null;type:varchar(100);default:''"`
+	// VerificationToken is set once the owner asks to verify the link, which
+	// is verified when the linked page shows its challenge. Changing the URL
+	// resets both.
+	VerificationToken     string `gorm:"not null;type:varchar(64);default:''"`
+	VerifiedAt            *time.Time
+	VerificationCheckedAt *time.Time `gorm:"index"`
+	// Position orders the links of a user from 0. Visibility limits who sees
+	// the link, public, followers only or private to the owner.
+	Position   int    `gorm:"not null;default:0"`
+	Visibility string `gorm:"not null;type:varchar(10);default:public"`
+	UserID     string `gorm:"uniqueIndex:idx_social_media_user_platform,where:platform <> 'custom'"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 // Request
 type SocialMediaCreateRequest struct {
-	Name           string `json:"name" valid:"required~Social Media name is required"`
-	SocialMediaURL string `json:"social_media_url" valid:"required~Social Media URL is required"`
+	// Name defaults to the platform label.
+	Name           string `json:"name" valid:"maxstringlength(255)~validation.social_media_name_too_long"`
+	SocialMediaURL string `json:"social_media_url" valid:"required~validation.social_media_url_required,url~validation.social_media_url_invalid,maxstringlength(255)~validation.social_media_url_too_long"`
+	// Platform is detected from the URL when left empty.
+	Platform string `json:"platform"`
+	// Visibility is public, followers or private.
+	Visibility string `json:"visibility"`
 }
 
 type SocialMediaUpdateRequest struct {
-	Name           string `json:"name" valid:"required~Social Media name is required"`
-	SocialMediaURL string `json:"social_media_url" valid:"required~Social Media URL is required"`
+	// Name defaults to the platform label.
+	Name           string `json:"name" valid:"maxstringlength(255)~validation.social_media_name_too_long"`
+	SocialMediaURL string `json:"social_media_url" valid:"required~validation.social_media_url_required,url~validation.social_media_url_invalid,maxstringlength(255)~validation.social_media_url_too_long"`
+	// Platform is detected from the URL when left empty.
+	Platform string `json:"platform"`
+	// Visibility is public, followers or private.
+	Visibility string `json:"visibility"`
+}
+
+type SocialMediaOrderRequest struct {
+	// SocialMediaIDs are all links of the user in their new order.
+	SocialMediaIDs []string `json:"social_media_ids"`
 }
 
 // Response
@@ -28,25 +74,52 @@ type SocialMediaCreateResponse struct {
 	UserID         string    `json:"user_id"`
 	Name           string    `json:"name"`
 	SocialMediaURL string    `json:"social_media_url"`
+	Platform       string    `json:"platform"`
+	Handle         string    `json:"handle"`
+	DisplayURL     string    `json:"display_url"`
+	Position       int       `json:"position"`
+	Visibility     string    `json:"visibility"`
 	CreatedAt      time.Time `json:"created_at"`
 }
 
 type SocialMediaUpdateResponse struct {
-	ID             string    `json:"id"`
-	UserID         string    `json:"user_id"`
-	Name           string    `json:"name"`
-	SocialMediaURL string    `json:"social_media_url"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             string `json:"id"`
+	UserID         string `json:"user_id"`
+	Name           string `json:"name"`
+	SocialMediaURL string `json:"social_media_url"`
+	Platform       string `json:"platform"`
+	Handle         string `json:"handle"`
+	DisplayURL     string `json:"display_url"`
+	Position       int    `json:"position"`
+	Visibility     string `json:"visibility"`
+	// VerifiedAt is cleared when the edit changed the URL.
+	VerifiedAt *time.Time `json:"verified_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
 }
 
 type SocialMediaResponse struct {
-	ID             string    `json:"id"`
-	UserID         string    `json:"user_id"`
-	Name           string    `json:"name"`
-	SocialMediaURL string    `json:"social_media_url"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             string `json:"id"`
+	UserID         string `json:"user_id"`
+	Name           string `json:"name"`
+	SocialMediaURL string `json:"social_media_url"`
+	Platform       string `json:"platform"`
+	Handle         string `json:"handle"`
+	DisplayURL     string `json:"display_url"`
+	Position       int    `json:"position"`
+	Visibility     string `json:"visibility"`
+	// VerifiedAt is set while the linked page shows the link's challenge.
+	VerifiedAt *time.Time `json:"verified_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// SocialMediaRedirect is where a link click leads. Links outside the catalog
+// are Interstitial, shown on a page the visitor continues from instead of
+// being redirected to.
+type SocialMediaRedirect struct {
+	URL          string
+	Interstitial bool
 }
 
 type DeleteSocialMediaResponse struct {
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+const (
+	DefaultSocialMediaStatsDays = 30
+	MaxSocialMediaStatsDays     = 90
+)
+
+// SocialMediaClick counts the clicks on a link through its redirect on one
+// UTC day. Nothing about who clicked is kept.
+type SocialMediaClick struct {
+	SocialMediaID string    `gorm:"primaryKey"`
+	Day           time.Time `gorm:"primaryKey;type:date"`
+	Clicks        int64     `gorm:"not null;default:0"`
+}
+
+// Request
+type SocialMediaStatsRequest struct {
+	Days int `form:"days"`
+}
+
+// Response
+type SocialMediaDailyClicksResponse struct {
+	Date   string `json:"date"`
+	Clicks int64  `json:"clicks"`
+}
+
+type SocialMediaStatsResponse struct {
+	SocialMediaID string `json:"social_media_id"`
+	Name          string `json:"name"`
+	Platform      string `json:"platform"`
+	DisplayURL    string `json:"display_url"`
+	TotalClicks   int64  `json:"total_clicks"`
+	// Daily has an entry for every day of the range, oldest first.
+	Daily []SocialMediaDailyClicksResponse `json:"daily"`
+}
+
+type SocialMediaStatsListResponse struct {
+	From        string                     `json:"from"`
+	To          string                     `json:"to"`
+	SocialMedia []SocialMediaStatsResponse `json:"social_media"`
+}
@@ -0,0 +1,224 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	SocialMediaInstagram = "instagram"
+	SocialMediaGitHub    = "github"
+	SocialMediaLinkedIn  = "linkedin"
+	SocialMediaX         = "x"
+	SocialMediaYouTube   = "youtube"
+	SocialMediaDiscord   = "discord"
+	// SocialMediaCustom is any other http(s) link. It has no handle, and a
+	// user may have several as long as they point to different URLs.
+	SocialMediaCustom = "custom"
+)
+
+// socialMediaProfile is one URL shape of a platform. Path matches the URL path
+// with the handle as its only group, Canonical formats the handle back into
+// the URL links are stored under.
+type socialMediaProfile struct {
+	Path      *regexp.Regexp
+	Canonical string
+}
+
+// SocialMediaPlatform is a catalog entry. Hosts are lowercase and without
+// "www.", which is stripped before matching.
+type SocialMediaPlatform struct {
+	Name     string
+	Label    string
+	Hosts    []string
+	Profiles []socialMediaProfile
+}
+
+var SocialMediaPlatforms = []SocialMediaPlatform{
+	{
+		Name:  SocialMediaInstagram,
+		Label: "Instagram",
+		Hosts: []string{"instagram.com", "instagr.am"},
+		Profiles: []socialMediaProfile{
+			{Path: regexp.MustCompile(`^/([A-Za-z0-9._]{1,30})/?$`), Canonical: "https://www.instagram.com/%s"},
+		},
+	},
+	{
+		Name:  SocialMediaGitHub,
+		Label: "GitHub",
+		Hosts: []string{"github.com"},
+		Profiles: []socialMediaProfile{
+			{Path: regexp.MustCompile(`^/([A-Za-z0-9](?:[A-Za-z0-9-]{0,38}))/?$`), Canonical: "https://github.com/%s"},
+		},
+	},
+	{
+		Name:  SocialMediaLinkedIn,
+		Label: "LinkedIn",
+		Hosts: []string{"linkedin.com"},
+		Profiles: []socialMediaProfile{
+			{Path: regexp.MustCompile(`^/in/([A-Za-z0-9_-]{3,100})/?$`), Canonical: "https://www.linkedin.com/in/%s"},
+			{Path: regexp.MustCompile(`^/company/([A-Za-z0-9_-]{2,100})/?$`), Canonical: "https://www.linkedin.com/company/%s"},
+		},
+	},
+	{
+		Name:  SocialMediaX,
+		Label: "X",
+		Hosts: []string{"x.com", "twitter.com", "mobile.twitter.com"},
+		Profiles: []socialMediaProfile{
+			{Path: regexp.MustCompile(`^/([A-Za-z0-9_]{1,15})/?$`), Canonical: "https://x.com/%s"},
+		},
+	},
+	{
+		Name:  SocialMediaYouTube,
+		Label: "YouTube",
+		Hosts: []string{"youtube.com", "m.youtube.com"},
+		Profiles: []socialMediaProfile{
+			{Path: regexp.MustCompile(`^/@([A-Za-z0-9._-]{3,30})/?$`), Canonical: "https://www.youtube.com/@%s"},
+			{Path: regexp.MustCompile(`^/channel/(UC[A-Za-z0-9_-]{22})/?$`), Canonical: "https://www.youtube.com/channel/%s"},
+		},
+	},
+	{
+		Name:  SocialMediaDiscord,
+		Label: "Discord",
+		Hosts: []string{"discord.gg", "discord.com", "discordapp.com"},
+		Profiles: []socialMediaProfile{
+			{Path: regexp.MustCompile(`^/(?:invite/)?([A-Za-z0-9-]{2,32})/?$`), Canonical: "https://discord.gg/%s"},
+		},
+	},
+}
+
+// FindSocialMediaPlatform looks a platform up by name. Custom links have no
+// catalog entry.
+func FindSocialMediaPlatform(name string) (SocialMediaPlatform, bool) {
+	for _, platform := range SocialMediaPlatforms {
+		if platform.Name == name {
+			return platform, true
+		}
+	}
+	return SocialMediaPlatform{}, false
+}
+
+// SocialMediaLink is a link resolved against the catalog.
+type SocialMediaLink struct {
+	Platform string
+	Handle   string
+	URL      string
+}
+
+// ResolveSocialMediaLink validates a link and normalizes it to its canonical
+// URL. Without a platform it is detected from the host, unknown hosts being
+// custom links.
+func ResolveSocialMediaLink(platformName string, rawURL string) (SocialMediaLink, error) {
+	platformName = strings.ToLower(strings.TrimSpace(platformName))
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return SocialMediaLink{}, ErrorInvalidSocialMediaURL
+	}
+
+	platform, known := FindSocialMediaPlatform(platformName)
+	if platformName != "" && platformName != SocialMediaCustom && !known {
+		return SocialMediaLink{}, ErrorInvalidSocialMediaPlatform
+	}
+
+	parsed, err := parseSocialMediaURL(rawURL)
+	if err != nil {
+		return SocialMediaLink{}, err
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+
+	if platformName == "" {
+		platform, known = platformOfHost(host)
+	}
+	if !known {
+		return SocialMediaLink{
+			Platform: SocialMediaCustom,
+			URL:      canonicalCustomURL(parsed),
+		}, nil
+	}
+
+	if !hasHost(platform, host) {
+		return SocialMediaLink{}, ErrorInvalidSocialMediaURL
+	}
+	for _, profile := range platform.Profiles {
+		match := profile.Path.FindStringSubmatch(parsed.Path)
+		if match == nil {
+			continue
+		}
+		return SocialMediaLink{
+			Platform: platform.Name,
+			Handle:   match[1],
+			URL:      fmt.Sprintf(profile.Canonical, match[1]),
+		}, nil
+	}
+	return SocialMediaLink{}, ErrorInvalidSocialMediaURL
+}
+
+// IsCatalogSocialMediaLink reports whether a stored link belongs to a catalog
+// platform and its URL still matches one of the platform's profiles. Custom
+// links never do.
+func IsCatalogSocialMediaLink(platformName string, rawURL string) bool {
+	if platformName == SocialMediaCustom {
+		return false
+	}
+	link, err := ResolveSocialMediaLink(platformName, rawURL)
+	return err == nil && link.Platform == platformName
+}
+
+// DisplaySocialMediaURL is the link without scheme, "www." and trailing slash
+// for showing on profiles.
+func DisplaySocialMediaURL(link string) string {
+	display := strings.TrimPrefix(strings.TrimPrefix(link, "https://"), "http://")
+	return strings.TrimSuffix(strings.TrimPrefix(display, "www."), "/")
+}
+
+// parseSocialMediaURL parses an http(s) URL. Links given without a scheme are
+// taken as https.
+func parseSocialMediaURL(rawURL string) (*url.URL, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || !strings.Contains(parsed.Hostname(), ".") || parsed.User != nil {
+		return nil, ErrorInvalidSocialMediaURL
+	}
+	return parsed, nil
+}
+
+// canonicalCustomURL lowercases scheme and host and drops the fragment and a
+// trailing slash, so trivially different spellings count as duplicates.
+func canonicalCustomURL(parsed *url.URL) string {
+	canonical := *parsed
+	canonical.Scheme = strings.ToLower(canonical.Scheme)
+	canonical.Host = strings.ToLower(canonical.Host)
+	canonical.Fragment = ""
+	canonical.RawFragment = ""
+	canonical.Path = strings.TrimSuffix(canonical.Path, "/")
+	canonical.RawPath = ""
+	return canonical.String()
+}
+
+func platformOfHost(host string) (SocialMediaPlatform, bool) {
+	for _, platform := range SocialMediaPlatforms {
+		if hasHost(platform, host) {
+			return platform, true
+		}
+	}
+	return SocialMediaPlatform{}, false
+}
+
+func hasHost(platform SocialMediaPlatform, host string) bool {
+	for _, known := range platform.Hosts {
+		if host == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Response
+type SocialMediaPlatformResponse struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+}
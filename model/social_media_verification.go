@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// SocialMediaChallengePrefix starts the challenge users place on the linked
+// page, followed by the link's verification token.
+const SocialMediaChallengePrefix = "mygram-verification="
+
+// SocialMediaChallenge is the text the verifier looks for on the linked page.
+func SocialMediaChallenge(token string) string {
+	return SocialMediaChallengePrefix + token
+}
+
+// Response
+type SocialMediaVerificationResponse struct {
+	SocialMediaID  string     `json:"social_media_id"`
+	SocialMediaURL string     `json:"social_media_url"`
+	Challenge      string     `json:"challenge"`
+	VerifiedAt     *time.Time `json:"verified_at"`
+}
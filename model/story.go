@@ -0,0 +1,80 @@
+package model
+
+import "time"
+
+// StoryLifetime is how long a story stays up before the reaper deletes it.
+const StoryLifetime = 24 * time.Hour
+
+// Story is an uploaded photo shared with followers until ExpiresAt. Its file
+// is stored under FileName and deleted together with the story.
+type Story struct {
+	ID        string    `gorm:"primaryKey"`
+	UserID    string    `gorm:"not null;index"`
+	MediaURL  string    `gorm:"not null;type:varchar(255)"`
+	FileName  string    `gorm:"not null;type:varchar(255)"`
+	Caption   string    `gorm:"not null;type:varchar(255);default:''"`
+	AltText   string    `gorm:"not null;type:varchar(255);default:''"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	Views     []StoryView
+	CreatedAt time.Time
+}
+
+// StoryView records the first time a viewer saw a story.
+type StoryView struct {
+	StoryID   string `gorm:"primaryKey"`
+	ViewerID  string `gorm:"primaryKey;index"`
+	Viewer    User   `gorm:"foreignKey:ViewerID"`
+	CreatedAt time.Time
+}
+
+// Request
+type StoryUploadRequest struct {
+	Caption string `form:"caption" valid:"maxstringlength(255)~validation.caption_too_long"`
+	AltText string `form:"alt_text" valid:"maxstringlength(255)~validation.alt_text_too_long"`
+}
+
+// Response
+type StoryResponse struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	MediaURL  string    `json:"media_url"`
+	Caption   string    `json:"caption"`
+	AltText   string    `json:"alt_text"`
+	Seen      bool      `json:"seen"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToStoryResponse(story Story, seen bool) StoryResponse {
+	return StoryResponse{
+		ID:        story.ID,
+		UserID:    story.UserID,
+		MediaURL:  story.MediaURL,
+		Caption:   story.Caption,
+		AltText:   story.AltText,
+		Seen:      seen,
+		ExpiresAt: story.ExpiresAt,
+		CreatedAt: story.CreatedAt,
+	}
+}
+
+// StoryFeedResponse groups the stories of one user, oldest first as they are
+// played. AllSeen tells clients to show the group as watched.
+type StoryFeedResponse struct {
+	UserID   string          `json:"user_id"`
+	Username string          `json:"username"`
+	AllSeen  bool            `json:"all_seen"`
+	Stories  []StoryResponse `json:"stories"`
+}
+
+type StoryViewerResponse struct {
+	UserID   string    `json:"user_id"`
+	Username string    `json:"username"`
+	SeenAt   time.Time `json:"seen_at"`
+}
+
+type StoryViewerListResponse struct {
+	StoryID    string                `json:"story_id"`
+	Viewers    []StoryViewerResponse `json:"viewers"`
+	Pagination PaginationResponse    `json:"pagination"`
+}
@@ -4,24 +4,48 @@ import (
 	"time"
 )
 
+const (
+	UserRoleUser      = "user"
+	UserRoleModerator = "moderator"
+	UserRoleAdmin     = "admin"
+)
+
 type User struct {
-	ID           string `gorm:"primaryKey" `
-	Username     string `gorm:"not null;unique;type:varchar(30)" `
-	Email        string `gorm:"not null;unique;type:varchar(255)"`
-	Password     string `gorm:"not null;type:varchar(255)"`
-	Age          int    `gorm:"not null;size:2"`
-	Photos       []Photo
-	Comments     []Comment
-	SocialMedias []SocialMedia
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID        string `gorm:"primaryKey" `
+	Username  string `gorm:"not null;unique;type:varchar(30)" `
+	Email     string `gorm:"not null;unique;type:varchar(255)"`
+	Password  string `gorm:"not null;type:varchar(255)"`
+	Age       int    `gorm:"not null;size:2"`
+	IsPrivate bool   `gorm:"not null;default:false"`
+	Role      string `gorm:"not null;type:varchar(10);default:user"`
+	// Locale is the language the user is answered in, empty follows the
+	// Accept-Language header.
+	Locale         string `gorm:"not null;type:varchar(10);default:''"`
+	SuspendedUntil *time.Time
+	Photos         []Photo
+	Comments       []Comment
+	SocialMedias   []SocialMedia
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	SearchVector string `gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(username, ''))) STORED;index:idx_users_search_vector,type:gin"`
+}
+
+// IsSuspended reports whether a moderator suspension is still running at now.
+func (u User) IsSuspended(now time.Time) bool {
+	return u.SuspendedUntil != nil && u.SuspendedUntil.After(now)
+}
+
+// IsModerator reports whether the user may review other users' content.
+func (u User) IsModerator() bool {
+	return u.Role == UserRoleModerator || u.Role == UserRoleAdmin
 }
 
 type UserRegisterRequest struct {
-	Email    string `json:"email" valid:"required~Email is required,email~Invalid email address"`
-	Username string `json:"username" valid:"required~Username is required"`
-	Password string `json:"password" valid:"required~Password is required,minstringlength(6)~Password atleast 6 characters"`
-	Age      int    `json:"age" valid:"required~Age is required,range(8|99)~Age minimum is 8"`
+	Email    string `json:"email" valid:"required~validation.email_required,email~validation.email_invalid"`
+	Username string `json:"username" valid:"required~validation.username_required,matches(^[A-Za-z0-9._]+$)~validation.username_invalid_characters,maxstringlength(30)~validation.username_too_long"`
+	Password string `json:"password" valid:"required~validation.password_required,minstringlength(6)~validation.password_too_short"`
+	Age      int    `json:"age" valid:"required~validation.age_required,range(8|99)~validation.age_out_of_range"`
 }
 
 type UserRegisterResponse struct {
@@ -31,9 +55,17 @@ type UserRegisterResponse struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type UserPrivacyRequest struct {
+	IsPrivate bool `json:"is_private"`
+}
+
+type UserLocaleRequest struct {
+	Locale string `json:"locale"`
+}
+
 type UserLoginRequest struct {
-	Username string `json:"username" valid:"required~Username is required"`
-	Password string `json:"password" valid:"required~Password is required"`
+	Username string `json:"username" valid:"required~validation.username_required"`
+	Password string `json:"password" valid:"required~validation.password_required"`
 }
 type UserLoginResponse struct {
 	Token string `json:"token"`
@@ -44,6 +76,7 @@ type UserGramResponse struct {
 	Email        string                     `json:"email"`
 	Username     string                     `json:"username"`
 	Age          int                        `json:"age"`
+	IsPrivate    bool                       `json:"is_private"`
 	Photos       []ListPhotoResponse        `json:"my_photos"`
 	Comments     []ListCommentResponse      `json:"my_comments"`
 	SocialMedias []ListSocialMediasResponse `json:"my_social_medias"`
@@ -51,6 +84,30 @@ type UserGramResponse struct {
 	UpdatedAt    time.Time                  `json:"updated_at"`
 }
 
+// UserProfileResponse omits photos and social medias when the viewer may not see them.
+type UserProfileResponse struct {
+	ID             string                     `json:"id"`
+	Username       string                     `json:"username"`
+	IsPrivate      bool                       `json:"is_private"`
+	FollowersCount int64                      `json:"followers_count"`
+	FollowingCount int64                      `json:"following_count"`
+	FollowStatus   string                     `json:"follow_status"`
+	CanViewContent bool                       `json:"can_view_content"`
+	Photos         []ListPhotoResponse        `json:"photos"`
+	SocialMedias   []ListSocialMediasResponse `json:"social_medias"`
+	CreatedAt      time.Time                  `json:"created_at"`
+}
+
+type UserPrivacyResponse struct {
+	ID        string `json:"id"`
+	IsPrivate bool   `json:"is_private"`
+}
+
+type UserLocaleResponse struct {
+	ID     string `json:"id"`
+	Locale string `json:"locale"`
+}
+
 type ListPhotoResponse struct {
 	ID        string    `json:"id"`
 	Title     string    `json:"title"`
@@ -61,11 +118,17 @@ type ListPhotoResponse struct {
 }
 
 type ListSocialMediasResponse struct {
-	ID             string    `json:"id"`
-	Name           string    `json:"name"`
-	SocialMediaURL string    `json:"social_media_url"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	SocialMediaURL string     `json:"social_media_url"`
+	Platform       string     `json:"platform"`
+	Handle         string     `json:"handle"`
+	DisplayURL     string     `json:"display_url"`
+	Position       int        `json:"position"`
+	Visibility     string     `json:"visibility"`
+	VerifiedAt     *time.Time `json:"verified_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
 type ListCommentResponse struct {
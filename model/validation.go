@@ -0,0 +1,155 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"mygram/i18n"
+	"reflect"
+	"strings"
+
+	valid "github.com/asaskevich/govalidator"
+)
+
+// ValidateRequest checks request against its valid tags. A failing request
+// is answered with ErrorValidation listing every failed field under the name
+// clients send it by, its JSON key or form field. The messages in valid tags
+// are catalog keys, as in required~validation.title_required.
+func ValidateRequest(request interface{}) error {
+	ok, err := valid.ValidateStruct(request)
+	if err == nil && ok {
+		return nil
+	}
+
+	details := toFieldErrors(reflect.TypeOf(request), err)
+	if len(details) == 0 {
+		if err != nil {
+			return ErrorInvalidRequest.WithMessage(err.Error())
+		}
+		return ErrorInvalidRequest
+	}
+	return ErrorValidation.WithDetails(details...)
+}
+
+// BindError is the error for a request body or query that could not be
+// decoded. Values of the wrong type are reported for their field.
+func BindError(err error) error {
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		kind := typeError.Type.Kind().String()
+		return ErrorValidation.WithDetails(FieldError{
+			Field:   typeError.Field,
+			Code:    "type",
+			Message: i18n.Translate(i18n.DefaultLanguage, "validation.type", "type", kind),
+			Key:     "validation.type",
+			Args:    []string{"type", kind},
+		})
+	}
+	return ErrorInvalidRequest.WithMessage(err.Error())
+}
+
+func toFieldErrors(requestType reflect.Type, err error) []FieldError {
+	switch err := err.(type) {
+	case valid.Errors:
+		details := make([]FieldError, 0, len(err))
+		for _, inner := range err {
+			details = append(details, toFieldErrors(requestType, inner)...)
+		}
+		return details
+	case valid.Error:
+		key := err.Err.Error()
+		if !err.CustomErrorMessageExists {
+			key = "validation.invalid"
+		}
+		return []FieldError{{
+			Field:   requestFieldName(requestType, err.Path, err.Name),
+			Code:    validationCode(err.Validator),
+			Message: i18n.Translate(i18n.DefaultLanguage, key),
+			Key:     key,
+		}}
+	}
+	return nil
+}
+
+// requestFieldName turns the Go field path of a validation error into the
+// dotted path of request keys, as in location.place_name or media.0. Errors
+// inside slice items carry the key of the slice as their name.
+func requestFieldName(requestType reflect.Type, path []string, name string) string {
+	keys := make([]string, 0, len(path)+1)
+	for _, segment := range strings.Split(strings.Join(path, "."), ".") {
+		if segment == "" {
+			continue
+		}
+		requestType = elemType(requestType)
+		field, found := structField(requestType, segment)
+		if !found {
+			keys = append(keys, segment)
+			continue
+		}
+		keys = append(keys, requestKey(field))
+		requestType = field.Type
+	}
+
+	field, found := structField(elemType(requestType), name)
+	switch {
+	case found:
+		keys = append(keys, requestKey(field))
+	case !containsKey(keys, name):
+		keys = append(keys, name)
+	}
+	return strings.Join(keys, ".")
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	return t
+}
+
+// structField finds a field by its Go name, or by its JSON key as
+// govalidator already reports fields carrying a json tag by their key.
+func structField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	if field, found := t.FieldByName(name); found {
+		return field, true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if requestKey(t.Field(i)) == name {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func requestKey(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		key := strings.Split(field.Tag.Get(tag), ",")[0]
+		if key != "" && key != "-" {
+			return key
+		}
+	}
+	return field.Name
+}
+
+// validationCode is the stable code of a failed govalidator rule, its name
+// without parameters, as in maxstringlength.
+func validationCode(validator string) string {
+	if i := strings.Index(validator, "("); i >= 0 {
+		validator = validator[:i]
+	}
+	if validator == "" {
+		return "invalid"
+	}
+	return strings.ToLower(validator)
+}
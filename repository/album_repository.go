@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"errors"
+	"mygram/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IAlbumRepository
+type IAlbumRepository interface {
+	GetByUserID(userId string) ([]model.Album, error)
+	GetOne(id string) (model.Album, error)
+	Save(album model.Album) (model.Album, error)
+	Update(updateAlbum model.Album, id string) (model.Album, error)
+	UpdateCover(id string, coverPhotoId *string) error
+	Delete(id string) error
+	AddPhoto(id string, photoId string) error
+	RemovePhoto(id string, photoId string) error
+	ReorderPhotos(id string, photoIds []string) error
+}
+type AlbumRepository struct {
+	db *gorm.DB
+}
+
+func NewAlbumRepository(db *gorm.DB) *AlbumRepository {
+	return &AlbumRepository{
+		db: db,
+	}
+}
+
+func (ar *AlbumRepository) preloadPhotos(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("AlbumPhotos", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Preload("AlbumPhotos.Photo")
+}
+
+func (ar *AlbumRepository) GetByUserID(userId string) ([]model.Album, error) {
+	albums := make([]model.Album, 0)
+
+	tx := ar.preloadPhotos(ar.db).
+		Where("user_id = ?", userId).
+		Order("created_at DESC").
+		Find(&albums)
+	return albums, tx.Error
+}
+
+func (ar *AlbumRepository) GetOne(id string) (model.Album, error) {
+	album := model.Album{}
+
+	tx := ar.preloadPhotos(ar.db).First(&album, "id = ?", id)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return model.Album{}, model.ErrorNotFound
+	}
+	return album, tx.Error
+}
+
+func (ar *AlbumRepository) Save(album model.Album) (model.Album, error) {
+	tx := ar.db.Create(&album)
+	return album, tx.Error
+}
+
+func (ar *AlbumRepository) Update(updateAlbum model.Album, id string) (model.Album, error) {
+	tx := ar.db.
+		Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Select("title", "description", "visibility").
+		Updates(&updateAlbum)
+	return updateAlbum, tx.Error
+}
+
+func (ar *AlbumRepository) UpdateCover(id string, coverPhotoId *string) error {
+	tx := ar.db.
+		Model(&model.Album{}).
+		Where("id = ?", id).
+		Update("cover_photo_id", coverPhotoId)
+	return tx.Error
+}
+
+func (ar *AlbumRepository) Delete(id string) error {
+	album := model.Album{
+		ID: id,
+	}
+
+	tx := ar.db.Select("AlbumPhotos").Delete(&album)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// AddPhoto appends the photo after the last position in the album. The album
+// row is locked so concurrent adds cannot claim the same position.
+func (ar *AlbumRepository) AddPhoto(id string, photoId string) error {
+	return ar.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&model.Album{}, "id = ?", id).Error
+		if err != nil {
+			return err
+		}
+
+		var position int
+		err = tx.Model(&model.AlbumPhoto{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("album_id = ?", id).
+			Scan(&position).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&model.AlbumPhoto{
+			AlbumID:  id,
+			PhotoID:  photoId,
+			Position: position + 1,
+		}).Error
+	})
+}
+
+func (ar *AlbumRepository) RemovePhoto(id string, photoId string) error {
+	return ar.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&model.AlbumPhoto{}, "album_id = ? AND photo_id = ?", id, photoId).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Album{}).
+			Where("id = ? AND cover_photo_id = ?", id, photoId).
+			Update("cover_photo_id", nil).Error
+	})
+}
+
+// ReorderPhotos rewrites every position so photoIds[0] becomes position 1.
+func (ar *AlbumRepository) ReorderPhotos(id string, photoIds []string) error {
+	return ar.db.Transaction(func(tx *gorm.DB) error {
+		for i, photoId := range photoIds {
+			err := tx.Model(&model.AlbumPhoto{}).
+				Where("album_id = ? AND photo_id = ?", id, photoId).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
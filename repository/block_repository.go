@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"mygram/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IBlockRepository
+type IBlockRepository interface {
+	GetByBlockerID(blockerId string) ([]model.Block, error)
+	GetBlockedIDs(userId string, userIds []string) ([]string, error)
+	Save(block model.Block) (model.Block, error)
+	Delete(blockerId string, blockedId string) error
+}
+type BlockRepository struct {
+	db *gorm.DB
+}
+
+func NewBlockRepository(db *gorm.DB) *BlockRepository {
+	return &BlockRepository{
+		db: db,
+	}
+}
+
+func (br *BlockRepository) GetByBlockerID(blockerId string) ([]model.Block, error) {
+	blocks := make([]model.Block, 0)
+
+	tx := br.db.
+		Preload("Blocked").
+		Where("blocker_id = ?", blockerId).
+		Order("created_at DESC").
+		Find(&blocks)
+	return blocks, tx.Error
+}
+
+// GetBlockedIDs returns the subset of userIds that have a block with userId in
+// either direction.
+func (br *BlockRepository) GetBlockedIDs(userId string, userIds []string) ([]string, error) {
+	blocked := make([]string, 0)
+	if len(userIds) == 0 {
+		return blocked, nil
+	}
+
+	tx := br.db.Raw(`
+		SELECT blocked_id FROM blocks WHERE blocker_id = ? AND blocked_id IN ?
+		UNION
+		SELECT blocker_id FROM blocks WHERE blocked_id = ? AND blocker_id IN ?`,
+		userId, userIds, userId, userIds,
+	).Scan(&blocked)
+	return blocked, tx.Error
+}
+
+// Save blocks a user and drops follows and follow requests in both directions.
+func (br *BlockRepository) Save(block model.Block) (model.Block, error) {
+	err := br.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&block)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return model.ErrorAlreadyBlocked
+		}
+
+		return tx.Delete(&model.Follow{},
+			"(follower_id = ? AND following_id = ?) OR (follower_id = ? AND following_id = ?)",
+			block.BlockerID, block.BlockedID, block.BlockedID, block.BlockerID,
+		).Error
+	})
+	if err != nil {
+		return model.Block{}, err
+	}
+	return block, nil
+}
+
+func (br *BlockRepository) Delete(blockerId string, blockedId string) error {
+	tx := br.db.Delete(&model.Block{}, "blocker_id = ? AND blocked_id = ?", blockerId, blockedId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.ErrorNotFound
+	}
+	return nil
+}
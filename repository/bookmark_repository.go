@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"mygram/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IBookmarkRepository
+type IBookmarkRepository interface {
+	GetByUserID(userId string, collection string, pagination model.PaginationRequest) ([]model.Bookmark, int64, error)
+	GetBookmarkedPhotoIDs(userId string, photoIds []string) ([]string, error)
+	Save(bookmark model.Bookmark) (model.Bookmark, error)
+	Delete(userId string, photoId string) error
+}
+type BookmarkRepository struct {
+	db *gorm.DB
+}
+
+func NewBookmarkRepository(db *gorm.DB) *BookmarkRepository {
+	return &BookmarkRepository{
+		db: db,
+	}
+}
+
+// GetByUserID lists bookmarks newest first. An empty collection matches every collection.
+// Bookmarks of photos the user can no longer see are left out of both the
+// page and the total.
+func (br *BookmarkRepository) GetByUserID(userId string, collection string, pagination model.PaginationRequest) ([]model.Bookmark, int64, error) {
+	bookmarks := make([]model.Bookmark, 0)
+	var total int64
+
+	query := br.db.
+		Model(&model.Bookmark{}).
+		Where("user_id = ?", userId).
+		Where(visiblePhoto("bookmarks.photo_id"), visibilityArgs(userId)...)
+	if collection != "" {
+		query = query.Where("collection = ?", collection)
+	}
+
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return bookmarks, 0, tx.Error
+	}
+
+	tx = query.
+		Preload("Photo").
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&bookmarks)
+	return bookmarks, total, tx.Error
+}
+
+func (br *BookmarkRepository) GetBookmarkedPhotoIDs(userId string, photoIds []string) ([]string, error) {
+	bookmarked := make([]string, 0)
+	if len(photoIds) == 0 {
+		return bookmarked, nil
+	}
+
+	tx := br.db.
+		Model(&model.Bookmark{}).
+		Where("user_id = ? AND photo_id IN ?", userId, photoIds).
+		Pluck("photo_id", &bookmarked)
+	return bookmarked, tx.Error
+}
+
+// Save relies on the unique (user_id, photo_id) index to reject duplicates.
+func (br *BookmarkRepository) Save(bookmark model.Bookmark) (model.Bookmark, error) {
+	tx := br.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&bookmark)
+	if tx.Error != nil {
+		return model.Bookmark{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.Bookmark{}, model.ErrorAlreadyBookmarked
+	}
+	return bookmark, nil
+}
+
+func (br *BookmarkRepository) Delete(userId string, photoId string) error {
+	tx := br.db.Delete(&model.Bookmark{}, "user_id = ? AND photo_id = ?", userId, photoId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.ErrorNotFound
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 	"mygram/model"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -12,8 +13,15 @@ import (
 type ICommentRepository interface {
 	Get() ([]model.Comment, error)
 	GetOne(id string) (model.Comment, error)
+	GetByPhotoID(photoId string, viewerId string, pagination model.PaginationRequest) ([]model.Comment, int64, error)
+	GetByUserID(userId string, pagination model.PaginationRequest) ([]model.Comment, int64, error)
+	GetRecentByUserID(userId string, since time.Time) ([]model.Comment, error)
 	Save(comment model.Comment) (model.Comment, error)
-	Update(updateComment model.Comment, id string) (model.Comment, error)
+	Update(updateComment model.Comment, id string, revision model.CommentRevision) (model.Comment, error)
+	GetRevisions(id string) ([]model.CommentRevision, error)
+	UpdateHidden(id string, hidden bool) error
+	Pin(id string, photoId string, limit int) error
+	Unpin(id string) error
 	Delete(id string) error
 }
 type CommentRepository struct {
@@ -43,32 +51,179 @@ func (cr *CommentRepository) GetOne(id string) (model.Comment, error) {
 	return comment, tx.Error
 }
 
+// GetByPhotoID lists the comments on a photo, pinned ones first and then in
+// the order they were written. Hidden comments and comments of authors
+// viewerId may not see are left out of both the page and the total.
+func (cr *CommentRepository) GetByPhotoID(photoId string, viewerId string, pagination model.PaginationRequest) ([]model.Comment, int64, error) {
+	comments := make([]model.Comment, 0)
+	var total int64
+
+	query := cr.db.
+		Model(&model.Comment{}).
+		Where("photo_id = ?", photoId).
+		Where(visibleUser("comments.user_id")+" AND (NOT comments.hidden OR comments.user_id = @viewer)", visibilityArgs(viewerId)...)
+
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return comments, 0, tx.Error
+	}
+
+	tx = query.
+		Order("pinned_at IS NULL, pinned_at, created_at").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&comments)
+	return comments, total, tx.Error
+}
+
+// GetByUserID lists the comments written by userId, newest first, leaving out
+// comments on photos the user can no longer see.
+func (cr *CommentRepository) GetByUserID(userId string, pagination model.PaginationRequest) ([]model.Comment, int64, error) {
+	comments := make([]model.Comment, 0)
+	var total int64
+
+	query := cr.db.
+		Model(&model.Comment{}).
+		Where("user_id = ?", userId).
+		Where(visiblePhoto("comments.photo_id"), visibilityArgs(userId)...)
+
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return comments, 0, tx.Error
+	}
+
+	tx = query.
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&comments)
+	return comments, total, tx.Error
+}
+
+func (cr *CommentRepository) GetRecentByUserID(userId string, since time.Time) ([]model.Comment, error) {
+	comments := make([]model.Comment, 0)
+
+	tx := cr.db.
+		Where("user_id = ? AND created_at >= ?", userId, since).
+		Order("created_at DESC").
+		Find(&comments)
+	return comments, tx.Error
+}
+
 func (cr *CommentRepository) Save(comment model.Comment) (model.Comment, error) {
 	tx := cr.db.Create(&comment)
 	return comment, tx.Error
 }
 
-func (cr *CommentRepository) Update(updateComment model.Comment, id string) (model.Comment, error) {
-	tx := cr.db.
-		Clauses(clause.Returning{
-			Columns: []clause.Column{
-				{Name: "id"},
-				{Name: "user_id"},
-				{Name: "photo_id"},
-				{Name: "created_at"},
-				{Name: "updated_at"},
+// Update replaces the message and stores the replaced one as revision. The row
+// is locked, so concurrent edits each record the version they actually
+// replaced. Saving an unchanged message records nothing. A Hidden update hides
+// the comment in the same write, so held text never shows; otherwise hidden is
+// left as it is.
+func (cr *CommentRepository) Update(updateComment model.Comment, id string, revision model.CommentRevision) (model.Comment, error) {
+	err := cr.db.Transaction(func(tx *gorm.DB) error {
+		current := model.Comment{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "id = ?", id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrorNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if current.Message != updateComment.Message {
+			revision.CommentID = id
+			revision.Message = current.Message
+			err = tx.Create(&revision).Error
+			if err != nil {
+				return err
+			}
+			updateComment.EditedAt = &revision.CreatedAt
+		}
+
+		return tx.
+			Clauses(clause.Returning{
+				Columns: []clause.Column{
+					{Name: "id"},
+					{Name: "user_id"},
+					{Name: "photo_id"},
+					{Name: "hidden"},
+					{Name: "edited_at"},
+					{Name: "created_at"},
+					{Name: "updated_at"},
+				},
 			},
-		},
-		).
+			).
+			Where("id = ?", id).
+			Updates(&updateComment).Error
+	})
+	return updateComment, err
+}
+
+func (cr *CommentRepository) GetRevisions(id string) ([]model.CommentRevision, error) {
+	revisions := make([]model.CommentRevision, 0)
+
+	tx := cr.db.
+		Where("comment_id = ?", id).
+		Order("created_at ASC").
+		Find(&revisions)
+	return revisions, tx.Error
+}
+
+func (cr *CommentRepository) UpdateHidden(id string, hidden bool) error {
+	tx := cr.db.
+		Model(&model.Comment{}).
 		Where("id = ?", id).
-		Updates(&updateComment)
-	return updateComment, tx.Error
+		Update("hidden", hidden)
+	return tx.Error
+}
+
+// Pin pins the comment unless its photo already has limit pinned comments. The
+// photo row is locked so concurrent pins cannot go over the limit. Pinning a
+// pinned comment keeps its place.
+func (cr *CommentRepository) Pin(id string, photoId string, limit int) error {
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&model.Photo{}, "id = ?", photoId).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrorNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var pinned int64
+		err = tx.Model(&model.Comment{}).
+			Where("photo_id = ? AND pinned_at IS NOT NULL AND id <> ?", photoId, id).
+			Count(&pinned).Error
+		if err != nil {
+			return err
+		}
+		if pinned >= int64(limit) {
+			return model.ErrorPinLimitReached
+		}
+
+		return tx.Model(&model.Comment{}).
+			Where("id = ? AND pinned_at IS NULL", id).
+			Update("pinned_at", time.Now()).Error
+	})
+}
+
+func (cr *CommentRepository) Unpin(id string) error {
+	tx := cr.db.
+		Model(&model.Comment{}).
+		Where("id = ?", id).
+		Update("pinned_at", nil)
+	return tx.Error
 }
 
 func (cr *CommentRepository) Delete(id string) error {
-	comment := model.Comment{}
+	comment := model.Comment{
+		ID: id,
+	}
 
-	tx := cr.db.Delete(&comment, "id = ?", id)
+	tx := cr.db.Select("Revisions", "Reactions").Delete(&comment)
 	if tx.Error != nil {
 		return tx.Error
 	}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"errors"
+	"mygram/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IConversationRepository
+type IConversationRepository interface {
+	GetOne(id string) (model.Conversation, error)
+	GetByUserID(userId string, pagination model.PaginationRequest) ([]model.Conversation, int64, error)
+	GetUnreadCounts(userId string, conversationIds []string) ([]model.UnreadCount, error)
+	GetLastMessages(conversationIds []string) ([]model.Message, error)
+	GetMessages(conversationId string, pagination model.PaginationRequest) ([]model.Message, int64, error)
+	Save(conversation model.Conversation) (model.Conversation, error)
+	SaveMessage(message model.Message) (model.Message, error)
+	MarkRead(conversationId string, userId string, readAt time.Time) error
+}
+type ConversationRepository struct {
+	db *gorm.DB
+}
+
+func NewConversationRepository(db *gorm.DB) *ConversationRepository {
+	return &ConversationRepository{
+		db: db,
+	}
+}
+
+func (cr *ConversationRepository) GetOne(id string) (model.Conversation, error) {
+	conversation := model.Conversation{}
+
+	tx := cr.db.Preload("Participants").First(&conversation, "id = ?", id)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return model.Conversation{}, model.ErrorNotFound
+	}
+	return conversation, tx.Error
+}
+
+// GetByUserID lists the conversations userId takes part in, the ones with the
+// latest messages first. One-to-one conversations with a user blocked either
+// way are left out of both the page and the total.
+func (cr *ConversationRepository) GetByUserID(userId string, pagination model.PaginationRequest) ([]model.Conversation, int64, error) {
+	conversations := make([]model.Conversation, 0)
+	var total int64
+
+	joined := cr.db.
+		Model(&model.ConversationParticipant{}).
+		Select("conversation_id").
+		Where("user_id = ?", userId)
+	query := cr.db.
+		Model(&model.Conversation{}).
+		Where("id IN (?)", joined).
+		Where("(is_group OR NOT EXISTS (SELECT 1 FROM conversation_participants other"+
+			" WHERE other.conversation_id = conversations.id AND other.user_id <> @viewer AND "+blockedWith("other.user_id")+"))",
+			visibilityArgs(userId)...)
+
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return conversations, 0, tx.Error
+	}
+
+	tx = query.
+		Preload("Participants").
+		Order("COALESCE(last_message_at, created_at) DESC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&conversations)
+	return conversations, total, tx.Error
+}
+
+// GetUnreadCounts counts the messages of others sent after userId last read
+// each conversation.
+func (cr *ConversationRepository) GetUnreadCounts(userId string, conversationIds []string) ([]model.UnreadCount, error) {
+	counts := make([]model.UnreadCount, 0)
+	if len(conversationIds) == 0 {
+		return counts, nil
+	}
+
+	tx := cr.db.
+		Table("messages").
+		Select("messages.conversation_id, COUNT(*) AS count").
+		Joins("JOIN conversation_participants ON conversation_participants.conversation_id = messages.conversation_id AND conversation_participants.user_id = ?", userId).
+		Where("messages.conversation_id IN ?", conversationIds).
+		Where("messages.sender_id <> ?", userId).
+		Where("conversation_participants.last_read_at IS NULL OR messages.created_at > conversation_participants.last_read_at").
+		Group("messages.conversation_id").
+		Scan(&counts)
+	return counts, tx.Error
+}
+
+// GetLastMessages returns the latest message of each conversation.
+func (cr *ConversationRepository) GetLastMessages(conversationIds []string) ([]model.Message, error) {
+	messages := make([]model.Message, 0)
+	if len(conversationIds) == 0 {
+		return messages, nil
+	}
+
+	latest := cr.db.
+		Model(&model.Message{}).
+		Select("DISTINCT ON (conversation_id) id").
+		Where("conversation_id IN ?", conversationIds).
+		Order("conversation_id, created_at DESC")
+
+	tx := cr.db.
+		Preload("Photo").
+		Where("id IN (?)", latest).
+		Find(&messages)
+	return messages, tx.Error
+}
+
+// GetMessages lists the messages of a conversation, newest first.
+func (cr *ConversationRepository) GetMessages(conversationId string, pagination model.PaginationRequest) ([]model.Message, int64, error) {
+	messages := make([]model.Message, 0)
+	var total int64
+
+	tx := cr.db.
+		Model(&model.Message{}).
+		Where("conversation_id = ?", conversationId).
+		Count(&total)
+	if tx.Error != nil {
+		return messages, 0, tx.Error
+	}
+
+	tx = cr.db.
+		Preload("Photo").
+		Where("conversation_id = ?", conversationId).
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&messages)
+	return messages, total, tx.Error
+}
+
+// Save creates the conversation with its participants. When a one-to-one
+// conversation of the same users already exists, that one is returned.
+func (cr *ConversationRepository) Save(conversation model.Conversation) (model.Conversation, error) {
+	err := cr.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Omit("Participants").
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "direct_key"}}, DoNothing: true}).
+			Create(&conversation)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return tx.Preload("Participants").First(&conversation, "direct_key = ?", conversation.DirectKey).Error
+		}
+
+		return tx.Create(&conversation.Participants).Error
+	})
+	if err != nil {
+		return model.Conversation{}, err
+	}
+	return conversation, nil
+}
+
+// SaveMessage stores the message, bumps the conversation to the top of the
+// list and marks it read for its sender.
+func (cr *ConversationRepository) SaveMessage(message model.Message) (model.Message, error) {
+	err := cr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Photo").Create(&message).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.Conversation{}).
+			Where("id = ?", message.ConversationID).
+			Update("last_message_at", message.CreatedAt).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.ConversationParticipant{}).
+			Where("conversation_id = ? AND user_id = ?", message.ConversationID, message.SenderID).
+			Update("last_read_at", message.CreatedAt).Error
+	})
+	return message, err
+}
+
+// MarkRead moves the read receipt of userId forward to readAt. An older
+// readAt leaves it where it is.
+func (cr *ConversationRepository) MarkRead(conversationId string, userId string, readAt time.Time) error {
+	tx := cr.db.
+		Model(&model.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationId, userId).
+		Where("last_read_at IS NULL OR last_read_at < ?", readAt).
+		Update("last_read_at", readAt)
+	return tx.Error
+}
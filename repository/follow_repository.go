@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"mygram/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IFollowRepository
+type IFollowRepository interface {
+	GetOne(followerId string, followingId string) (model.Follow, error)
+	GetAcceptedFollowingIDs(followerId string, followingIds []string) ([]string, error)
+	GetPendingRequests(followingId string) ([]model.Follow, error)
+	CountFollowers(userId string) (int64, error)
+	CountFollowing(userId string) (int64, error)
+	Save(follow model.Follow) (model.Follow, error)
+	Accept(followerId string, followingId string) error
+	AcceptAllPending(followingId string) error
+	Delete(followerId string, followingId string) error
+}
+type FollowRepository struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) *FollowRepository {
+	return &FollowRepository{
+		db: db,
+	}
+}
+
+func (fr *FollowRepository) GetOne(followerId string, followingId string) (model.Follow, error) {
+	follow := model.Follow{}
+
+	tx := fr.db.First(&follow, "follower_id = ? AND following_id = ?", followerId, followingId)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return model.Follow{}, model.ErrorNotFound
+	}
+	return follow, tx.Error
+}
+
+func (fr *FollowRepository) GetAcceptedFollowingIDs(followerId string, followingIds []string) ([]string, error) {
+	following := make([]string, 0)
+	if len(followingIds) == 0 {
+		return following, nil
+	}
+
+	tx := fr.db.
+		Model(&model.Follow{}).
+		Where("follower_id = ? AND following_id IN ? AND status = ?", followerId, followingIds, model.FollowStatusAccepted).
+		Pluck("following_id", &following)
+	return following, tx.Error
+}
+
+func (fr *FollowRepository) GetPendingRequests(followingId string) ([]model.Follow, error) {
+	follows := make([]model.Follow, 0)
+
+	tx := fr.db.
+		Preload("Follower").
+		Where("following_id = ? AND status = ?", followingId, model.FollowStatusPending).
+		Order("created_at ASC").
+		Find(&follows)
+	return follows, tx.Error
+}
+
+func (fr *FollowRepository) CountFollowers(userId string) (int64, error) {
+	var count int64
+
+	tx := fr.db.
+		Model(&model.Follow{}).
+		Where("following_id = ? AND status = ?", userId, model.FollowStatusAccepted).
+		Count(&count)
+	return count, tx.Error
+}
+
+func (fr *FollowRepository) CountFollowing(userId string) (int64, error) {
+	var count int64
+
+	tx := fr.db.
+		Model(&model.Follow{}).
+		Where("follower_id = ? AND status = ?", userId, model.FollowStatusAccepted).
+		Count(&count)
+	return count, tx.Error
+}
+
+func (fr *FollowRepository) Save(follow model.Follow) (model.Follow, error) {
+	tx := fr.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&follow)
+	if tx.Error != nil {
+		return model.Follow{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.Follow{}, model.ErrorAlreadyFollowing
+	}
+	return follow, nil
+}
+
+func (fr *FollowRepository) Accept(followerId string, followingId string) error {
+	tx := fr.db.
+		Model(&model.Follow{}).
+		Where("follower_id = ? AND following_id = ? AND status = ?", followerId, followingId, model.FollowStatusPending).
+		Update("status", model.FollowStatusAccepted)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.ErrorNotFound
+	}
+	return nil
+}
+
+func (fr *FollowRepository) AcceptAllPending(followingId string) error {
+	tx := fr.db.
+		Model(&model.Follow{}).
+		Where("following_id = ? AND status = ?", followingId, model.FollowStatusPending).
+		Update("status", model.FollowStatusAccepted)
+	return tx.Error
+}
+
+func (fr *FollowRepository) Delete(followerId string, followingId string) error {
+	tx := fr.db.Delete(&model.Follow{}, "follower_id = ? AND following_id = ?", followerId, followingId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.ErrorNotFound
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"mygram/model"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:generate mockery --name IImageRepository
+type IImageRepository interface {
+	Save(name string, data []byte) (string, error)
+	Delete(name string) error
+	Path(name string) (string, error)
+}
+
+// ImageRepository stores uploaded images on the local disk. Files are served
+// from baseURL, which maps to dir, by a handler checking who may see them.
+type ImageRepository struct {
+	dir     string
+	baseURL string
+}
+
+func NewImageRepository(dir string, baseURL string) *ImageRepository {
+	return &ImageRepository{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Save writes the image and returns its public URL.
+func (ir *ImageRepository) Save(name string, data []byte) (string, error) {
+	err := os.MkdirAll(ir.dir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(filepath.Join(ir.dir, filepath.Base(name)), data, 0o644)
+	if err != nil {
+		return "", err
+	}
+	return ir.baseURL + "/" + filepath.Base(name), nil
+}
+
+func (ir *ImageRepository) Delete(name string) error {
+	err := os.Remove(filepath.Join(ir.dir, filepath.Base(name)))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Path returns where the image is stored on disk, or ErrorNotFound when there
+// is no such file.
+func (ir *ImageRepository) Path(name string) (string, error) {
+	path := filepath.Join(ir.dir, filepath.Base(name))
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) || (err == nil && info.IsDir()) {
+		return "", model.ErrorNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	model "mygram/model"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -53,6 +55,72 @@ func (_m *ICommentRepository) Get() ([]model.Comment, error) {
 	return r0, r1
 }
 
+// GetByPhotoID provides a mock function with given fields: photoId, viewerId, pagination
+func (_m *ICommentRepository) GetByPhotoID(photoId string, viewerId string, pagination model.PaginationRequest) ([]model.Comment, int64, error) {
+	ret := _m.Called(photoId, viewerId, pagination)
+
+	var r0 []model.Comment
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, model.PaginationRequest) ([]model.Comment, int64, error)); ok {
+		return rf(photoId, viewerId, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, model.PaginationRequest) []model.Comment); ok {
+		r0 = rf(photoId, viewerId, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, model.PaginationRequest) int64); ok {
+		r1 = rf(photoId, viewerId, pagination)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, model.PaginationRequest) error); ok {
+		r2 = rf(photoId, viewerId, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByUserID provides a mock function with given fields: userId, pagination
+func (_m *ICommentRepository) GetByUserID(userId string, pagination model.PaginationRequest) ([]model.Comment, int64, error) {
+	ret := _m.Called(userId, pagination)
+
+	var r0 []model.Comment
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, model.PaginationRequest) ([]model.Comment, int64, error)); ok {
+		return rf(userId, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.PaginationRequest) []model.Comment); ok {
+		r0 = rf(userId, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.PaginationRequest) int64); ok {
+		r1 = rf(userId, pagination)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, model.PaginationRequest) error); ok {
+		r2 = rf(userId, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetOne provides a mock function with given fields: id
 func (_m *ICommentRepository) GetOne(id string) (model.Comment, error) {
 	ret := _m.Called(id)
@@ -77,6 +145,72 @@ func (_m *ICommentRepository) GetOne(id string) (model.Comment, error) {
 	return r0, r1
 }
 
+// GetRecentByUserID provides a mock function with given fields: userId, since
+func (_m *ICommentRepository) GetRecentByUserID(userId string, since time.Time) ([]model.Comment, error) {
+	ret := _m.Called(userId, since)
+
+	var r0 []model.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) ([]model.Comment, error)); ok {
+		return rf(userId, since)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) []model.Comment); ok {
+		r0 = rf(userId, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(userId, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: id
+func (_m *ICommentRepository) GetRevisions(id string) ([]model.CommentRevision, error) {
+	ret := _m.Called(id)
+
+	var r0 []model.CommentRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]model.CommentRevision, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) []model.CommentRevision); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CommentRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Pin provides a mock function with given fields: id, photoId, limit
+func (_m *ICommentRepository) Pin(id string, photoId string, limit int) error {
+	ret := _m.Called(id, photoId, limit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int) error); ok {
+		r0 = rf(id, photoId, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: comment
 func (_m *ICommentRepository) Save(comment model.Comment) (model.Comment, error) {
 	ret := _m.Called(comment)
@@ -101,23 +235,37 @@ func (_m *ICommentRepository) Save(comment model.Comment) (model.Comment, error)
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: updateComment, id
-func (_m *ICommentRepository) Update(updateComment model.Comment, id string) (model.Comment, error) {
-	ret := _m.Called(updateComment, id)
+// Unpin provides a mock function with given fields: id
+func (_m *ICommentRepository) Unpin(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: updateComment, id, revision
+func (_m *ICommentRepository) Update(updateComment model.Comment, id string, revision model.CommentRevision) (model.Comment, error) {
+	ret := _m.Called(updateComment, id, revision)
 
 	var r0 model.Comment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.Comment, string) (model.Comment, error)); ok {
-		return rf(updateComment, id)
+	if rf, ok := ret.Get(0).(func(model.Comment, string, model.CommentRevision) (model.Comment, error)); ok {
+		return rf(updateComment, id, revision)
 	}
-	if rf, ok := ret.Get(0).(func(model.Comment, string) model.Comment); ok {
-		r0 = rf(updateComment, id)
+	if rf, ok := ret.Get(0).(func(model.Comment, string, model.CommentRevision) model.Comment); ok {
+		r0 = rf(updateComment, id, revision)
 	} else {
 		r0 = ret.Get(0).(model.Comment)
 	}
 
-	if rf, ok := ret.Get(1).(func(model.Comment, string) error); ok {
-		r1 = rf(updateComment, id)
+	if rf, ok := ret.Get(1).(func(model.Comment, string, model.CommentRevision) error); ok {
+		r1 = rf(updateComment, id, revision)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -125,6 +273,20 @@ func (_m *ICommentRepository) Update(updateComment model.Comment, id string) (mo
 	return r0, r1
 }
 
+// UpdateHidden provides a mock function with given fields: id, hidden
+func (_m *ICommentRepository) UpdateHidden(id string, hidden bool) error {
+	ret := _m.Called(id, hidden)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(id, hidden)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewICommentRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -5,6 +5,8 @@ package mocks
 import (
 	model "mygram/model"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -53,6 +55,91 @@ func (_m *IPhotoRepository) Get() ([]model.Photo, error) {
 	return r0, r1
 }
 
+// GetByIDs provides a mock function with given fields: ids
+func (_m *IPhotoRepository) GetByIDs(ids []string) ([]model.Photo, error) {
+	ret := _m.Called(ids)
+
+	var r0 []model.Photo
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]model.Photo, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []model.Photo); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Photo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMissingAltText provides a mock function with given fields: userId, pagination
+func (_m *IPhotoRepository) GetMissingAltText(userId string, pagination model.PaginationRequest) ([]model.Photo, int64, error) {
+	ret := _m.Called(userId, pagination)
+
+	var r0 []model.Photo
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, model.PaginationRequest) ([]model.Photo, int64, error)); ok {
+		return rf(userId, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.PaginationRequest) []model.Photo); ok {
+		r0 = rf(userId, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Photo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.PaginationRequest) int64); ok {
+		r1 = rf(userId, pagination)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, model.PaginationRequest) error); ok {
+		r2 = rf(userId, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetNearby provides a mock function with given fields: latitude, longitude, radiusKm, limit
+func (_m *IPhotoRepository) GetNearby(latitude float64, longitude float64, radiusKm float64, limit int) ([]model.PhotoDistance, error) {
+	ret := _m.Called(latitude, longitude, radiusKm, limit)
+
+	var r0 []model.PhotoDistance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(float64, float64, float64, int) ([]model.PhotoDistance, error)); ok {
+		return rf(latitude, longitude, radiusKm, limit)
+	}
+	if rf, ok := ret.Get(0).(func(float64, float64, float64, int) []model.PhotoDistance); ok {
+		r0 = rf(latitude, longitude, radiusKm, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PhotoDistance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(float64, float64, float64, int) error); ok {
+		r1 = rf(latitude, longitude, radiusKm, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOne provides a mock function with given fields: id
 func (_m *IPhotoRepository) GetOne(id string) (model.Photo, error) {
 	ret := _m.Called(id)
@@ -77,6 +164,91 @@ func (_m *IPhotoRepository) GetOne(id string) (model.Photo, error) {
 	return r0, r1
 }
 
+// GetSimilar provides a mock function with given fields: hash, maxDistance, userId, limit
+func (_m *IPhotoRepository) GetSimilar(hash int64, maxDistance int, userId string, limit int) ([]model.PhotoSimilarity, error) {
+	ret := _m.Called(hash, maxDistance, userId, limit)
+
+	var r0 []model.PhotoSimilarity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, string, int) ([]model.PhotoSimilarity, error)); ok {
+		return rf(hash, maxDistance, userId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, string, int) []model.PhotoSimilarity); ok {
+		r0 = rf(hash, maxDistance, userId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PhotoSimilarity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, string, int) error); ok {
+		r1 = rf(hash, maxDistance, userId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnpublished provides a mock function with given fields: userId, pagination
+func (_m *IPhotoRepository) GetUnpublished(userId string, pagination model.PaginationRequest) ([]model.Photo, int64, error) {
+	ret := _m.Called(userId, pagination)
+
+	var r0 []model.Photo
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, model.PaginationRequest) ([]model.Photo, int64, error)); ok {
+		return rf(userId, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.PaginationRequest) []model.Photo); ok {
+		r0 = rf(userId, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Photo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.PaginationRequest) int64); ok {
+		r1 = rf(userId, pagination)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, model.PaginationRequest) error); ok {
+		r2 = rf(userId, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PublishDue provides a mock function with given fields: now
+func (_m *IPhotoRepository) PublishDue(now time.Time) ([]model.Photo, error) {
+	ret := _m.Called(now)
+
+	var r0 []model.Photo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]model.Photo, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []model.Photo); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Photo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: photo
 func (_m *IPhotoRepository) Save(photo model.Photo) (model.Photo, error) {
 	ret := _m.Called(photo)
@@ -102,21 +274,21 @@ func (_m *IPhotoRepository) Save(photo model.Photo) (model.Photo, error) {
 }
 
 // Update provides a mock function with given fields: updatePhoto, id
-func (_m *IPhotoRepository) Update(updatePhoto model.Photo, id string) (model.Photo, error) {
+func (_m *IPhotoRepository) Update(updatePhoto model.PhotoUpdate, id string) (model.Photo, error) {
 	ret := _m.Called(updatePhoto, id)
 
 	var r0 model.Photo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.Photo, string) (model.Photo, error)); ok {
+	if rf, ok := ret.Get(0).(func(model.PhotoUpdate, string) (model.Photo, error)); ok {
 		return rf(updatePhoto, id)
 	}
-	if rf, ok := ret.Get(0).(func(model.Photo, string) model.Photo); ok {
+	if rf, ok := ret.Get(0).(func(model.PhotoUpdate, string) model.Photo); ok {
 		r0 = rf(updatePhoto, id)
 	} else {
 		r0 = ret.Get(0).(model.Photo)
 	}
 
-	if rf, ok := ret.Get(1).(func(model.Photo, string) error); ok {
+	if rf, ok := ret.Get(1).(func(model.PhotoUpdate, string) error); ok {
 		r1 = rf(updatePhoto, id)
 	} else {
 		r1 = ret.Error(1)
@@ -125,6 +297,34 @@ func (_m *IPhotoRepository) Update(updatePhoto model.Photo, id string) (model.Ph
 	return r0, r1
 }
 
+// UpdateCommentPolicy provides a mock function with given fields: id, commentPolicy
+func (_m *IPhotoRepository) UpdateCommentPolicy(id string, commentPolicy string) error {
+	ret := _m.Called(id, commentPolicy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, commentPolicy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateHidden provides a mock function with given fields: id, hidden
+func (_m *IPhotoRepository) UpdateHidden(id string, hidden bool) error {
+	ret := _m.Called(id, hidden)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(id, hidden)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewIPhotoRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -5,6 +5,8 @@ package mocks
 import (
 	model "mygram/model"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -53,6 +55,84 @@ func (_m *ISocialMediaRepository) Get() ([]model.SocialMedia, error) {
 	return r0, r1
 }
 
+// GetByUserID provides a mock function with given fields: userId
+func (_m *ISocialMediaRepository) GetByUserID(userId string) ([]model.SocialMedia, error) {
+	ret := _m.Called(userId)
+
+	var r0 []model.SocialMedia
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]model.SocialMedia, error)); ok {
+		return rf(userId)
+	}
+	if rf, ok := ret.Get(0).(func(string) []model.SocialMedia); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SocialMedia)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClicks provides a mock function with given fields: socialMediaIds, from
+func (_m *ISocialMediaRepository) GetClicks(socialMediaIds []string, from time.Time) ([]model.SocialMediaClick, error) {
+	ret := _m.Called(socialMediaIds, from)
+
+	var r0 []model.SocialMediaClick
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string, time.Time) ([]model.SocialMediaClick, error)); ok {
+		return rf(socialMediaIds, from)
+	}
+	if rf, ok := ret.Get(0).(func([]string, time.Time) []model.SocialMediaClick); ok {
+		r0 = rf(socialMediaIds, from)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SocialMediaClick)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, time.Time) error); ok {
+		r1 = rf(socialMediaIds, from)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueForRecheck provides a mock function with given fields: checkedBefore, limit
+func (_m *ISocialMediaRepository) GetDueForRecheck(checkedBefore time.Time, limit int) ([]model.SocialMedia, error) {
+	ret := _m.Called(checkedBefore, limit)
+
+	var r0 []model.SocialMedia
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, int) ([]model.SocialMedia, error)); ok {
+		return rf(checkedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, int) []model.SocialMedia); ok {
+		r0 = rf(checkedBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SocialMedia)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, int) error); ok {
+		r1 = rf(checkedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOne provides a mock function with given fields: id
 func (_m *ISocialMediaRepository) GetOne(id string) (model.SocialMedia, error) {
 	ret := _m.Called(id)
@@ -101,6 +181,20 @@ func (_m *ISocialMediaRepository) Save(socialMedia model.SocialMedia) (model.Soc
 	return r0, r1
 }
 
+// SaveClick provides a mock function with given fields: socialMediaId, day
+func (_m *ISocialMediaRepository) SaveClick(socialMediaId string, day time.Time) error {
+	ret := _m.Called(socialMediaId, day)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(socialMediaId, day)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: updateSocialMedia, id
 func (_m *ISocialMediaRepository) Update(updateSocialMedia model.SocialMedia, id string) (model.SocialMedia, error) {
 	ret := _m.Called(updateSocialMedia, id)
@@ -125,6 +219,48 @@ func (_m *ISocialMediaRepository) Update(updateSocialMedia model.SocialMedia, id
 	return r0, r1
 }
 
+// UpdatePositions provides a mock function with given fields: userId, ids
+func (_m *ISocialMediaRepository) UpdatePositions(userId string, ids []string) error {
+	ret := _m.Called(userId, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(userId, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateVerification provides a mock function with given fields: id, verifiedAt, checkedAt
+func (_m *ISocialMediaRepository) UpdateVerification(id string, verifiedAt *time.Time, checkedAt time.Time) error {
+	ret := _m.Called(id, verifiedAt, checkedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *time.Time, time.Time) error); ok {
+		r0 = rf(id, verifiedAt, checkedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateVerificationToken provides a mock function with given fields: id, token
+func (_m *ISocialMediaRepository) UpdateVerificationToken(id string, token string) error {
+	ret := _m.Called(id, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewISocialMediaRepository interface {
 	mock.TestingT
 	Cleanup(func())
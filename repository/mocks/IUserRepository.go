@@ -5,6 +5,8 @@ package mocks
 import (
 	model "mygram/model"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,6 +15,32 @@ type IUserRepository struct {
 	mock.Mock
 }
 
+// GetByIDs provides a mock function with given fields: ids
+func (_m *IUserRepository) GetByIDs(ids []string) ([]model.User, error) {
+	ret := _m.Called(ids)
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]model.User, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []model.User); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUsername provides a mock function with given fields: username
 func (_m *IUserRepository) GetByUsername(username string) (model.User, error) {
 	ret := _m.Called(username)
@@ -61,6 +89,30 @@ func (_m *IUserRepository) GetDetailUser(id string) (model.User, error) {
 	return r0, r1
 }
 
+// GetOne provides a mock function with given fields: id
+func (_m *IUserRepository) GetOne(id string) (model.User, error) {
+	ret := _m.Called(id)
+
+	var r0 model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (model.User, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) model.User); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(model.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: newUser
 func (_m *IUserRepository) Save(newUser model.User) (model.User, error) {
 	ret := _m.Called(newUser)
@@ -85,6 +137,48 @@ func (_m *IUserRepository) Save(newUser model.User) (model.User, error) {
 	return r0, r1
 }
 
+// UpdateLocale provides a mock function with given fields: id, locale
+func (_m *IUserRepository) UpdateLocale(id string, locale string) error {
+	ret := _m.Called(id, locale)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, locale)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePrivacy provides a mock function with given fields: id, isPrivate
+func (_m *IUserRepository) UpdatePrivacy(id string, isPrivate bool) error {
+	ret := _m.Called(id, isPrivate)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(id, isPrivate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSuspension provides a mock function with given fields: id, suspendedUntil
+func (_m *IUserRepository) UpdateSuspension(id string, suspendedUntil *time.Time) error {
+	ret := _m.Called(id, suspendedUntil)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *time.Time) error); ok {
+		r0 = rf(id, suspendedUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewIUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
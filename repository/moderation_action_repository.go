@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"mygram/model"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name IModerationActionRepository
+type IModerationActionRepository interface {
+	Get(pagination model.PaginationRequest) ([]model.ModerationAction, int64, error)
+	GetByTargetUserID(userId string, action string) ([]model.ModerationAction, error)
+	Save(action model.ModerationAction) (model.ModerationAction, error)
+}
+type ModerationActionRepository struct {
+	db *gorm.DB
+}
+
+func NewModerationActionRepository(db *gorm.DB) *ModerationActionRepository {
+	return &ModerationActionRepository{
+		db: db,
+	}
+}
+
+func (mar *ModerationActionRepository) Get(pagination model.PaginationRequest) ([]model.ModerationAction, int64, error) {
+	actions := make([]model.ModerationAction, 0)
+	var total int64
+
+	tx := mar.db.Model(&model.ModerationAction{}).Count(&total)
+	if tx.Error != nil {
+		return actions, 0, tx.Error
+	}
+
+	tx = mar.db.
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&actions)
+	return actions, total, tx.Error
+}
+
+func (mar *ModerationActionRepository) GetByTargetUserID(userId string, action string) ([]model.ModerationAction, error) {
+	actions := make([]model.ModerationAction, 0)
+
+	tx := mar.db.
+		Where("target_user_id = ? AND action = ?", userId, action).
+		Order("created_at DESC").
+		Find(&actions)
+	return actions, tx.Error
+}
+
+func (mar *ModerationActionRepository) Save(action model.ModerationAction) (model.ModerationAction, error) {
+	tx := mar.db.Create(&action)
+	return action, tx.Error
+}
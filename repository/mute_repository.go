@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"mygram/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IMuteRepository
+type IMuteRepository interface {
+	GetByMuterID(muterId string) ([]model.Mute, error)
+	GetMutedIDs(muterId string, userIds []string) ([]string, error)
+	Save(mute model.Mute) (model.Mute, error)
+	Delete(muterId string, mutedId string) error
+}
+type MuteRepository struct {
+	db *gorm.DB
+}
+
+func NewMuteRepository(db *gorm.DB) *MuteRepository {
+	return &MuteRepository{
+		db: db,
+	}
+}
+
+func (mr *MuteRepository) GetByMuterID(muterId string) ([]model.Mute, error) {
+	mutes := make([]model.Mute, 0)
+
+	tx := mr.db.
+		Preload("Muted").
+		Where("muter_id = ?", muterId).
+		Order("created_at DESC").
+		Find(&mutes)
+	return mutes, tx.Error
+}
+
+func (mr *MuteRepository) GetMutedIDs(muterId string, userIds []string) ([]string, error) {
+	muted := make([]string, 0)
+	if len(userIds) == 0 {
+		return muted, nil
+	}
+
+	tx := mr.db.
+		Model(&model.Mute{}).
+		Where("muter_id = ? AND muted_id IN ?", muterId, userIds).
+		Pluck("muted_id", &muted)
+	return muted, tx.Error
+}
+
+func (mr *MuteRepository) Save(mute model.Mute) (model.Mute, error) {
+	tx := mr.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&mute)
+	if tx.Error != nil {
+		return model.Mute{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.Mute{}, model.ErrorAlreadyMuted
+	}
+	return mute, nil
+}
+
+func (mr *MuteRepository) Delete(muterId string, mutedId string) error {
+	tx := mr.db.Delete(&model.Mute{}, "muter_id = ? AND muted_id = ?", muterId, mutedId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.ErrorNotFound
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"mygram/model"
 
 	"fmt"
+	"math"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -14,8 +16,16 @@ import (
 type IPhotoRepository interface {
 	Get() ([]model.Photo, error)
 	GetOne(id string) (model.Photo, error)
+	GetByIDs(ids []string) ([]model.Photo, error)
+	GetNearby(latitude float64, longitude float64, radiusKm float64, limit int) ([]model.PhotoDistance, error)
 	Save(photo model.Photo) (model.Photo, error)
-	Update(updatePhoto model.Photo, id string) (model.Photo, error)
+	Update(updatePhoto model.PhotoUpdate, id string) (model.Photo, error)
+	UpdateHidden(id string, hidden bool) error
+	UpdateCommentPolicy(id string, commentPolicy string) error
+	GetMissingAltText(userId string, pagination model.PaginationRequest) ([]model.Photo, int64, error)
+	GetSimilar(hash int64, maxDistance int, userId string, limit int) ([]model.PhotoSimilarity, error)
+	GetUnpublished(userId string, pagination model.PaginationRequest) ([]model.Photo, int64, error)
+	PublishDue(now time.Time) ([]model.Photo, error)
 	Delete(id string) error
 }
 type PhotoRepository struct {
@@ -28,10 +38,15 @@ func NewPhotoRepository(db *gorm.DB) *PhotoRepository {
 	}
 }
 
+// orderByPosition preloads carousel media in post order.
+func orderByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
 func (pr *PhotoRepository) Get() ([]model.Photo, error) {
 	photo := make([]model.Photo, 0)
 
-	tx := pr.db.Preload("Comments").Find(&photo)
+	tx := pr.db.Preload("Comments").Preload("Metadata").Preload("Media", orderByPosition).Where("status = ?", model.PhotoStatusPublished).Find(&photo)
 	fmt.Println(photo)
 	return photo, tx.Error
 }
@@ -41,42 +56,386 @@ func (pr *PhotoRepository) GetOne(id string) (model.Photo, error) {
 		ID: id,
 	}
 
-	tx := pr.db.Preload("Comments").First(&photo)
+	tx := pr.db.Preload("Comments").Preload("Metadata").Preload("Media", orderByPosition).First(&photo)
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return model.Photo{}, model.ErrorNotFound
 	}
 	return photo, tx.Error
 }
 
+func (pr *PhotoRepository) GetByIDs(ids []string) ([]model.Photo, error) {
+	photos := make([]model.Photo, 0)
+	if len(ids) == 0 {
+		return photos, nil
+	}
+
+	tx := pr.db.Where("id IN ?", ids).Find(&photos)
+	return photos, tx.Error
+}
+
+const earthRadiusKm = 6371.0
+
+// haversineSQL is the great circle distance in km from the point bound to its
+// three placeholders (latitude, latitude, longitude). least() guards asin
+// against rounding just above 1.
+const haversineSQL = `2 * 6371 * asin(least(1, sqrt(
+	power(sin(radians(latitude - ?) / 2), 2) +
+	cos(radians(?)) * cos(radians(latitude)) * power(sin(radians(longitude - ?) / 2), 2)
+)))`
+
+// GetNearby returns up to limit photos within radiusKm, closest first. A
+// bounding box on the indexed coordinates narrows the rows before the exact
+// Haversine distance is computed, so no PostGIS is needed.
+func (pr *PhotoRepository) GetNearby(latitude float64, longitude float64, radiusKm float64, limit int) ([]model.PhotoDistance, error) {
+	photos := make([]model.PhotoDistance, 0)
+	distances := make([]struct {
+		ID         string
+		DistanceKm float64
+	}, 0)
+
+	deltaLatitude := radiusKm / earthRadiusKm * 180 / math.Pi
+	minLatitude, maxLatitude := latitude-deltaLatitude, latitude+deltaLatitude
+
+	candidates := pr.db.
+		Model(&model.Photo{}).
+		Select("id, "+haversineSQL+" AS distance_km", latitude, latitude, longitude).
+		Where("status = ?", model.PhotoStatusPublished).
+		Where("latitude BETWEEN ? AND ?", minLatitude, maxLatitude)
+
+	// Longitude degrees shrink towards the poles. Near a pole every longitude
+	// is in range, across the antimeridian the box wraps around.
+	if minLatitude > -90 && maxLatitude < 90 {
+		deltaLongitude := deltaLatitude / math.Cos(latitude*math.Pi/180)
+		minLongitude, maxLongitude := longitude-deltaLongitude, longitude+deltaLongitude
+		switch {
+		case deltaLongitude >= 180:
+		case minLongitude < -180:
+			candidates = candidates.Where("(longitude >= ? OR longitude <= ?)", minLongitude+360, maxLongitude)
+		case maxLongitude > 180:
+			candidates = candidates.Where("(longitude >= ? OR longitude <= ?)", minLongitude, maxLongitude-360)
+		default:
+			candidates = candidates.Where("longitude BETWEEN ? AND ?", minLongitude, maxLongitude)
+		}
+	}
+
+	tx := pr.db.
+		Table("(?) AS nearby", candidates).
+		Where("distance_km <= ?", radiusKm).
+		Order("distance_km ASC").
+		Limit(limit).
+		Scan(&distances)
+	if tx.Error != nil || len(distances) == 0 {
+		return photos, tx.Error
+	}
+
+	ids := make([]string, 0, len(distances))
+	for _, distance := range distances {
+		ids = append(ids, distance.ID)
+	}
+	res, err := pr.GetByIDs(ids)
+	if err != nil {
+		return photos, err
+	}
+	byId := make(map[string]model.Photo, len(res))
+	for _, photo := range res {
+		byId[photo.ID] = photo
+	}
+
+	for _, distance := range distances {
+		if photo, ok := byId[distance.ID]; ok {
+			photos = append(photos, model.PhotoDistance{
+				Photo:      photo,
+				DistanceKm: distance.DistanceKm,
+			})
+		}
+	}
+	return photos, nil
+}
+
+// GetMissingAltText lists photos of userId, newest first, with a media item
+// lacking alt text or only carrying a suggestion. Photos posted before
+// carousels have no media rows and never had alt text.
+func (pr *PhotoRepository) GetMissingAltText(userId string, pagination model.PaginationRequest) ([]model.Photo, int64, error) {
+	photos := make([]model.Photo, 0)
+	var total int64
+
+	query := pr.db.
+		Model(&model.Photo{}).
+		Where("user_id = ?", userId).
+		Where("NOT EXISTS (SELECT 1 FROM photo_media WHERE photo_media.photo_id = photos.id) OR " +
+			"EXISTS (SELECT 1 FROM photo_media WHERE photo_media.photo_id = photos.id AND (photo_media.alt_text = '' OR photo_media.alt_text_generated))")
+
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return photos, 0, tx.Error
+	}
+
+	tx = query.
+		Preload("Media", orderByPosition).
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&photos)
+	return photos, total, tx.Error
+}
+
+// hammingSQL counts the bits differing between perceptual_hash and the hash
+// bound to its placeholder.
+const hammingSQL = "length(replace(((perceptual_hash # ?)::bit(64))::text, '0', ''))"
+
+// GetSimilar returns up to limit photos whose perceptual hash is within
+// maxDistance bits of hash, closest and then oldest first. An empty userId
+// searches every account.
+func (pr *PhotoRepository) GetSimilar(hash int64, maxDistance int, userId string, limit int) ([]model.PhotoSimilarity, error) {
+	photos := make([]model.PhotoSimilarity, 0)
+	distances := make([]struct {
+		ID       string
+		Distance int
+	}, 0)
+
+	candidates := pr.db.
+		Model(&model.Photo{}).
+		Select("id, created_at, "+hammingSQL+" AS distance", hash).
+		Where("perceptual_hash IS NOT NULL")
+	if userId != "" {
+		candidates = candidates.Where("user_id = ?", userId)
+	}
+
+	tx := pr.db.
+		Table("(?) AS similar", candidates).
+		Where("distance <= ?", maxDistance).
+		Order("distance ASC, created_at ASC").
+		Limit(limit).
+		Scan(&distances)
+	if tx.Error != nil || len(distances) == 0 {
+		return photos, tx.Error
+	}
+
+	ids := make([]string, 0, len(distances))
+	for _, distance := range distances {
+		ids = append(ids, distance.ID)
+	}
+	res, err := pr.GetByIDs(ids)
+	if err != nil {
+		return photos, err
+	}
+	byId := make(map[string]model.Photo, len(res))
+	for _, photo := range res {
+		byId[photo.ID] = photo
+	}
+
+	for _, distance := range distances {
+		if photo, ok := byId[distance.ID]; ok {
+			photos = append(photos, model.PhotoSimilarity{
+				Photo:    photo,
+				Distance: distance.Distance,
+			})
+		}
+	}
+	return photos, nil
+}
+
+// GetUnpublished lists the drafts and scheduled photos of userId, the ones
+// due first and then the newest drafts.
+func (pr *PhotoRepository) GetUnpublished(userId string, pagination model.PaginationRequest) ([]model.Photo, int64, error) {
+	photos := make([]model.Photo, 0)
+	var total int64
+
+	query := pr.db.
+		Model(&model.Photo{}).
+		Where("user_id = ?", userId).
+		Where("status <> ?", model.PhotoStatusPublished)
+
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return photos, 0, tx.Error
+	}
+
+	tx = query.
+		Preload("Media", orderByPosition).
+		Order("publish_at ASC NULLS LAST, created_at DESC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&photos)
+	return photos, total, tx.Error
+}
+
+// PublishDue publishes the scheduled photos whose publish time has come and
+// returns them. The status check and change are a single UPDATE, so when
+// several instances run it at once every photo is returned to exactly one.
+func (pr *PhotoRepository) PublishDue(now time.Time) ([]model.Photo, error) {
+	photos := make([]model.Photo, 0)
+
+	tx := pr.db.
+		Model(&photos).
+		Clauses(clause.Returning{}).
+		Where("status = ? AND publish_at <= ?", model.PhotoStatusScheduled, now).
+		Update("status", model.PhotoStatusPublished)
+	return photos, tx.Error
+}
+
 func (pr *PhotoRepository) Save(photo model.Photo) (model.Photo, error) {
 	tx := pr.db.Create(&photo)
 	return photo, tx.Error
 }
 
-func (pr *PhotoRepository) Update(updatePhoto model.Photo, id string) (model.Photo, error) {
-	tx := pr.db.
-		Clauses(clause.Returning{
-			Columns: []clause.Column{
-				{Name: "id"},
-				{Name: "user_id"},
-				{Name: "created_at"},
-				{Name: "updated_at"},
+// Update writes a photo update in one transaction, so a failing write leaves
+// the photo as it was. Status changes never apply to published photos.
+func (pr *PhotoRepository) Update(updatePhoto model.PhotoUpdate, id string) (model.Photo, error) {
+	photo := model.Photo{
+		Title:   updatePhoto.Title,
+		Caption: updatePhoto.Caption,
+	}
+
+	err := pr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Returning{
+				Columns: []clause.Column{
+					{Name: "id"},
+					{Name: "user_id"},
+					{Name: "created_at"},
+					{Name: "updated_at"},
+				},
 			},
-		},
-		).
+			).
+			Where("id = ?", id).
+			Updates(&photo).Error
+		if err != nil {
+			return err
+		}
+
+		if updatePhoto.Location != nil {
+			err = tx.Model(&model.Photo{}).
+				Where("id = ?", id).
+				Updates(map[string]interface{}{
+					"latitude":   updatePhoto.Location.Latitude,
+					"longitude":  updatePhoto.Location.Longitude,
+					"place_name": updatePhoto.Location.PlaceName,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if updatePhoto.Media != nil {
+			err = replaceMedia(tx, id, updatePhoto.Media)
+			if err != nil {
+				return err
+			}
+		}
+
+		if updatePhoto.ShareMetadata != nil {
+			err = tx.Model(&model.Photo{}).
+				Where("id = ?", id).
+				Update("share_metadata", *updatePhoto.ShareMetadata).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if updatePhoto.Status != nil {
+			err = tx.Model(&model.Photo{}).
+				Where("id = ? AND status <> ?", id, model.PhotoStatusPublished).
+				Updates(map[string]interface{}{
+					"status":     *updatePhoto.Status,
+					"publish_at": updatePhoto.PublishAt,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if updatePhoto.Hide {
+			return tx.Model(&model.Photo{}).
+				Where("id = ?", id).
+				Update("hidden", true).Error
+		}
+		return nil
+	})
+	return photo, err
+}
+
+func (pr *PhotoRepository) UpdateHidden(id string, hidden bool) error {
+	tx := pr.db.
+		Model(&model.Photo{}).
+		Where("id = ?", id).
+		Update("hidden", hidden)
+	return tx.Error
+}
+
+func (pr *PhotoRepository) UpdateCommentPolicy(id string, commentPolicy string) error {
+	tx := pr.db.
+		Model(&model.Photo{}).
 		Where("id = ?", id).
-		Updates(&updatePhoto)
-	return updatePhoto, tx.Error
+		Update("comment_policy", commentPolicy)
+	return tx.Error
 }
 
+// replaceMedia swaps every media item of the photo, mirroring the first one as
+// its photo URL and their alt text for search.
+func replaceMedia(tx *gorm.DB, id string, media []model.PhotoMedia) error {
+	err := tx.Delete(&model.PhotoMedia{}, "photo_id = ?", id).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Create(&media).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&model.Photo{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"photo_url": media[0].URL,
+			"alt_text":  model.JoinAltText(media),
+		}).Error
+}
+
+// Delete removes the photo together with its metadata, media, comments,
+// bookmarks and album memberships, clearing it as cover of any album that
+// selected it.
 func (pr *PhotoRepository) Delete(id string) error {
 	photo := model.Photo{
 		ID: id,
 	}
 
-	tx := pr.db.Select("Comments").Delete(&photo)
-	if tx.Error != nil {
-		return tx.Error
-	}
-	return nil
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&model.AlbumPhoto{}, "photo_id = ?", id).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Delete(&model.Bookmark{}, "photo_id = ?", id).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.Album{}).
+			Where("cover_photo_id = ?", id).
+			Update("cover_photo_id", nil).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.Message{}).
+			Where("photo_id = ?", id).
+			Update("photo_id", nil).Error
+		if err != nil {
+			return err
+		}
+
+		comments := tx.Model(&model.Comment{}).Select("id").Where("photo_id = ?", id)
+		err = tx.Delete(&model.CommentRevision{}, "comment_id IN (?)", comments).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Delete(&model.CommentReaction{}, "comment_id IN (?)", comments).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Select("Metadata", "Media", "Comments").Delete(&photo).Error
+	})
 }
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"mygram/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IReactionRepository
+type IReactionRepository interface {
+	CountByCommentIDs(commentIds []string) ([]model.ReactionCount, error)
+	Save(reaction model.CommentReaction) (model.CommentReaction, error)
+	Delete(commentId string, userId string, emoji string) error
+}
+type ReactionRepository struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) *ReactionRepository {
+	return &ReactionRepository{
+		db: db,
+	}
+}
+
+// CountByCommentIDs counts reactions per comment and emoji for a whole page of
+// comments in one query.
+func (rr *ReactionRepository) CountByCommentIDs(commentIds []string) ([]model.ReactionCount, error) {
+	counts := make([]model.ReactionCount, 0)
+	if len(commentIds) == 0 {
+		return counts, nil
+	}
+
+	tx := rr.db.
+		Model(&model.CommentReaction{}).
+		Select("comment_id, emoji, COUNT(*) AS count").
+		Where("comment_id IN ?", commentIds).
+		Group("comment_id, emoji").
+		Scan(&counts)
+	return counts, tx.Error
+}
+
+// Save relies on the unique (comment_id, user_id, emoji) index to reject duplicates.
+func (rr *ReactionRepository) Save(reaction model.CommentReaction) (model.CommentReaction, error) {
+	tx := rr.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&reaction)
+	if tx.Error != nil {
+		return model.CommentReaction{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.CommentReaction{}, model.ErrorAlreadyReacted
+	}
+	return reaction, nil
+}
+
+func (rr *ReactionRepository) Delete(commentId string, userId string, emoji string) error {
+	tx := rr.db.Delete(&model.CommentReaction{}, "comment_id = ? AND user_id = ? AND emoji = ?", commentId, userId, emoji)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.ErrorNotFound
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"errors"
+	"mygram/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IReportRepository
+type IReportRepository interface {
+	GetOne(id string) (model.Report, error)
+	Get(status string, pagination model.PaginationRequest) ([]model.Report, int64, error)
+	CountOpenByTarget(targetType string, targetId string) (int64, error)
+	Save(report model.Report) (model.Report, error)
+	Reopen(report model.Report) (model.Report, error)
+	Claim(id string, moderatorId string) error
+	CloseByTarget(targetType string, targetId string, status string, moderatorId string) error
+}
+type ReportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) *ReportRepository {
+	return &ReportRepository{
+		db: db,
+	}
+}
+
+func (rr *ReportRepository) GetOne(id string) (model.Report, error) {
+	report := model.Report{}
+
+	tx := rr.db.First(&report, "id = ?", id)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return model.Report{}, model.ErrorNotFound
+	}
+	return report, tx.Error
+}
+
+// Get lists reports oldest first so the queue is worked in arrival order. An
+// empty status matches every report still waiting for a decision.
+func (rr *ReportRepository) Get(status string, pagination model.PaginationRequest) ([]model.Report, int64, error) {
+	reports := make([]model.Report, 0)
+	var total int64
+
+	query := rr.db.Model(&model.Report{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	} else {
+		query = query.Where("status IN ?", []string{model.ReportStatusOpen, model.ReportStatusClaimed})
+	}
+
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return reports, 0, tx.Error
+	}
+
+	tx = query.
+		Order("created_at ASC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&reports)
+	return reports, total, tx.Error
+}
+
+func (rr *ReportRepository) CountOpenByTarget(targetType string, targetId string) (int64, error) {
+	var count int64
+
+	tx := rr.db.
+		Model(&model.Report{}).
+		Where("target_type = ? AND target_id = ? AND status IN ?", targetType, targetId, []string{model.ReportStatusOpen, model.ReportStatusClaimed}).
+		Count(&count)
+	return count, tx.Error
+}
+
+func (rr *ReportRepository) Save(report model.Report) (model.Report, error) {
+	tx := rr.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&report)
+	if tx.Error != nil {
+		return model.Report{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.Report{}, model.ErrorAlreadyReported
+	}
+	return report, nil
+}
+
+// Reopen puts the report of the same reporter on the same target back in the
+// queue with the excerpt, reason and note of report. A report still pending
+// keeps its status and moderator and only takes the new details.
+func (rr *ReportRepository) Reopen(report model.Report) (model.Report, error) {
+	pending := []string{model.ReportStatusOpen, model.ReportStatusClaimed}
+	reopened := model.Report{}
+
+	tx := rr.db.
+		Model(&reopened).
+		Clauses(clause.Returning{}).
+		Where("reporter_id = ? AND target_type = ? AND target_id = ?", report.ReporterID, report.TargetType, report.TargetID).
+		Updates(map[string]interface{}{
+			"excerpt":      report.Excerpt,
+			"reason":       report.Reason,
+			"note":         report.Note,
+			"status":       gorm.Expr("CASE WHEN status IN ? THEN status ELSE ? END", pending, model.ReportStatusOpen),
+			"moderator_id": gorm.Expr("CASE WHEN status IN ? THEN moderator_id END", pending),
+		})
+	if tx.Error != nil {
+		return model.Report{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.Report{}, model.ErrorNotFound
+	}
+	return reopened, nil
+}
+
+// Claim assigns an open report to a moderator. It fails when another
+// moderator claimed or closed the report first.
+func (rr *ReportRepository) Claim(id string, moderatorId string) error {
+	tx := rr.db.
+		Model(&model.Report{}).
+		Where("id = ? AND status = ?", id, model.ReportStatusOpen).
+		Updates(map[string]interface{}{
+			"status":       model.ReportStatusClaimed,
+			"moderator_id": moderatorId,
+		})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.ErrorReportClaimed
+	}
+	return nil
+}
+
+// CloseByTarget closes every pending report on the same target, since one
+// review settles all of them.
+func (rr *ReportRepository) CloseByTarget(targetType string, targetId string, status string, moderatorId string) error {
+	tx := rr.db.
+		Model(&model.Report{}).
+		Where("target_type = ? AND target_id = ? AND status IN ?", targetType, targetId, []string{model.ReportStatusOpen, model.ReportStatusClaimed}).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"moderator_id": moderatorId,
+		})
+	return tx.Error
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"html"
+	"mygram/model"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// InMemorySearchRepository implements ISearchRepository over plain slices.
+// Matching follows websearch_to_tsquery semantics (every term must be
+// present) and hidden content and suspended users are skipped, so it can
+// stand in for SearchRepository in tests.
+type InMemorySearchRepository struct {
+	Photos   []model.Photo
+	Users    []model.User
+	Comments []model.Comment
+}
+
+type searchField struct {
+	text   string
+	weight float64
+}
+
+func NewInMemorySearchRepository(photos []model.Photo, users []model.User, comments []model.Comment) *InMemorySearchRepository {
+	return &InMemorySearchRepository{
+		Photos:   photos,
+		Users:    users,
+		Comments: comments,
+	}
+}
+
+func (imsr *InMemorySearchRepository) SearchPhotos(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error) {
+	terms := searchTerms(query)
+	results := make([]model.SearchResult, 0)
+
+	for _, photo := range imsr.Photos {
+		if photo.Hidden || !photo.IsPublished() {
+			continue
+		}
+		rank := searchRank(terms, searchField{photo.Title, 1}, searchField{photo.Caption, 0.4}, searchField{photo.AltText, 0.2})
+		if rank == 0 {
+			continue
+		}
+		text := photo.Title + " " + photo.Caption
+		if photo.AltText != "" {
+			text += " " + photo.AltText
+		}
+		results = append(results, model.SearchResult{
+			ID:        photo.ID,
+			Type:      model.SearchTypePhoto,
+			UserID:    photo.UserID,
+			Rank:      rank,
+			Highlight: searchHighlight(terms, text),
+			CreatedAt: photo.CreatedAt,
+		})
+	}
+	return searchPage(results, pagination)
+}
+
+func (imsr *InMemorySearchRepository) SearchUsers(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error) {
+	terms := searchTerms(query)
+	results := make([]model.SearchResult, 0)
+
+	now := time.Now()
+	for _, user := range imsr.Users {
+		if user.IsSuspended(now) {
+			continue
+		}
+		rank := searchRank(terms, searchField{user.Username, 1})
+		if rank == 0 {
+			continue
+		}
+		results = append(results, model.SearchResult{
+			ID:        user.ID,
+			Type:      model.SearchTypeUser,
+			UserID:    user.ID,
+			Rank:      rank,
+			Highlight: searchHighlight(terms, user.Username),
+			CreatedAt: user.CreatedAt,
+		})
+	}
+	return searchPage(results, pagination)
+}
+
+func (imsr *InMemorySearchRepository) SearchComments(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error) {
+	terms := searchTerms(query)
+	results := make([]model.SearchResult, 0)
+
+	for _, comment := range imsr.Comments {
+		if comment.Hidden {
+			continue
+		}
+		rank := searchRank(terms, searchField{comment.Message, 1})
+		if rank == 0 {
+			continue
+		}
+		results = append(results, model.SearchResult{
+			ID:        comment.ID,
+			Type:      model.SearchTypeComment,
+			UserID:    comment.UserID,
+			PhotoID:   comment.PhotoID,
+			Rank:      rank,
+			Highlight: searchHighlight(terms, comment.Message),
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+	return searchPage(results, pagination)
+}
+
+func searchTokens(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func searchTerms(query string) []string {
+	terms := searchTokens(strings.ToLower(query))
+	sort.Strings(terms)
+	return terms
+}
+
+// searchRank returns zero unless every term occurs in at least one field.
+func searchRank(terms []string, fields ...searchField) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+
+	var rank float64
+	for _, term := range terms {
+		var termRank float64
+		for _, field := range fields {
+			for _, token := range searchTokens(strings.ToLower(field.text)) {
+				if token == term {
+					termRank += field.weight
+				}
+			}
+		}
+		if termRank == 0 {
+			return 0
+		}
+		rank += termRank
+	}
+	return rank
+}
+
+// searchHighlight HTML-escapes text and wraps every token matching one of
+// terms in <mark>, mirroring ts_headline over escaped text.
+func searchHighlight(terms []string, text string) string {
+	var sb strings.Builder
+	word := make([]rune, 0)
+
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		token := string(word)
+		i := sort.SearchStrings(terms, strings.ToLower(token))
+		if i < len(terms) && terms[i] == strings.ToLower(token) {
+			sb.WriteString("<mark>" + html.EscapeString(token) + "</mark>")
+		} else {
+			sb.WriteString(html.EscapeString(token))
+		}
+		word = word[:0]
+	}
+
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		sb.WriteString(html.EscapeString(string(r)))
+	}
+	flush()
+
+	return sb.String()
+}
+
+func searchPage(results []model.SearchResult, pagination model.PaginationRequest) ([]model.SearchResult, int64, error) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	total := int64(len(results))
+	start := pagination.Offset()
+	if start > len(results) {
+		start = len(results)
+	}
+	end := start + pagination.Limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[start:end], total, nil
+}
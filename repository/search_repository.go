@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"mygram/model"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+
+// searchHeadline returns a ts_headline expression over expr. The source
+// text is HTML-escaped first so that the only markup in a highlight is the
+// <mark> pair added around matches.
+func searchHeadline(expr string) string {
+	escaped := expr
+	for _, r := range [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&#34;"}, {"'", "&#39;"}} {
+		escaped = "replace(" + escaped + ", '" + strings.ReplaceAll(r[0], "'", "''") + "', '" + r[1] + "')"
+	}
+	return "ts_headline('simple', " + escaped + ", q, ?) AS highlight"
+}
+
+//go:generate mockery --name ISearchRepository
+type ISearchRepository interface {
+	SearchPhotos(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error)
+	SearchUsers(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error)
+	SearchComments(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error)
+}
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{
+		db: db,
+	}
+}
+
+func (sr *SearchRepository) SearchPhotos(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error) {
+	return sr.search(
+		"photos",
+		"id, user_id, '' AS photo_id, created_at, "+searchHeadline("title || ' ' || caption || ' ' || alt_text"),
+		model.SearchTypePhoto,
+		"NOT hidden AND status = '"+model.PhotoStatusPublished+"'",
+		query,
+		pagination,
+	)
+}
+
+func (sr *SearchRepository) SearchUsers(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error) {
+	return sr.search(
+		"users",
+		"id, id AS user_id, '' AS photo_id, created_at, "+searchHeadline("username"),
+		model.SearchTypeUser,
+		"(suspended_until IS NULL OR suspended_until <= now())",
+		query,
+		pagination,
+	)
+}
+
+func (sr *SearchRepository) SearchComments(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error) {
+	return sr.search(
+		"comments",
+		"id, user_id, photo_id, created_at, "+searchHeadline("message"),
+		model.SearchTypeComment,
+		"NOT hidden",
+		query,
+		pagination,
+	)
+}
+
+// search runs a ranked full-text query against the search_vector column of
+// table, limited to rows matching condition.
+func (sr *SearchRepository) search(table string, columns string, searchType string, condition string, query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error) {
+	results := make([]model.SearchResult, 0)
+	var total int64
+
+	tx := sr.db.
+		Table(table).
+		Where("search_vector @@ websearch_to_tsquery('simple', ?)", query).
+		Where(condition).
+		Count(&total)
+	if tx.Error != nil {
+		return results, 0, tx.Error
+	}
+
+	tx = sr.db.Raw(
+		"SELECT "+columns+", ts_rank(search_vector, q) AS rank"+
+			" FROM "+table+", websearch_to_tsquery('simple', ?) q"+
+			" WHERE search_vector @@ q AND "+condition+
+			" ORDER BY rank DESC, created_at DESC"+
+			" LIMIT ? OFFSET ?",
+		searchHeadlineOptions, query, pagination.Limit, pagination.Offset(),
+	).Scan(&results)
+	if tx.Error != nil {
+		return results, 0, tx.Error
+	}
+
+	for i := range results {
+		results[i].Type = searchType
+	}
+	return results, total, nil
+}
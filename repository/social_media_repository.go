@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 	"mygram/model"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -12,9 +13,16 @@ import (
 type ISocialMediaRepository interface {
 	Get() ([]model.SocialMedia, error)
 	GetOne(id string) (model.SocialMedia, error)
+	GetByUserID(userId string) ([]model.SocialMedia, error)
 	Save(socialMedia model.SocialMedia) (model.SocialMedia, error)
 	Update(updateSocialMedia model.SocialMedia, id string) (model.SocialMedia, error)
 	Delete(id string) error
+	UpdateVerificationToken(id string, token string) error
+	UpdateVerification(id string, verifiedAt *time.Time, checkedAt time.Time) error
+	GetDueForRecheck(checkedBefore time.Time, limit int) ([]model.SocialMedia, error)
+	UpdatePositions(userId string, ids []string) error
+	SaveClick(socialMediaId string, day time.Time) error
+	GetClicks(socialMediaIds []string, from time.Time) ([]model.SocialMediaClick, error)
 }
 type SocialMediaRepository struct {
 	db *gorm.DB
@@ -29,7 +37,7 @@ func NewSocialMediaRepository(db *gorm.DB) *SocialMediaRepository {
 func (smr *SocialMediaRepository) Get() ([]model.SocialMedia, error) {
 	socialMedia := make([]model.SocialMedia, 0)
 
-	tx := smr.db.Find(&socialMedia)
+	tx := smr.db.Order("user_id, position, created_at").Find(&socialMedia)
 	return socialMedia, tx.Error
 }
 
@@ -43,11 +51,28 @@ func (smr *SocialMediaRepository) GetOne(id string) (model.SocialMedia, error) {
 	return socialMedia, tx.Error
 }
 
-func (smr *SocialMediaRepository) Save(socialMedia model.SocialMedia) (model.SocialMedia, error) {
-	tx := smr.db.Create(&socialMedia)
+func (smr *SocialMediaRepository) GetByUserID(userId string) ([]model.SocialMedia, error) {
+	socialMedia := make([]model.SocialMedia, 0)
+
+	tx := smr.db.Where("user_id = ?", userId).Order("position, created_at").Find(&socialMedia)
 	return socialMedia, tx.Error
 }
 
+// Save relies on the unique (user_id, platform) index to reject a second link
+// for a catalog platform.
+func (smr *SocialMediaRepository) Save(socialMedia model.SocialMedia) (model.SocialMedia, error) {
+	tx := smr.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&socialMedia)
+	if tx.Error != nil {
+		return model.SocialMedia{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return model.SocialMedia{}, model.ErrorDuplicateSocialMedia
+	}
+	return socialMedia, nil
+}
+
 func (smr *SocialMediaRepository) Update(updateSocialMedia model.SocialMedia, id string) (model.SocialMedia, error) {
 	tx := smr.db.
 		Clauses(clause.Returning{
@@ -60,6 +85,7 @@ func (smr *SocialMediaRepository) Update(updateSocialMedia model.SocialMedia, id
 		},
 		).
 		Where("id = ?", id).
+		Select("Name", "SocialMediaURL", "Platform", "Handle", "Visibility", "VerificationToken", "VerifiedAt", "VerificationCheckedAt").
 		Updates(&updateSocialMedia)
 	return updateSocialMedia, tx.Error
 }
@@ -67,9 +93,98 @@ func (smr *SocialMediaRepository) Update(updateSocialMedia model.SocialMedia, id
 func (smr *SocialMediaRepository) Delete(id string) error {
 	socialMedia := model.SocialMedia{}
 
-	tx := smr.db.Delete(&socialMedia, "id = ?", id)
-	if tx.Error != nil {
-		return tx.Error
+	return smr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&model.SocialMediaClick{}, "social_media_id = ?", id).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&socialMedia, "id = ?", id).Error
+	})
+}
+
+// UpdateVerificationToken starts a new verification of the link, dropping an
+// earlier one.
+func (smr *SocialMediaRepository) UpdateVerificationToken(id string, token string) error {
+	tx := smr.db.
+		Model(&model.SocialMedia{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"verification_token":      token,
+			"verified_at":             nil,
+			"verification_checked_at": nil,
+		})
+	return tx.Error
+}
+
+// UpdateVerification records a check of the link. It leaves updated_at alone,
+// checks are not edits by the owner.
+func (smr *SocialMediaRepository) UpdateVerification(id string, verifiedAt *time.Time, checkedAt time.Time) error {
+	tx := smr.db.
+		Model(&model.SocialMedia{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"verified_at":             verifiedAt,
+			"verification_checked_at": checkedAt,
+		})
+	return tx.Error
+}
+
+// GetDueForRecheck returns verified links last checked before checkedBefore,
+// the longest unchecked first.
+func (smr *SocialMediaRepository) GetDueForRecheck(checkedBefore time.Time, limit int) ([]model.SocialMedia, error) {
+	socialMedia := make([]model.SocialMedia, 0)
+
+	tx := smr.db.
+		Where("verified_at IS NOT NULL AND verification_checked_at < ?", checkedBefore).
+		Order("verification_checked_at").
+		Limit(limit).
+		Find(&socialMedia)
+	return socialMedia, tx.Error
+}
+
+// UpdatePositions numbers the links of userId in the order of ids.
+func (smr *SocialMediaRepository) UpdatePositions(userId string, ids []string) error {
+	return smr.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range ids {
+			err := tx.Model(&model.SocialMedia{}).
+				Where("id = ? AND user_id = ?", id, userId).
+				UpdateColumn("position", position).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveClick counts one click on the link on day.
+func (smr *SocialMediaRepository) SaveClick(socialMediaId string, day time.Time) error {
+	click := model.SocialMediaClick{
+		SocialMediaID: socialMediaId,
+		Day:           day,
+		Clicks:        1,
 	}
-	return nil
+
+	tx := smr.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "social_media_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"clicks": gorm.Expr("social_media_clicks.clicks + 1")}),
+		}).
+		Create(&click)
+	return tx.Error
+}
+
+// GetClicks returns the daily clicks of the links from the day from on.
+func (smr *SocialMediaRepository) GetClicks(socialMediaIds []string, from time.Time) ([]model.SocialMediaClick, error) {
+	clicks := make([]model.SocialMediaClick, 0)
+	if len(socialMediaIds) == 0 {
+		return clicks, nil
+	}
+
+	tx := smr.db.
+		Where("social_media_id IN ? AND day >= ?", socialMediaIds, from).
+		Order("day").
+		Find(&clicks)
+	return clicks, tx.Error
 }
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"errors"
+	"mygram/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name IStoryRepository
+type IStoryRepository interface {
+	GetOne(id string) (model.Story, error)
+	GetFeed(viewerId string, now time.Time) ([]model.Story, error)
+	GetSeenIDs(viewerId string, storyIds []string) ([]string, error)
+	GetViewers(storyId string, ownerId string, pagination model.PaginationRequest) ([]model.StoryView, int64, error)
+	GetExpired(now time.Time, limit int) ([]model.Story, error)
+	Save(story model.Story) (model.Story, error)
+	SaveView(view model.StoryView) error
+	DeleteByIDs(ids []string) error
+}
+type StoryRepository struct {
+	db *gorm.DB
+}
+
+func NewStoryRepository(db *gorm.DB) *StoryRepository {
+	return &StoryRepository{
+		db: db,
+	}
+}
+
+func (sr *StoryRepository) GetOne(id string) (model.Story, error) {
+	story := model.Story{}
+
+	tx := sr.db.First(&story, "id = ?", id)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return model.Story{}, model.ErrorNotFound
+	}
+	return story, tx.Error
+}
+
+// GetFeed returns the unexpired stories of viewerId and of the users they
+// follow with an accepted follow, oldest first.
+func (sr *StoryRepository) GetFeed(viewerId string, now time.Time) ([]model.Story, error) {
+	stories := make([]model.Story, 0)
+
+	following := sr.db.
+		Model(&model.Follow{}).
+		Select("following_id").
+		Where("follower_id = ? AND status = ?", viewerId, model.FollowStatusAccepted)
+
+	tx := sr.db.
+		Where("user_id = ? OR user_id IN (?)", viewerId, following).
+		Where("expires_at > ?", now).
+		Order("created_at ASC").
+		Find(&stories)
+	return stories, tx.Error
+}
+
+// GetSeenIDs returns the subset of storyIds viewerId has seen.
+func (sr *StoryRepository) GetSeenIDs(viewerId string, storyIds []string) ([]string, error) {
+	seen := make([]string, 0)
+	if len(storyIds) == 0 {
+		return seen, nil
+	}
+
+	tx := sr.db.
+		Model(&model.StoryView{}).
+		Where("viewer_id = ? AND story_id IN ?", viewerId, storyIds).
+		Pluck("story_id", &seen)
+	return seen, tx.Error
+}
+
+// GetViewers lists who saw the story, most recent first. Viewers with a block
+// with ownerId either way are left out of both the page and the total.
+func (sr *StoryRepository) GetViewers(storyId string, ownerId string, pagination model.PaginationRequest) ([]model.StoryView, int64, error) {
+	views := make([]model.StoryView, 0)
+	var total int64
+
+	query := sr.db.
+		Model(&model.StoryView{}).
+		Where("story_id = ?", storyId).
+		Where("NOT "+blockedWith("story_views.viewer_id"), visibilityArgs(ownerId)...)
+
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return views, 0, tx.Error
+	}
+
+	tx = query.
+		Preload("Viewer").
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset()).
+		Find(&views)
+	return views, total, tx.Error
+}
+
+// GetExpired returns up to limit stories that expired at now, oldest first.
+func (sr *StoryRepository) GetExpired(now time.Time, limit int) ([]model.Story, error) {
+	stories := make([]model.Story, 0)
+
+	tx := sr.db.
+		Where("expires_at <= ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&stories)
+	return stories, tx.Error
+}
+
+func (sr *StoryRepository) Save(story model.Story) (model.Story, error) {
+	tx := sr.db.Create(&story)
+	return story, tx.Error
+}
+
+// SaveView records a view, keeping the time of the first one.
+func (sr *StoryRepository) SaveView(view model.StoryView) error {
+	tx := sr.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&view)
+	return tx.Error
+}
+
+// DeleteByIDs removes the stories together with their views.
+func (sr *StoryRepository) DeleteByIDs(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&model.StoryView{}, "story_id IN ?", ids).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&model.Story{}, "id IN ?", ids).Error
+	})
+}
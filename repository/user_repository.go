@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"errors"
 	"mygram/model"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -9,8 +11,13 @@ import (
 //go:generate mockery --name IUserRepository
 type IUserRepository interface {
 	Save(newUser model.User) (model.User, error)
+	GetOne(id string) (model.User, error)
+	GetByIDs(ids []string) ([]model.User, error)
 	GetByUsername(username string) (model.User, error)
 	GetDetailUser(id string) (model.User, error)
+	UpdatePrivacy(id string, isPrivate bool) error
+	UpdateSuspension(id string, suspendedUntil *time.Time) error
+	UpdateLocale(id string, locale string) error
 }
 type UserRepository struct {
 	db *gorm.DB
@@ -33,6 +40,9 @@ func (ur *UserRepository) Save(newUser model.User) (model.User, error) {
 func (ur *UserRepository) GetByUsername(username string) (model.User, error) {
 	user := model.User{}
 	tx := ur.db.First(&user, "username = ?", username)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return model.User{}, model.ErrorNotFound
+	}
 
 	return user, tx.Error
 }
@@ -42,7 +52,53 @@ func (ur *UserRepository) GetDetailUser(id string) (model.User, error) {
 		ID: id,
 	}
 
-	tx := ur.db.Preload("Photos").Preload("Comments").Preload("SocialMedias").Find(&user)
+	tx := ur.db.Preload("Photos").Preload("Comments").Preload("SocialMedias", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position, created_at")
+	}).Find(&user)
 
 	return user, tx.Error
 }
+
+func (ur *UserRepository) GetOne(id string) (model.User, error) {
+	user := model.User{}
+
+	tx := ur.db.First(&user, "id = ?", id)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return model.User{}, model.ErrorNotFound
+	}
+	return user, tx.Error
+}
+
+func (ur *UserRepository) GetByIDs(ids []string) ([]model.User, error) {
+	users := make([]model.User, 0)
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	tx := ur.db.Where("id IN ?", ids).Find(&users)
+	return users, tx.Error
+}
+
+func (ur *UserRepository) UpdatePrivacy(id string, isPrivate bool) error {
+	tx := ur.db.
+		Model(&model.User{}).
+		Where("id = ?", id).
+		Update("is_private", isPrivate)
+	return tx.Error
+}
+
+func (ur *UserRepository) UpdateLocale(id string, locale string) error {
+	tx := ur.db.
+		Model(&model.User{}).
+		Where("id = ?", id).
+		Update("locale", locale)
+	return tx.Error
+}
+
+func (ur *UserRepository) UpdateSuspension(id string, suspendedUntil *time.Time) error {
+	tx := ur.db.
+		Model(&model.User{}).
+		Where("id = ?", id).
+		Update("suspended_until", suspendedUntil)
+	return tx.Error
+}
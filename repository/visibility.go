@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"database/sql"
+	"mygram/model"
+)
+
+// The conditions below mirror service.VisibilityPolicy in SQL, so lists can
+// leave out what the viewer may not see before they are paginated and
+// counted. They take the column holding a user or photo id and expect the
+// viewer bound through visibilityArgs.
+
+// blockedWith matches users with a block with the viewer in either direction.
+func blockedWith(column string) string {
+	return "EXISTS (SELECT 1 FROM blocks WHERE (blocks.blocker_id = @viewer AND blocks.blocked_id = " + column + ")" +
+		" OR (blocks.blocker_id = " + column + " AND blocks.blocked_id = @viewer))"
+}
+
+// visibleUser matches the viewer and the users whose content they may see:
+// known, not blocked either way, not suspended, and public or followed.
+func visibleUser(column string) string {
+	return "(" + column + " = @viewer OR (NOT " + blockedWith(column) +
+		" AND EXISTS (SELECT 1 FROM users WHERE users.id = " + column +
+		" AND (users.suspended_until IS NULL OR users.suspended_until <= now())" +
+		" AND (NOT users.is_private OR EXISTS (SELECT 1 FROM follows WHERE follows.follower_id = @viewer" +
+		" AND follows.following_id = users.id AND follows.status = @accepted)))))"
+}
+
+// visiblePhoto matches the photos the viewer may see: their own, or published
+// photos not hidden by moderation of users they may see.
+func visiblePhoto(column string) string {
+	return "EXISTS (SELECT 1 FROM photos WHERE photos.id = " + column +
+		" AND (photos.user_id = @viewer OR (NOT photos.hidden AND photos.status NOT IN @unpublished AND " + visibleUser("photos.user_id") + ")))"
+}
+
+// visibilityArgs binds the viewer and the constants the conditions use.
+func visibilityArgs(viewerId string) []interface{} {
+	return []interface{}{
+		sql.Named("viewer", viewerId),
+		sql.Named("accepted", model.FollowStatusAccepted),
+		sql.Named("unpublished", []string{model.PhotoStatusDraft, model.PhotoStatusScheduled}),
+	}
+}
@@ -1,10 +1,14 @@
 package routes
 
 import (
+	"context"
 	"mygram/controller"
+	"mygram/helper"
 	"mygram/middleware"
+	"mygram/model"
 	"mygram/repository"
 	"mygram/service"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -12,55 +16,225 @@ import (
 
 func Routes(g *gin.Engine, db *gorm.DB) {
 	userRepository := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepository)
+	followRepository := repository.NewFollowRepository(db)
+	photoRepository := repository.NewPhotoRepository(db)
+	blockRepository := repository.NewBlockRepository(db)
+	muteRepository := repository.NewMuteRepository(db)
+	visibilityPolicy := service.NewVisibilityPolicy(userRepository, followRepository, photoRepository, blockRepository, muteRepository)
+
+	userService := service.NewUserService(userRepository, followRepository, visibilityPolicy)
 	userController := controller.NewUserController(*userService)
 
+	followService := service.NewFollowService(followRepository, userRepository, visibilityPolicy)
+	followController := controller.NewFollowController(*followService)
+
+	blockService := service.NewBlockService(blockRepository, muteRepository, userRepository)
+	blockController := controller.NewBlockController(*blockService)
+
 	socialMediaRepository := repository.NewSocialMediaRepository(db)
-	socialMediaService := service.NewSocialMediaService(socialMediaRepository)
+	socialMediaFetcher := service.NewHTTPFetcher(time.Duration(helper.GetEnvInt("SOCIAL_MEDIA_FETCH_TIMEOUT_SECONDS", 10))*time.Second, 1<<20)
+	socialMediaVerifier := service.NewSocialMediaVerifier(socialMediaRepository, socialMediaFetcher, time.Duration(helper.GetEnvInt("SOCIAL_MEDIA_RECHECK_INTERVAL_SECONDS", 3600))*time.Second, time.Duration(helper.GetEnvInt("SOCIAL_MEDIA_RECHECK_AFTER_HOURS", 24))*time.Hour)
+	go socialMediaVerifier.Run(context.Background())
+	socialMediaService := service.NewSocialMediaService(socialMediaRepository, userRepository, visibilityPolicy, socialMediaVerifier)
 	socialMediaController := controller.NewSocialMediaController(*socialMediaService)
 
-	photoRepository := repository.NewPhotoRepository(db)
-	photoService := service.NewPhotoService(photoRepository)
+	bookmarkRepository := repository.NewBookmarkRepository(db)
+	commentRepository := repository.NewCommentRepository(db)
+	reactionRepository := repository.NewReactionRepository(db)
+	reportRepository := repository.NewReportRepository(db)
+
+	blockedWords := helper.GetEnvList("FILTER_BLOCKED_WORDS")
+	linkLimit := helper.GetEnvInt("FILTER_MAX_LINKS", 2)
+	commentFilter := service.NewFilterPipeline(reportRepository,
+		service.NewWordListFilter(blockedWords, model.FilterVerdictReject),
+		service.NewLinkLimitFilter(linkLimit),
+		service.NewDuplicateCommentFilter(commentRepository, time.Duration(helper.GetEnvInt("FILTER_DUPLICATE_WINDOW_MINUTES", 10))*time.Minute),
+		service.NewCommentRateFilter(commentRepository, helper.GetEnvInt("FILTER_RATE_LIMIT", 5), time.Duration(helper.GetEnvInt("FILTER_RATE_WINDOW_SECONDS", 60))*time.Second),
+	)
+	captionFilter := service.NewFilterPipeline(reportRepository,
+		service.NewWordListFilter(blockedWords, model.FilterVerdictReject),
+		service.NewLinkLimitFilter(linkLimit),
+	)
+
+	uploadDir := helper.GetEnv("UPLOAD_DIR", "uploads")
+	imageRepository := repository.NewImageRepository(uploadDir, helper.GetEnv("PUBLIC_BASE_URL", "")+"/uploads")
+	maxUploadSize := int64(helper.GetEnvInt("MAX_UPLOAD_SIZE_MB", 10)) << 20
+	duplicateDistance := helper.GetEnvInt("DUPLICATE_PHOTO_DISTANCE", 6)
+	duplicateAction := helper.GetEnv("DUPLICATE_PHOTO_ACTION", model.DuplicatePhotoWarn)
+	photoService := service.NewPhotoService(photoRepository, bookmarkRepository, reactionRepository, imageRepository, visibilityPolicy, captionFilter, service.NewStubCaptioner(), maxUploadSize, duplicateDistance, duplicateAction)
 	photoController := controller.NewPhotoController(*photoService)
 
-	commentRepository := repository.NewCommentRepository(db)
-	commentService := service.NewCommentService(commentRepository, photoRepository)
+	publishScheduler := service.NewPublishScheduler(photoRepository, time.Duration(helper.GetEnvInt("PUBLISH_SCHEDULER_INTERVAL_SECONDS", 30))*time.Second)
+	go publishScheduler.Run(context.Background())
+
+	storyRepository := repository.NewStoryRepository(db)
+	storyService := service.NewStoryService(storyRepository, userRepository, imageRepository, visibilityPolicy, maxUploadSize)
+	storyController := controller.NewStoryController(*storyService)
+
+	storyReaper := service.NewStoryReaper(storyRepository, imageRepository, time.Duration(helper.GetEnvInt("STORY_REAPER_INTERVAL_SECONDS", 300))*time.Second)
+	go storyReaper.Run(context.Background())
+
+	conversationRepository := repository.NewConversationRepository(db)
+	conversationService := service.NewConversationService(conversationRepository, userRepository, photoRepository, visibilityPolicy)
+	conversationController := controller.NewConversationController(*conversationService)
+
+	bookmarkService := service.NewBookmarkService(bookmarkRepository, photoRepository, visibilityPolicy)
+	bookmarkController := controller.NewBookmarkController(*bookmarkService)
+
+	moderationActionRepository := repository.NewModerationActionRepository(db)
+	moderationService := service.NewModerationService(reportRepository, moderationActionRepository, photoRepository, commentRepository, userRepository, visibilityPolicy, helper.GetEnvInt("REPORT_AUTO_HIDE_THRESHOLD", 5))
+	moderationController := controller.NewModerationController(*moderationService)
+
+	commentService := service.NewCommentService(commentRepository, photoRepository, userRepository, reactionRepository, visibilityPolicy, commentFilter, time.Duration(helper.GetEnvInt("COMMENT_EDIT_WINDOW_MINUTES", 0))*time.Minute, helper.GetEnvInt("PINNED_COMMENTS_LIMIT", 3))
 	commentController := controller.NewCommentController(*commentService)
 
+	reactionEmojis := helper.GetEnvList("REACTION_EMOJIS")
+	if len(reactionEmojis) == 0 {
+		reactionEmojis = model.DefaultReactionEmojis
+	}
+	reactionService := service.NewReactionService(reactionRepository, commentRepository, visibilityPolicy, reactionEmojis)
+	reactionController := controller.NewReactionController(*reactionService)
+
+	albumRepository := repository.NewAlbumRepository(db)
+	albumService := service.NewAlbumService(albumRepository, photoRepository, userRepository, visibilityPolicy)
+	albumController := controller.NewAlbumController(*albumService)
+
+	mediaService := service.NewMediaService(photoRepository, storyRepository, imageRepository, visibilityPolicy)
+	mediaController := controller.NewMediaController(*mediaService)
+
+	searchRepository := repository.NewSearchRepository(db)
+	searchService := service.NewSearchService(searchRepository, visibilityPolicy)
+	searchController := controller.NewSearchController(*searchService)
+
+	g.Use(middleware.ErrorMiddleware(helper.GetEnv("ERROR_RESPONSE_FORMAT", "") == "problem", userRepository))
+
 	g.GET("", controller.BaseContoller)
+	g.GET("/uploads/:name", middleware.AuthMiddleware, mediaController.GetMedia)
+	g.GET("/l/:social_media_id", middleware.OptionalAuthMiddleware, socialMediaController.RedirectSocialMedia)
+	notSuspended := middleware.SuspensionMiddleware(userRepository)
 	base := g.Group("/api/v1")
 	{
 		base.GET("/mygram", middleware.AuthMiddleware, userController.MyGram)
+		base.GET("/search", middleware.AuthMiddleware, searchController.Search)
 		auth := base.Group("/auth")
 		{
 			auth.POST("/register", userController.Register)
 			auth.POST("/login", userController.Login)
 		}
-		socialMediaRoute := base.Group("/social_media", middleware.AuthMiddleware)
+		socialMediaRoute := base.Group("/social_media", middleware.AuthMiddleware, notSuspended)
 		{
-			socialMediaRoute.GET("", socialMediaController.GetListSocialMedias)
+			socialMediaRoute.GET("", middleware.RoleMiddleware(userRepository, model.UserRoleAdmin), socialMediaController.GetListSocialMedias)
+			socialMediaRoute.GET("/platforms", socialMediaController.GetSocialMediaPlatforms)
 			socialMediaRoute.GET("/:id", socialMediaController.GetOneSocialMediaByID)
 			socialMediaRoute.POST("", socialMediaController.CreateSocialMedia)
 			socialMediaRoute.PUT("/:id", socialMediaController.UpdateSocialMedia)
 			socialMediaRoute.DELETE("/:id", socialMediaController.DeleteSocialMedia)
+			socialMediaRoute.POST("/:id/verification", socialMediaController.StartSocialMediaVerification)
+			socialMediaRoute.POST("/:id/verify", socialMediaController.VerifySocialMedia)
 
 		}
-		photoRoute := base.Group("/photo", middleware.AuthMiddleware)
+		photoRoute := base.Group("/photo", middleware.AuthMiddleware, notSuspended)
 		{
 			photoRoute.GET("", photoController.GetListPhotos)
+			photoRoute.GET("/nearby", photoController.GetNearbyPhotos)
 			photoRoute.GET("/:id", photoController.GetPhotoByID)
 			photoRoute.POST("", photoController.CreatePhoto)
+			photoRoute.POST("/upload", photoController.UploadPhoto)
 			photoRoute.PUT("/:id", photoController.UpdatePhoto)
 			photoRoute.DELETE("/:id", photoController.DeletePhoto)
+			photoRoute.POST("/:id/bookmark", bookmarkController.BookmarkPhoto)
+			photoRoute.DELETE("/:id/bookmark", bookmarkController.UnbookmarkPhoto)
+			photoRoute.POST("/:id/report", moderationController.ReportPhoto)
+			photoRoute.PUT("/:id/comment-settings", photoController.UpdateCommentSettings)
+			photoRoute.GET("/:id/comments", commentController.GetPhotoComments)
+		}
+
+		storyRoute := base.Group("/stories", middleware.AuthMiddleware, notSuspended)
+		{
+			storyRoute.POST("", storyController.UploadStory)
+			storyRoute.GET("/feed", storyController.GetStoryFeed)
+			storyRoute.GET("/:id", storyController.GetStoryByID)
+			storyRoute.GET("/:id/viewers", storyController.GetStoryViewers)
+			storyRoute.DELETE("/:id", storyController.DeleteStory)
+		}
+
+		conversationRoute := base.Group("/conversations", middleware.AuthMiddleware, notSuspended)
+		{
+			conversationRoute.GET("", conversationController.GetConversations)
+			conversationRoute.POST("", conversationController.CreateConversation)
+			conversationRoute.GET("/:id/messages", conversationController.GetMessages)
+			conversationRoute.POST("/:id/messages", conversationController.SendMessage)
+			conversationRoute.POST("/:id/read", conversationController.ReadConversation)
 		}
 
-		commentRoute := base.Group("/comment", middleware.AuthMiddleware)
+		commentRoute := base.Group("/comment", middleware.AuthMiddleware, notSuspended)
 		{
-			commentRoute.GET("", commentController.GetListComments)
-			commentRoute.POST("/:photo_id", commentController.CreateCommentByPhotoID)
+			commentRoute.GET("", middleware.RoleMiddleware(userRepository, model.UserRoleAdmin), commentController.GetListComments)
+			commentRoute.POST("/:id", commentController.CreateCommentByPhotoID)
 			commentRoute.GET("/:id", commentController.GetOneCommentsByID)
 			commentRoute.PUT("/:id", commentController.UpdateComment)
 			commentRoute.DELETE("/:id", commentController.DeleteComment)
+			commentRoute.POST("/:id/report", moderationController.ReportComment)
+			commentRoute.GET("/:id/revisions", commentController.GetCommentRevisions)
+			commentRoute.POST("/:id/reactions/:emoji", reactionController.ReactToComment)
+			commentRoute.DELETE("/:id/reactions/:emoji", reactionController.RemoveCommentReaction)
+			commentRoute.POST("/:id/pin", commentController.PinComment)
+			commentRoute.DELETE("/:id/pin", commentController.UnpinComment)
+		}
+
+		albumRoute := base.Group("/album", middleware.AuthMiddleware, notSuspended)
+		{
+			albumRoute.GET("/:id", albumController.GetAlbumByID)
+			albumRoute.POST("", albumController.CreateAlbum)
+			albumRoute.PUT("/:id", albumController.UpdateAlbum)
+			albumRoute.DELETE("/:id", albumController.DeleteAlbum)
+			albumRoute.POST("/:id/photos", albumController.AddPhotoToAlbum)
+			albumRoute.DELETE("/:id/photos/:photo_id", albumController.RemovePhotoFromAlbum)
+			albumRoute.PUT("/:id/photos/order", albumController.ReorderAlbumPhotos)
+			albumRoute.PUT("/:id/cover", albumController.SetAlbumCover)
+		}
+
+		meRoute := base.Group("/me", middleware.AuthMiddleware, notSuspended)
+		{
+			meRoute.GET("/bookmarks", bookmarkController.GetMyBookmarks)
+			meRoute.PUT("/privacy", userController.UpdatePrivacy)
+			meRoute.PUT("/locale", userController.UpdateLocale)
+			meRoute.GET("/follow-requests", followController.GetFollowRequests)
+			meRoute.POST("/follow-requests/:username/approve", followController.ApproveFollowRequest)
+			meRoute.POST("/follow-requests/:username/reject", followController.RejectFollowRequest)
+			meRoute.GET("/blocks", blockController.GetBlockedUsers)
+			meRoute.GET("/mutes", blockController.GetMutedUsers)
+			meRoute.GET("/warnings", moderationController.GetMyWarnings)
+			meRoute.GET("/photos/missing-alt-text", photoController.GetMissingAltText)
+			meRoute.GET("/drafts", photoController.GetDrafts)
+			meRoute.GET("/comments", commentController.GetMyComments)
+			meRoute.GET("/social_media", socialMediaController.GetMySocialMedia)
+			meRoute.PUT("/social_media/order", socialMediaController.ReorderSocialMedia)
+			meRoute.GET("/social_media/stats", socialMediaController.GetSocialMediaStats)
+		}
+
+		usersRoute := base.Group("/users", middleware.AuthMiddleware, notSuspended)
+		{
+			usersRoute.GET("/:username", userController.GetProfile)
+			usersRoute.GET("/:username/albums", albumController.GetAlbumsByUsername)
+			usersRoute.GET("/:username/social_media", socialMediaController.GetUserSocialMedia)
+			usersRoute.POST("/:username/follow", followController.FollowUser)
+			usersRoute.DELETE("/:username/follow", followController.UnfollowUser)
+			usersRoute.POST("/:username/block", blockController.BlockUser)
+			usersRoute.DELETE("/:username/block", blockController.UnblockUser)
+			usersRoute.POST("/:username/mute", blockController.MuteUser)
+			usersRoute.DELETE("/:username/mute", blockController.UnmuteUser)
+			usersRoute.POST("/:username/report", moderationController.ReportUser)
+		}
+
+		moderationRoute := base.Group("/moderation", middleware.AuthMiddleware, notSuspended, middleware.RoleMiddleware(userRepository, model.UserRoleModerator, model.UserRoleAdmin))
+		{
+			moderationRoute.GET("/reports", moderationController.GetReportQueue)
+			moderationRoute.POST("/reports/:id/claim", moderationController.ClaimReport)
+			moderationRoute.POST("/reports/:id/resolve", moderationController.ResolveReport)
+			moderationRoute.POST("/reports/:id/dismiss", moderationController.DismissReport)
+			moderationRoute.GET("/actions", moderationController.GetModerationActions)
+			moderationRoute.GET("/photos/:id/reposts", moderationController.GetPhotoReposts)
 		}
 	}
 
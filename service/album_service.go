@@ -0,0 +1,273 @@
+package service
+
+import (
+	"mygram/helper"
+	"mygram/model"
+	"mygram/repository"
+)
+
+type AlbumService struct {
+	AlbumRepository  repository.IAlbumRepository
+	PhotoRepository  repository.IPhotoRepository
+	UserRepository   repository.IUserRepository
+	VisibilityPolicy *VisibilityPolicy
+}
+
+func NewAlbumService(albumRepository repository.IAlbumRepository, photoRepository repository.IPhotoRepository, userRepository repository.IUserRepository, visibilityPolicy *VisibilityPolicy) *AlbumService {
+	return &AlbumService{
+		AlbumRepository:  albumRepository,
+		PhotoRepository:  photoRepository,
+		UserRepository:   userRepository,
+		VisibilityPolicy: visibilityPolicy,
+	}
+}
+
+// GetByUsername lists a user's albums. Private albums are only listed for their owner.
+func (as *AlbumService) GetByUsername(username string, viewerId string) ([]model.AlbumResponse, error) {
+	albumsResponse := make([]model.AlbumResponse, 0)
+
+	user, err := as.UserRepository.GetByUsername(username)
+	if err != nil {
+		return []model.AlbumResponse{}, err
+	}
+
+	canView, err := as.VisibilityPolicy.CanView(viewerId, user.ID)
+	if err != nil {
+		return []model.AlbumResponse{}, err
+	}
+	if !canView {
+		canInteract, err := as.VisibilityPolicy.CanInteract(viewerId, user.ID)
+		if err != nil {
+			return []model.AlbumResponse{}, err
+		}
+		if !canInteract {
+			return []model.AlbumResponse{}, model.ErrorNotFound
+		}
+		return []model.AlbumResponse{}, model.ErrorPrivateAccount
+	}
+
+	res, err := as.AlbumRepository.GetByUserID(user.ID)
+	if err != nil {
+		return []model.AlbumResponse{}, err
+	}
+
+	for _, album := range res {
+		if album.Visibility == model.AlbumVisibilityPrivate && album.UserID != viewerId {
+			continue
+		}
+		albumsResponse = append(albumsResponse, model.ToAlbumResponse(withoutHiddenPhotos(album, viewerId)))
+	}
+
+	return albumsResponse, nil
+}
+
+func (as *AlbumService) GetById(id string, viewerId string) (model.AlbumResponse, error) {
+	album, err := as.AlbumRepository.GetOne(id)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	if album.Visibility == model.AlbumVisibilityPrivate && album.UserID != viewerId {
+		return model.AlbumResponse{}, model.ErrorNotFound
+	}
+
+	canView, err := as.VisibilityPolicy.CanView(viewerId, album.UserID)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+	if !canView {
+		return model.AlbumResponse{}, model.ErrorNotFound
+	}
+
+	return model.ToAlbumResponse(withoutHiddenPhotos(album, viewerId)), nil
+}
+
+func (as *AlbumService) Add(request model.AlbumCreateRequest, userId string) (model.AlbumResponse, error) {
+	id := helper.GenerateID()
+
+	visibility := request.Visibility
+	if visibility == "" {
+		visibility = model.AlbumVisibilityPublic
+	}
+
+	album := model.Album{
+		ID:          id,
+		UserID:      userId,
+		Title:       request.Title,
+		Description: request.Description,
+		Visibility:  visibility,
+	}
+
+	res, err := as.AlbumRepository.Save(album)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	return model.ToAlbumResponse(res), nil
+}
+
+// UpdateById keeps the visibility of the album when the request leaves it
+// out, so renaming a private album does not make it public.
+func (as *AlbumService) UpdateById(request model.AlbumUpdateRequest, id string, userId string) (model.AlbumResponse, error) {
+	current, err := as.getOwnedAlbum(id, userId)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	visibility := request.Visibility
+	if visibility == "" {
+		visibility = current.Visibility
+	}
+
+	album := model.Album{
+		Title:       request.Title,
+		Description: request.Description,
+		Visibility:  visibility,
+	}
+
+	_, err = as.AlbumRepository.Update(album, id)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	return as.GetById(id, userId)
+}
+
+func (as *AlbumService) DeleteById(id string, userId string) error {
+	_, err := as.getOwnedAlbum(id, userId)
+	if err != nil {
+		return err
+	}
+
+	return as.AlbumRepository.Delete(id)
+}
+
+func (as *AlbumService) AddPhoto(request model.AlbumPhotoRequest, id string, userId string) (model.AlbumResponse, error) {
+	album, err := as.getOwnedAlbum(id, userId)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	if albumHasPhoto(album, request.PhotoID) {
+		return model.AlbumResponse{}, model.ErrorPhotoAlreadyInAlbum
+	}
+
+	photo, err := as.PhotoRepository.GetOne(request.PhotoID)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	if photo.UserID != userId {
+		return model.AlbumResponse{}, model.ErrorForbiddenAccess
+	}
+
+	err = as.AlbumRepository.AddPhoto(id, request.PhotoID)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	return as.GetById(id, userId)
+}
+
+func (as *AlbumService) RemovePhoto(id string, photoId string, userId string) (model.AlbumResponse, error) {
+	album, err := as.getOwnedAlbum(id, userId)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	if !albumHasPhoto(album, photoId) {
+		return model.AlbumResponse{}, model.ErrorPhotoNotInAlbum
+	}
+
+	err = as.AlbumRepository.RemovePhoto(id, photoId)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	return as.GetById(id, userId)
+}
+
+// ReorderPhotos expects request.PhotoIDs to be a permutation of the album's photos.
+func (as *AlbumService) ReorderPhotos(request model.AlbumReorderRequest, id string, userId string) (model.AlbumResponse, error) {
+	album, err := as.getOwnedAlbum(id, userId)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	if len(request.PhotoIDs) != len(album.AlbumPhotos) {
+		return model.AlbumResponse{}, model.ErrorInvalidAlbumOrder
+	}
+
+	seen := make(map[string]bool)
+	for _, photoId := range request.PhotoIDs {
+		if seen[photoId] || !albumHasPhoto(album, photoId) {
+			return model.AlbumResponse{}, model.ErrorInvalidAlbumOrder
+		}
+		seen[photoId] = true
+	}
+
+	err = as.AlbumRepository.ReorderPhotos(id, request.PhotoIDs)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	return as.GetById(id, userId)
+}
+
+func (as *AlbumService) SetCover(request model.AlbumPhotoRequest, id string, userId string) (model.AlbumResponse, error) {
+	album, err := as.getOwnedAlbum(id, userId)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	if !albumHasPhoto(album, request.PhotoID) {
+		return model.AlbumResponse{}, model.ErrorPhotoNotInAlbum
+	}
+
+	err = as.AlbumRepository.UpdateCover(id, &request.PhotoID)
+	if err != nil {
+		return model.AlbumResponse{}, err
+	}
+
+	return as.GetById(id, userId)
+}
+
+func (as *AlbumService) getOwnedAlbum(id string, userId string) (model.Album, error) {
+	album, err := as.AlbumRepository.GetOne(id)
+	if err != nil {
+		return model.Album{}, err
+	}
+
+	if album.UserID != userId {
+		return model.Album{}, model.ErrorForbiddenAccess
+	}
+
+	return album, nil
+}
+
+func albumHasPhoto(album model.Album, photoId string) bool {
+	for _, albumPhoto := range album.AlbumPhotos {
+		if albumPhoto.PhotoID == photoId {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutHiddenPhotos drops photos hidden by moderation or not published yet
+// from an album shown to someone other than their owner, including a hidden
+// cover.
+func withoutHiddenPhotos(album model.Album, viewerId string) model.Album {
+	albumPhotos := make([]model.AlbumPhoto, 0, len(album.AlbumPhotos))
+	for _, albumPhoto := range album.AlbumPhotos {
+		if isPhotoHiddenFrom(viewerId, albumPhoto.Photo) {
+			if album.CoverPhotoID != nil && *album.CoverPhotoID == albumPhoto.PhotoID {
+				album.CoverPhotoID = nil
+			}
+			continue
+		}
+		albumPhotos = append(albumPhotos, albumPhoto)
+	}
+	album.AlbumPhotos = albumPhotos
+	return album
+}
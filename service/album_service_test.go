@@ -0,0 +1,321 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository/mocks"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAlbumService_GetByUsername(t *testing.T) {
+	albumRepository := mocks.NewIAlbumRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	followRepository := mocks.NewIFollowRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(userRepository, followRepository, nil, blockRepository, nil)
+
+	albums := []model.Album{
+		{ID: "1", UserID: "1", Title: "Bali", Visibility: model.AlbumVisibilityPublic},
+		{ID: "2", UserID: "1", Title: "Family", Visibility: model.AlbumVisibilityPrivate},
+	}
+
+	type args struct {
+		username string
+		viewerId string
+	}
+	tests := []struct {
+		name     string
+		as       *AlbumService
+		args     args
+		want     []model.AlbumResponse
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success (Owner sees private albums)",
+			as: &AlbumService{
+				AlbumRepository:  albumRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username: "adiwahyudi",
+				viewerId: "1",
+			},
+			want: []model.AlbumResponse{
+				model.ToAlbumResponse(albums[0]),
+				model.ToAlbumResponse(albums[1]),
+			},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "adiwahyudi").Return(model.User{ID: "1"}, nil).Once()
+				albumRepository.On("GetByUserID", "1").Return(albums, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Success (Private albums hidden from others)",
+			as: &AlbumService{
+				AlbumRepository:  albumRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username: "adiwahyudi",
+				viewerId: "2",
+			},
+			want: []model.AlbumResponse{
+				model.ToAlbumResponse(albums[0]),
+			},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "adiwahyudi").Return(model.User{ID: "1"}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "2", []string{"1"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"1"}).Return([]model.User{{ID: "1"}}, nil).Once()
+				albumRepository.On("GetByUserID", "1").Return(albums, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #3 - Failed (User not found)",
+			as: &AlbumService{
+				AlbumRepository:  albumRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username: "nobody",
+				viewerId: "2",
+			},
+			want: []model.AlbumResponse{},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "nobody").Return(model.User{}, model.ErrorNotFound).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #4 - Failed (Private account not followed)",
+			as: &AlbumService{
+				AlbumRepository:  albumRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username: "private",
+				viewerId: "2",
+			},
+			want: []model.AlbumResponse{},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "private").Return(model.User{ID: "3", IsPrivate: true}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "2", []string{"3"}).Return([]string{}, nil).Twice()
+				userRepository.On("GetByIDs", []string{"3"}).Return([]model.User{{ID: "3", IsPrivate: true}}, nil).Once()
+				followRepository.On("GetAcceptedFollowingIDs", "2", []string{"3"}).Return([]string{}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #5 - Failed (Blocked)",
+			as: &AlbumService{
+				AlbumRepository:  albumRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username: "blocker",
+				viewerId: "2",
+			},
+			want: []model.AlbumResponse{},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "blocker").Return(model.User{ID: "4"}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "2", []string{"4"}).Return([]string{"4"}, nil).Twice()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.as.GetByUsername(tt.args.username, tt.args.viewerId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AlbumService.GetByUsername() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AlbumService.GetByUsername() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlbumService_ReorderPhotos(t *testing.T) {
+	albumRepository := mocks.NewIAlbumRepository(t)
+
+	album := model.Album{
+		ID:     "1",
+		UserID: "1",
+		AlbumPhotos: []model.AlbumPhoto{
+			{AlbumID: "1", PhotoID: "a", Position: 1},
+			{AlbumID: "1", PhotoID: "b", Position: 2},
+		},
+	}
+	reordered := model.Album{
+		ID:     "1",
+		UserID: "1",
+		AlbumPhotos: []model.AlbumPhoto{
+			{AlbumID: "1", PhotoID: "b", Position: 1},
+			{AlbumID: "1", PhotoID: "a", Position: 2},
+		},
+	}
+
+	type args struct {
+		request model.AlbumReorderRequest
+		id      string
+		userId  string
+	}
+	tests := []struct {
+		name     string
+		as       *AlbumService
+		args     args
+		want     model.AlbumResponse
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success",
+			as: &AlbumService{
+				AlbumRepository: albumRepository,
+			},
+			args: args{
+				request: model.AlbumReorderRequest{PhotoIDs: []string{"b", "a"}},
+				id:      "1",
+				userId:  "1",
+			},
+			want: model.ToAlbumResponse(reordered),
+			mockFunc: func() {
+				albumRepository.On("GetOne", "1").Return(album, nil).Once()
+				albumRepository.On("ReorderPhotos", "1", []string{"b", "a"}).Return(nil).Once()
+				albumRepository.On("GetOne", "1").Return(reordered, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Failed (Missing photo in order)",
+			as: &AlbumService{
+				AlbumRepository: albumRepository,
+			},
+			args: args{
+				request: model.AlbumReorderRequest{PhotoIDs: []string{"b", "b"}},
+				id:      "1",
+				userId:  "1",
+			},
+			want: model.AlbumResponse{},
+			mockFunc: func() {
+				albumRepository.On("GetOne", "1").Return(album, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #3 - Failed (Not the owner)",
+			as: &AlbumService{
+				AlbumRepository: albumRepository,
+			},
+			args: args{
+				request: model.AlbumReorderRequest{PhotoIDs: []string{"b", "a"}},
+				id:      "1",
+				userId:  "2",
+			},
+			want: model.AlbumResponse{},
+			mockFunc: func() {
+				albumRepository.On("GetOne", mock.Anything).Return(album, nil).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.as.ReorderPhotos(tt.args.request, tt.args.id, tt.args.userId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AlbumService.ReorderPhotos() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AlbumService.ReorderPhotos() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlbumService_UpdateById(t *testing.T) {
+	albumRepository := mocks.NewIAlbumRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(nil, nil, nil, nil, nil)
+
+	private := model.Album{ID: "1", UserID: "1", Title: "Trip", Visibility: model.AlbumVisibilityPrivate}
+	renamed := model.Album{ID: "1", UserID: "1", Title: "Bali", Visibility: model.AlbumVisibilityPrivate}
+
+	type args struct {
+		request model.AlbumUpdateRequest
+		id      string
+		userId  string
+	}
+	tests := []struct {
+		name     string
+		as       *AlbumService
+		args     args
+		want     model.AlbumResponse
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Rename keeps a private album private",
+			as: &AlbumService{
+				AlbumRepository:  albumRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request: model.AlbumUpdateRequest{Title: "Bali"},
+				id:      "1",
+				userId:  "1",
+			},
+			want: model.ToAlbumResponse(renamed),
+			mockFunc: func() {
+				albumRepository.On("GetOne", "1").Return(private, nil).Once()
+				albumRepository.On("Update", model.Album{Title: "Bali", Visibility: model.AlbumVisibilityPrivate}, "1").Return(renamed, nil).Once()
+				albumRepository.On("GetOne", "1").Return(renamed, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Visibility given is applied",
+			as: &AlbumService{
+				AlbumRepository:  albumRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request: model.AlbumUpdateRequest{Title: "Trip", Visibility: model.AlbumVisibilityPublic},
+				id:      "1",
+				userId:  "1",
+			},
+			want: model.ToAlbumResponse(model.Album{ID: "1", UserID: "1", Title: "Trip", Visibility: model.AlbumVisibilityPublic}),
+			mockFunc: func() {
+				albumRepository.On("GetOne", "1").Return(private, nil).Once()
+				albumRepository.On("Update", model.Album{Title: "Trip", Visibility: model.AlbumVisibilityPublic}, "1").Return(model.Album{}, nil).Once()
+				albumRepository.On("GetOne", "1").Return(model.Album{ID: "1", UserID: "1", Title: "Trip", Visibility: model.AlbumVisibilityPublic}, nil).Once()
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.as.UpdateById(tt.args.request, tt.args.id, tt.args.userId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AlbumService.UpdateById() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AlbumService.UpdateById() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
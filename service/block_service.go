@@ -0,0 +1,132 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository"
+)
+
+type BlockService struct {
+	BlockRepository repository.IBlockRepository
+	MuteRepository  repository.IMuteRepository
+	UserRepository  repository.IUserRepository
+}
+
+func NewBlockService(blockRepository repository.IBlockRepository, muteRepository repository.IMuteRepository, userRepository repository.IUserRepository) *BlockService {
+	return &BlockService{
+		BlockRepository: blockRepository,
+		MuteRepository:  muteRepository,
+		UserRepository:  userRepository,
+	}
+}
+
+// Block blocks a user. Any follow between the two users is removed.
+func (bs *BlockService) Block(username string, userId string) (model.UserRelationResponse, error) {
+	user, err := bs.UserRepository.GetByUsername(username)
+	if err != nil {
+		return model.UserRelationResponse{}, err
+	}
+
+	if user.ID == userId {
+		return model.UserRelationResponse{}, model.ErrorCannotBlockSelf
+	}
+
+	block := model.Block{
+		BlockerID: userId,
+		BlockedID: user.ID,
+	}
+
+	res, err := bs.BlockRepository.Save(block)
+	if err != nil {
+		return model.UserRelationResponse{}, err
+	}
+
+	return model.UserRelationResponse{
+		UserID:    user.ID,
+		Username:  user.Username,
+		CreatedAt: res.CreatedAt,
+	}, nil
+}
+
+func (bs *BlockService) Unblock(username string, userId string) error {
+	user, err := bs.UserRepository.GetByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	return bs.BlockRepository.Delete(userId, user.ID)
+}
+
+func (bs *BlockService) GetBlocked(userId string) ([]model.UserRelationResponse, error) {
+	blockedResponse := make([]model.UserRelationResponse, 0)
+
+	res, err := bs.BlockRepository.GetByBlockerID(userId)
+	if err != nil {
+		return []model.UserRelationResponse{}, err
+	}
+
+	for _, block := range res {
+		blockedResponse = append(blockedResponse, model.UserRelationResponse{
+			UserID:    block.BlockedID,
+			Username:  block.Blocked.Username,
+			CreatedAt: block.CreatedAt,
+		})
+	}
+
+	return blockedResponse, nil
+}
+
+// Mute hides a user's content from your feed without them knowing.
+func (bs *BlockService) Mute(username string, userId string) (model.UserRelationResponse, error) {
+	user, err := bs.UserRepository.GetByUsername(username)
+	if err != nil {
+		return model.UserRelationResponse{}, err
+	}
+
+	if user.ID == userId {
+		return model.UserRelationResponse{}, model.ErrorCannotMuteSelf
+	}
+
+	mute := model.Mute{
+		MuterID: userId,
+		MutedID: user.ID,
+	}
+
+	res, err := bs.MuteRepository.Save(mute)
+	if err != nil {
+		return model.UserRelationResponse{}, err
+	}
+
+	return model.UserRelationResponse{
+		UserID:    user.ID,
+		Username:  user.Username,
+		CreatedAt: res.CreatedAt,
+	}, nil
+}
+
+func (bs *BlockService) Unmute(username string, userId string) error {
+	user, err := bs.UserRepository.GetByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	return bs.MuteRepository.Delete(userId, user.ID)
+}
+
+func (bs *BlockService) GetMuted(userId string) ([]model.UserRelationResponse, error) {
+	mutedResponse := make([]model.UserRelationResponse, 0)
+
+	res, err := bs.MuteRepository.GetByMuterID(userId)
+	if err != nil {
+		return []model.UserRelationResponse{}, err
+	}
+
+	for _, mute := range res {
+		mutedResponse = append(mutedResponse, model.UserRelationResponse{
+			UserID:    mute.MutedID,
+			Username:  mute.Muted.Username,
+			CreatedAt: mute.CreatedAt,
+		})
+	}
+
+	return mutedResponse, nil
+}
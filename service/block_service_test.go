@@ -0,0 +1,94 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository/mocks"
+	"reflect"
+	"testing"
+)
+
+func TestBlockService_Block(t *testing.T) {
+	blockRepository := mocks.NewIBlockRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+
+	type args struct {
+		username string
+		userId   string
+	}
+	tests := []struct {
+		name     string
+		bs       *BlockService
+		args     args
+		want     model.UserRelationResponse
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success",
+			bs: &BlockService{
+				BlockRepository: blockRepository,
+				UserRepository:  userRepository,
+			},
+			args: args{
+				username: "spammer",
+				userId:   "1",
+			},
+			want: model.UserRelationResponse{
+				UserID:   "2",
+				Username: "spammer",
+			},
+			mockFunc: func() {
+				block := model.Block{BlockerID: "1", BlockedID: "2"}
+				userRepository.On("GetByUsername", "spammer").Return(model.User{ID: "2", Username: "spammer"}, nil).Once()
+				blockRepository.On("Save", block).Return(block, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Failed (Cannot block self)",
+			bs: &BlockService{
+				BlockRepository: blockRepository,
+				UserRepository:  userRepository,
+			},
+			args: args{
+				username: "me",
+				userId:   "1",
+			},
+			want: model.UserRelationResponse{},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "me").Return(model.User{ID: "1", Username: "me"}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #3 - Failed (Already blocked)",
+			bs: &BlockService{
+				BlockRepository: blockRepository,
+				UserRepository:  userRepository,
+			},
+			args: args{
+				username: "spammer",
+				userId:   "1",
+			},
+			want: model.UserRelationResponse{},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "spammer").Return(model.User{ID: "2", Username: "spammer"}, nil).Once()
+				blockRepository.On("Save", model.Block{BlockerID: "1", BlockedID: "2"}).Return(model.Block{}, model.ErrorAlreadyBlocked).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.bs.Block(tt.args.username, tt.args.userId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BlockService.Block() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BlockService.Block() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
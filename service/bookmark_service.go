@@ -0,0 +1,81 @@
+package service
+
+import (
+	"mygram/helper"
+	"mygram/model"
+	"mygram/repository"
+	"strings"
+)
+
+type BookmarkService struct {
+	BookmarkRepository repository.IBookmarkRepository
+	PhotoRepository    repository.IPhotoRepository
+	VisibilityPolicy   *VisibilityPolicy
+}
+
+func NewBookmarkService(bookmarkRepository repository.IBookmarkRepository, photoRepository repository.IPhotoRepository, visibilityPolicy *VisibilityPolicy) *BookmarkService {
+	return &BookmarkService{
+		BookmarkRepository: bookmarkRepository,
+		PhotoRepository:    photoRepository,
+		VisibilityPolicy:   visibilityPolicy,
+	}
+}
+
+func (bs *BookmarkService) Add(request model.BookmarkCreateRequest, photoId string, userId string) (model.BookmarkResponse, error) {
+	photo, err := bs.PhotoRepository.GetOne(photoId)
+	if err != nil {
+		return model.BookmarkResponse{}, err
+	}
+
+	visible, err := bs.VisibilityPolicy.FilterPhotos(userId, []model.Photo{photo})
+	if err != nil {
+		return model.BookmarkResponse{}, err
+	}
+	if len(visible) == 0 {
+		return model.BookmarkResponse{}, model.ErrorNotFound
+	}
+	photo = visible[0]
+
+	collection := strings.TrimSpace(request.Collection)
+	if collection == "" {
+		collection = model.DefaultBookmarkCollection
+	}
+
+	bookmark := model.Bookmark{
+		ID:         helper.GenerateID(),
+		UserID:     userId,
+		PhotoID:    photo.ID,
+		Collection: collection,
+	}
+
+	res, err := bs.BookmarkRepository.Save(bookmark)
+	if err != nil {
+		return model.BookmarkResponse{}, err
+	}
+
+	res.Photo = photo
+	return model.ToBookmarkResponse(res), nil
+}
+
+func (bs *BookmarkService) DeleteByPhotoId(photoId string, userId string) error {
+	return bs.BookmarkRepository.Delete(userId, photoId)
+}
+
+func (bs *BookmarkService) GetMine(request model.BookmarkListRequest, userId string) (model.BookmarkListResponse, error) {
+	bookmarksResponse := make([]model.BookmarkResponse, 0)
+	pagination := request.PaginationRequest.Normalize()
+
+	res, total, err := bs.BookmarkRepository.GetByUserID(userId, request.Collection, pagination)
+	if err != nil {
+		return model.BookmarkListResponse{}, err
+	}
+
+	for _, bookmark := range res {
+		bookmarksResponse = append(bookmarksResponse, model.ToBookmarkResponse(bookmark))
+	}
+
+	return model.BookmarkListResponse{
+		Bookmarks:  bookmarksResponse,
+		Pagination: model.ToPaginationResponse(pagination, total),
+	}, nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository/mocks"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkService_Add(t *testing.T) {
+	bookmarkRepository := mocks.NewIBookmarkRepository(t)
+	photoRepository := mocks.NewIPhotoRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	followRepository := mocks.NewIFollowRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(userRepository, followRepository, photoRepository, blockRepository, nil)
+
+	photo := model.Photo{ID: "1", UserID: "2", Title: "Sunset", PhotoURL: "https://img/1.jpg"}
+
+	type args struct {
+		request model.BookmarkCreateRequest
+		photoId string
+		userId  string
+	}
+	tests := []struct {
+		name     string
+		bs       *BookmarkService
+		args     args
+		want     model.BookmarkResponse
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success (Default collection)",
+			bs: &BookmarkService{
+				BookmarkRepository: bookmarkRepository,
+				PhotoRepository:    photoRepository,
+				VisibilityPolicy:   visibilityPolicy,
+			},
+			args: args{
+				request: model.BookmarkCreateRequest{Collection: "  "},
+				photoId: "1",
+				userId:  "1",
+			},
+			want: model.BookmarkResponse{
+				ID:         "b1",
+				PhotoID:    "1",
+				Collection: model.DefaultBookmarkCollection,
+				Photo: model.PhotoInBookmarkResponse{
+					ID:       "1",
+					UserID:   "2",
+					Title:    "Sunset",
+					PhotoURL: "https://img/1.jpg",
+				},
+			},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "1").Return(photo, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				bookmarkRepository.
+					On("Save", mock.MatchedBy(func(bookmark model.Bookmark) bool {
+						return bookmark.Collection == model.DefaultBookmarkCollection && bookmark.UserID == "1"
+					})).
+					Return(model.Bookmark{ID: "b1", UserID: "1", PhotoID: "1", Collection: model.DefaultBookmarkCollection}, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Failed (Already bookmarked)",
+			bs: &BookmarkService{
+				BookmarkRepository: bookmarkRepository,
+				PhotoRepository:    photoRepository,
+				VisibilityPolicy:   visibilityPolicy,
+			},
+			args: args{
+				request: model.BookmarkCreateRequest{Collection: "Travel"},
+				photoId: "1",
+				userId:  "1",
+			},
+			want: model.BookmarkResponse{},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "1").Return(photo, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				bookmarkRepository.On("Save", mock.Anything).Return(model.Bookmark{}, model.ErrorAlreadyBookmarked).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #3 - Failed (Photo not found)",
+			bs: &BookmarkService{
+				BookmarkRepository: bookmarkRepository,
+				PhotoRepository:    photoRepository,
+				VisibilityPolicy:   visibilityPolicy,
+			},
+			args: args{
+				photoId: "404",
+				userId:  "1",
+			},
+			want: model.BookmarkResponse{},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "404").Return(model.Photo{}, model.ErrorNotFound).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #4 - Failed (Private owner not followed)",
+			bs: &BookmarkService{
+				BookmarkRepository: bookmarkRepository,
+				PhotoRepository:    photoRepository,
+				VisibilityPolicy:   visibilityPolicy,
+			},
+			args: args{
+				photoId: "5",
+				userId:  "1",
+			},
+			want: model.BookmarkResponse{},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "5").Return(model.Photo{ID: "5", UserID: "3"}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"3"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"3"}).Return([]model.User{{ID: "3", IsPrivate: true}}, nil).Once()
+				followRepository.On("GetAcceptedFollowingIDs", "1", []string{"3"}).Return([]string{}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #5 - Failed (Photo hidden by moderation)",
+			bs: &BookmarkService{
+				BookmarkRepository: bookmarkRepository,
+				PhotoRepository:    photoRepository,
+				VisibilityPolicy:   visibilityPolicy,
+			},
+			args: args{
+				photoId: "6",
+				userId:  "1",
+			},
+			want: model.BookmarkResponse{},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "6").Return(model.Photo{ID: "6", UserID: "2", Hidden: true}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #6 - Failed (Photo is a draft)",
+			bs: &BookmarkService{
+				BookmarkRepository: bookmarkRepository,
+				PhotoRepository:    photoRepository,
+				VisibilityPolicy:   visibilityPolicy,
+			},
+			args: args{
+				photoId: "7",
+				userId:  "1",
+			},
+			want: model.BookmarkResponse{},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "7").Return(model.Photo{ID: "7", UserID: "2", Status: model.PhotoStatusDraft}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.bs.Add(tt.args.request, tt.args.photoId, tt.args.userId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BookmarkService.Add() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BookmarkService.Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"mygram/model"
+	"strings"
+)
+
+// Captioner suggests alt text for a media item the owner left undescribed.
+// Suggestions are marked as generated so owners can review them.
+type Captioner interface {
+	Suggest(request model.CaptionRequest) (string, error)
+}
+
+// StubCaptioner builds alt text from the post title and caption without
+// looking at the image. It is deterministic, so it also serves tests, until a
+// real image captioning service is plugged in.
+type StubCaptioner struct{}
+
+func NewStubCaptioner() *StubCaptioner {
+	return &StubCaptioner{}
+}
+
+func (sc *StubCaptioner) Suggest(request model.CaptionRequest) (string, error) {
+	subject := strings.TrimSpace(request.Title)
+	if caption := strings.TrimSpace(request.Caption); caption != "" {
+		subject += ": " + caption
+	}
+
+	suggestion := "Photo"
+	if request.Count > 1 {
+		suggestion = fmt.Sprintf("Image %d of %d", request.Position+1, request.Count)
+	}
+	if subject != "" {
+		suggestion += " from the post " + subject
+	}
+	return truncate(model.NormalizeAltText(suggestion), model.MaxAltTextLength), nil
+}
+
+// suggestAltText fills the items without alt text with suggestions. A failing
+// captioner leaves them empty, they show up in the missing alt text report.
+func suggestAltText(captioner Captioner, media []model.PhotoMedia, title string, caption string) {
+	if captioner == nil {
+		return
+	}
+
+	for i := range media {
+		if media[i].AltText != "" {
+			continue
+		}
+		suggestion, err := captioner.Suggest(model.CaptionRequest{
+			URL:      media[i].URL,
+			Title:    title,
+			Caption:  caption,
+			Position: media[i].Position,
+			Count:    len(media),
+		})
+		suggestion = model.NormalizeAltText(suggestion)
+		if err != nil || suggestion == "" || model.ValidateAltText(suggestion) != nil {
+			continue
+		}
+		media[i].AltText = suggestion
+		media[i].AltTextGenerated = true
+	}
+}
@@ -1,27 +1,68 @@
 package service
 
 import (
+	"errors"
 	"mygram/helper"
 	"mygram/model"
 	"mygram/repository"
+	"time"
 )
 
 type CommentService struct {
-	CommentRepository repository.ICommentRepository
-	PhotoRepository   repository.IPhotoRepository
+	CommentRepository  repository.ICommentRepository
+	PhotoRepository    repository.IPhotoRepository
+	UserRepository     repository.IUserRepository
+	ReactionRepository repository.IReactionRepository
+	VisibilityPolicy   *VisibilityPolicy
+	CommentFilter      *FilterPipeline
+	// EditWindow is how long after posting a comment can still be edited,
+	// zero means comments stay editable.
+	EditWindow time.Duration
+	// PinnedLimit is how many comments a photo owner can pin per photo.
+	PinnedLimit int
 }
 
-func NewCommentService(commentRepository repository.ICommentRepository, photoRepository repository.IPhotoRepository) *CommentService {
+func NewCommentService(commentRepository repository.ICommentRepository, photoRepository repository.IPhotoRepository, userRepository repository.IUserRepository, reactionRepository repository.IReactionRepository, visibilityPolicy *VisibilityPolicy, commentFilter *FilterPipeline, editWindow time.Duration, pinnedLimit int) *CommentService {
 	return &CommentService{
-		CommentRepository: commentRepository,
-		PhotoRepository:   photoRepository,
+		CommentRepository:  commentRepository,
+		PhotoRepository:    photoRepository,
+		UserRepository:     userRepository,
+		ReactionRepository: reactionRepository,
+		VisibilityPolicy:   visibilityPolicy,
+		CommentFilter:      commentFilter,
+		EditWindow:         editWindow,
+		PinnedLimit:        pinnedLimit,
 	}
 }
 
 func (cs *CommentService) Add(request model.CommentCreateRequest, userId string, photoId string) (model.CommentCreateResponse, error) {
 	id := helper.GenerateID()
 
-	_, err := cs.PhotoRepository.GetOne(photoId)
+	photo, err := cs.PhotoRepository.GetOne(photoId)
+	if err != nil {
+		return model.CommentCreateResponse{}, err
+	}
+
+	// A block in either direction makes the photo invisible, so it is answered
+	// like any other photo the user may not see rather than with ErrorBlocked.
+	canView, err := cs.VisibilityPolicy.CanView(userId, photo.UserID)
+	if err != nil {
+		return model.CommentCreateResponse{}, err
+	}
+	if !canView || isPhotoHiddenFrom(userId, photo) {
+		return model.CommentCreateResponse{}, model.ErrorNotFound
+	}
+
+	err = cs.checkCommentPolicy(photo, userId)
+	if err != nil {
+		return model.CommentCreateResponse{}, err
+	}
+
+	filterResult, err := cs.CommentFilter.Check(model.FilterContent{
+		UserID: userId,
+		Kind:   model.FilterContentComment,
+		Text:   request.Message,
+	})
 	if err != nil {
 		return model.CommentCreateResponse{}, err
 	}
@@ -31,6 +72,7 @@ func (cs *CommentService) Add(request model.CommentCreateRequest, userId string,
 		UserID:  userId,
 		PhotoID: photoId,
 		Message: request.Message,
+		Hidden:  filterResult.Verdict == model.FilterVerdictHold,
 	}
 
 	res, err := cs.CommentRepository.Save(comment)
@@ -38,16 +80,24 @@ func (cs *CommentService) Add(request model.CommentCreateRequest, userId string,
 		return model.CommentCreateResponse{}, err
 	}
 
+	if res.Hidden {
+		err = cs.CommentFilter.Hold(filterResult, model.ReportTargetComment, res.ID, userId, res.Message)
+		if err != nil {
+			return model.CommentCreateResponse{}, err
+		}
+	}
+
 	return model.CommentCreateResponse{
-		ID:        res.ID,
-		UserID:    res.UserID,
-		PhotoID:   res.PhotoID,
-		Message:   res.Message,
-		CreatedAt: res.CreatedAt,
+		ID:            res.ID,
+		UserID:        res.UserID,
+		PhotoID:       res.PhotoID,
+		Message:       res.Message,
+		PendingReview: res.Hidden,
+		CreatedAt:     res.CreatedAt,
 	}, nil
 }
 
-func (cs *CommentService) GetAll() ([]model.CommentResponse, error) {
+func (cs *CommentService) GetAll(userId string) ([]model.CommentResponse, error) {
 	var commentResponse []model.CommentResponse
 
 	res, err := cs.CommentRepository.Get()
@@ -56,31 +106,91 @@ func (cs *CommentService) GetAll() ([]model.CommentResponse, error) {
 		return []model.CommentResponse{}, err
 	}
 
+	res, err = cs.VisibilityPolicy.FilterFeedComments(userId, res)
+	if err != nil {
+		return []model.CommentResponse{}, err
+	}
+
+	commentIds := make([]string, 0, len(res))
 	for _, val := range res {
-		commentResponse = append(commentResponse, model.CommentResponse(val))
+		commentIds = append(commentIds, val.ID)
+	}
+	reactions, err := reactionCounts(cs.ReactionRepository, commentIds)
+	if err != nil {
+		return []model.CommentResponse{}, err
+	}
+
+	for _, val := range res {
+		commentResponse = append(commentResponse, model.ToCommentResponse(val, reactionsOf(reactions, val.ID)))
 	}
 
 	return commentResponse, nil
 }
 
-func (cs *CommentService) GetById(id string) (model.CommentResponse, error) {
+// GetByPhoto lists the comments on a photo userId may see, pinned ones first.
+func (cs *CommentService) GetByPhoto(request model.PaginationRequest, photoId string, userId string) (model.CommentListResponse, error) {
+	pagination := request.Normalize()
+
+	visiblePhotos, err := cs.VisibilityPolicy.VisiblePhotoIDs(userId, []string{photoId})
+	if err != nil {
+		return model.CommentListResponse{}, err
+	}
+	if !visiblePhotos[photoId] {
+		return model.CommentListResponse{}, model.ErrorNotFound
+	}
+
+	res, total, err := cs.CommentRepository.GetByPhotoID(photoId, userId, pagination)
+	if err != nil {
+		return model.CommentListResponse{}, err
+	}
+
+	return cs.toCommentListResponse(res, pagination, total)
+}
+
+// Mine lists the comments of userId, newest first. Comments on photos the
+// user can no longer see are left out.
+func (cs *CommentService) Mine(request model.PaginationRequest, userId string) (model.CommentListResponse, error) {
+	pagination := request.Normalize()
+
+	res, total, err := cs.CommentRepository.GetByUserID(userId, pagination)
+	if err != nil {
+		return model.CommentListResponse{}, err
+	}
+
+	return cs.toCommentListResponse(res, pagination, total)
+}
+
+func (cs *CommentService) GetById(id string, userId string) (model.CommentResponse, error) {
 	res, err := cs.CommentRepository.GetOne(id)
 
 	if err != nil {
-		if err == model.ErrorNotFound {
+		if errors.Is(err, model.ErrorNotFound) {
 			return model.CommentResponse{}, model.ErrorNotFound
 		}
 		return model.CommentResponse{}, err
 	}
 
-	return model.CommentResponse(res), nil
+	visible, err := cs.VisibilityPolicy.FilterComments(userId, []model.Comment{res})
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+	if len(visible) == 0 {
+		return model.CommentResponse{}, model.ErrorNotFound
+	}
+
+	reactions, err := reactionCounts(cs.ReactionRepository, []string{visible[0].ID})
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+
+	return model.ToCommentResponse(visible[0], reactionsOf(reactions, visible[0].ID)), nil
 }
 
 func (cs *CommentService) UpdateById(request model.CommentUpdateRequest, userId string, id string) (model.CommentUpdateResponse, error) {
 	comment, err := cs.CommentRepository.GetOne(id)
 
 	if err != nil {
-		if err == model.ErrorNotFound {
+		if errors.Is(err, model.ErrorNotFound) {
 			return model.CommentUpdateResponse{}, model.ErrorNotFound
 		}
 		return model.CommentUpdateResponse{}, err
@@ -90,17 +200,75 @@ func (cs *CommentService) UpdateById(request model.CommentUpdateRequest, userId
 		return model.CommentUpdateResponse{}, model.ErrorForbiddenAccess
 	}
 
+	if cs.EditWindow > 0 && time.Since(comment.CreatedAt) > cs.EditWindow {
+		return model.CommentUpdateResponse{}, model.ErrorEditWindowClosed
+	}
+
+	filterResult, err := cs.CommentFilter.Check(model.FilterContent{
+		ID:     id,
+		UserID: userId,
+		Kind:   model.FilterContentComment,
+		Text:   request.Message,
+	})
+	if err != nil {
+		return model.CommentUpdateResponse{}, err
+	}
+
 	commentUpdate := model.Comment{
 		Message: request.Message,
+		Hidden:  filterResult.Verdict == model.FilterVerdictHold,
 	}
 
-	res, err := cs.CommentRepository.Update(commentUpdate, id)
+	revision := model.CommentRevision{
+		ID: helper.GenerateID(),
+	}
+
+	res, err := cs.CommentRepository.Update(commentUpdate, id, revision)
 	if err != nil {
 		return model.CommentUpdateResponse{}, err
 	}
 
-	return model.CommentUpdateResponse(res), nil
+	if commentUpdate.Hidden {
+		err = cs.CommentFilter.Hold(filterResult, model.ReportTargetComment, id, userId, res.Message)
+		if err != nil {
+			return model.CommentUpdateResponse{}, err
+		}
+	}
+
+	return model.ToCommentUpdateResponse(res), nil
+
+}
+
+// GetRevisions lists the previous versions of a comment, oldest first. Only
+// the author and moderators may read them.
+func (cs *CommentService) GetRevisions(id string, userId string) ([]model.CommentRevisionResponse, error) {
+	revisionsResponse := make([]model.CommentRevisionResponse, 0)
+
+	comment, err := cs.CommentRepository.GetOne(id)
+	if err != nil {
+		return []model.CommentRevisionResponse{}, err
+	}
+
+	if comment.UserID != userId {
+		user, err := cs.UserRepository.GetOne(userId)
+		if err != nil {
+			return []model.CommentRevisionResponse{}, err
+		}
+		if !user.IsModerator() {
+			return []model.CommentRevisionResponse{}, model.ErrorForbiddenAccess
+		}
+	}
+
+	res, err := cs.CommentRepository.GetRevisions(id)
+	if err != nil {
+		return []model.CommentRevisionResponse{}, err
+	}
+
+	for _, revision := range res {
+		revisionsResponse = append(revisionsResponse, model.ToCommentRevisionResponse(revision))
+	}
 
+	return revisionsResponse, nil
 }
 
 func (cs *CommentService) DeleteById(userId string, id string) error {
@@ -108,14 +276,21 @@ func (cs *CommentService) DeleteById(userId string, id string) error {
 	comment, err := cs.CommentRepository.GetOne(id)
 
 	if err != nil {
-		if err == model.ErrorNotFound {
+		if errors.Is(err, model.ErrorNotFound) {
 			return model.ErrorNotFound
 		}
 		return err
 	}
 
+	// Photo owners moderate the discussion on their own photos.
 	if comment.UserID != userId {
-		return model.ErrorForbiddenAccess
+		photo, err := cs.PhotoRepository.GetOne(comment.PhotoID)
+		if err != nil {
+			return err
+		}
+		if photo.UserID != userId {
+			return model.ErrorForbiddenAccess
+		}
 	}
 
 	err = cs.CommentRepository.Delete(id)
@@ -125,3 +300,109 @@ func (cs *CommentService) DeleteById(userId string, id string) error {
 
 	return nil
 }
+
+// Pin pins a comment to the top of the owner's photo, up to PinnedLimit per photo.
+func (cs *CommentService) Pin(id string, userId string) (model.CommentResponse, error) {
+	comment, err := cs.getCommentOnOwnPhoto(id, userId)
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+
+	err = cs.CommentRepository.Pin(comment.ID, comment.PhotoID, cs.PinnedLimit)
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+
+	return cs.toCommentResponse(comment.ID)
+}
+
+func (cs *CommentService) Unpin(id string, userId string) (model.CommentResponse, error) {
+	comment, err := cs.getCommentOnOwnPhoto(id, userId)
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+
+	err = cs.CommentRepository.Unpin(comment.ID)
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+
+	return cs.toCommentResponse(comment.ID)
+}
+
+// checkCommentPolicy applies the photo owner's comment setting. Turning
+// comments off applies to the owner too.
+func (cs *CommentService) checkCommentPolicy(photo model.Photo, userId string) error {
+	switch photo.CommentPolicy {
+	case model.CommentPolicyOff:
+		return model.ErrorCommentsDisabled
+	case model.CommentPolicyFollowers:
+		if photo.UserID == userId {
+			return nil
+		}
+		isFollower, err := cs.VisibilityPolicy.IsFollower(userId, photo.UserID)
+		if err != nil {
+			return err
+		}
+		if !isFollower {
+			return model.ErrorCommentsFollowersOnly
+		}
+	}
+	return nil
+}
+
+// getCommentOnOwnPhoto returns a comment on a photo owned by userId. Other
+// users' comments held for review stay hidden from the photo owner.
+func (cs *CommentService) getCommentOnOwnPhoto(id string, userId string) (model.Comment, error) {
+	comment, err := cs.CommentRepository.GetOne(id)
+	if err != nil {
+		return model.Comment{}, err
+	}
+	if comment.Hidden && comment.UserID != userId {
+		return model.Comment{}, model.ErrorNotFound
+	}
+
+	photo, err := cs.PhotoRepository.GetOne(comment.PhotoID)
+	if err != nil {
+		return model.Comment{}, err
+	}
+	if photo.UserID != userId {
+		return model.Comment{}, model.ErrorForbiddenAccess
+	}
+
+	return comment, nil
+}
+
+func (cs *CommentService) toCommentResponse(id string) (model.CommentResponse, error) {
+	comment, err := cs.CommentRepository.GetOne(id)
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+
+	reactions, err := reactionCounts(cs.ReactionRepository, []string{comment.ID})
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+
+	return model.ToCommentResponse(comment, reactionsOf(reactions, comment.ID)), nil
+}
+
+func (cs *CommentService) toCommentListResponse(comments []model.Comment, pagination model.PaginationRequest, total int64) (model.CommentListResponse, error) {
+	commentIds := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		commentIds = append(commentIds, comment.ID)
+	}
+	reactions, err := reactionCounts(cs.ReactionRepository, commentIds)
+	if err != nil {
+		return model.CommentListResponse{}, err
+	}
+
+	commentResponse := make([]model.CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		commentResponse = append(commentResponse, model.ToCommentResponse(comment, reactionsOf(reactions, comment.ID)))
+	}
+	return model.CommentListResponse{
+		Comments:   commentResponse,
+		Pagination: model.ToPaginationResponse(pagination, total),
+	}, nil
+}
@@ -0,0 +1,489 @@
+package service
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentService_UpdateById(t *testing.T) {
+	commentRepository := mocks.NewICommentRepository(t)
+	reportRepository := mocks.NewIReportRepository(t)
+
+	editedAt := time.Now()
+
+	type args struct {
+		request model.CommentUpdateRequest
+		userId  string
+		id      string
+	}
+	tests := []struct {
+		name     string
+		cs       *CommentService
+		args     args
+		want     bool
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "Case #1 - Success (Edited within window)",
+			cs:   &CommentService{CommentRepository: commentRepository, EditWindow: time.Hour},
+			args: args{
+				request: model.CommentUpdateRequest{Message: "Updated"},
+				userId:  "1",
+				id:      "c1",
+			},
+			want: true,
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(model.Comment{ID: "c1", UserID: "1", Message: "Original", CreatedAt: time.Now().Add(-time.Minute)}, nil).Once()
+				commentRepository.
+					On("Update", model.Comment{Message: "Updated"}, "c1", mock.MatchedBy(func(revision model.CommentRevision) bool { return revision.ID != "" })).
+					Return(model.Comment{ID: "c1", UserID: "1", Message: "Updated", EditedAt: &editedAt}, nil).Once()
+			},
+		},
+		{
+			name: "Case #2 - Failed (Edit window closed)",
+			cs:   &CommentService{CommentRepository: commentRepository, EditWindow: time.Hour},
+			args: args{
+				request: model.CommentUpdateRequest{Message: "Updated"},
+				userId:  "1",
+				id:      "c2",
+			},
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c2").Return(model.Comment{ID: "c2", UserID: "1", Message: "Original", CreatedAt: time.Now().Add(-2 * time.Hour)}, nil).Once()
+			},
+			wantErr: model.ErrorEditWindowClosed,
+		},
+		{
+			name: "Case #3 - Failed (Not the author)",
+			cs:   &CommentService{CommentRepository: commentRepository},
+			args: args{
+				request: model.CommentUpdateRequest{Message: "Updated"},
+				userId:  "2",
+				id:      "c3",
+			},
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c3").Return(model.Comment{ID: "c3", UserID: "1", Message: "Original"}, nil).Once()
+			},
+			wantErr: model.ErrorForbiddenAccess,
+		},
+		{
+			name: "Case #4 - Success (Saved again with the same text)",
+			cs: &CommentService{
+				CommentRepository: commentRepository,
+				CommentFilter:     NewFilterPipeline(nil, NewDuplicateCommentFilter(commentRepository, time.Hour), NewCommentRateFilter(commentRepository, 1, time.Hour)),
+			},
+			args: args{
+				request: model.CommentUpdateRequest{Message: "Original"},
+				userId:  "1",
+				id:      "c4",
+			},
+			want: true,
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c4").Return(model.Comment{ID: "c4", UserID: "1", Message: "Original", CreatedAt: time.Now()}, nil).Once()
+				commentRepository.On("GetRecentByUserID", "1", mock.Anything).Return([]model.Comment{{ID: "c4", Message: "Original"}}, nil).Once()
+				commentRepository.
+					On("Update", model.Comment{Message: "Original"}, "c4", mock.Anything).
+					Return(model.Comment{ID: "c4", UserID: "1", Message: "Original", EditedAt: &editedAt}, nil).Once()
+			},
+		},
+		{
+			name: "Case #5 - Success (Edited at the posting rate limit)",
+			cs: &CommentService{
+				CommentRepository: commentRepository,
+				CommentFilter:     NewFilterPipeline(nil, NewCommentRateFilter(commentRepository, 1, time.Hour)),
+			},
+			args: args{
+				request: model.CommentUpdateRequest{Message: "Updated"},
+				userId:  "1",
+				id:      "c5",
+			},
+			want: true,
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c5").Return(model.Comment{ID: "c5", UserID: "1", Message: "Original", CreatedAt: time.Now()}, nil).Once()
+				commentRepository.
+					On("Update", model.Comment{Message: "Updated"}, "c5", mock.Anything).
+					Return(model.Comment{ID: "c5", UserID: "1", Message: "Updated", EditedAt: &editedAt}, nil).Once()
+			},
+		},
+		{
+			name: "Case #6 - Success (Held text is hidden in the same update)",
+			cs: &CommentService{
+				CommentRepository: commentRepository,
+				CommentFilter:     NewFilterPipeline(reportRepository, NewLinkLimitFilter(0)),
+			},
+			args: args{
+				request: model.CommentUpdateRequest{Message: "https://a.io"},
+				userId:  "1",
+				id:      "c6",
+			},
+			want: true,
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c6").Return(model.Comment{ID: "c6", UserID: "1", Message: "Original", CreatedAt: time.Now()}, nil).Once()
+				commentRepository.
+					On("Update", model.Comment{Message: "https://a.io", Hidden: true}, "c6", mock.Anything).
+					Return(model.Comment{ID: "c6", UserID: "1", Message: "https://a.io", Hidden: true, EditedAt: &editedAt}, nil).Once()
+				reportRepository.
+					On("Save", mock.MatchedBy(func(report model.Report) bool { return report.TargetID == "c6" })).
+					Return(model.Report{}, nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.cs.UpdateById(tt.args.request, tt.args.userId, tt.args.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CommentService.UpdateById() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Edited != tt.want {
+				t.Errorf("CommentService.UpdateById() edited = %v, want %v", got.Edited, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentService_GetRevisions(t *testing.T) {
+	commentRepository := mocks.NewICommentRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+
+	comment := model.Comment{ID: "c1", UserID: "1", Message: "Third"}
+	revisions := []model.CommentRevision{
+		{ID: "r1", CommentID: "c1", Message: "First"},
+		{ID: "r2", CommentID: "c1", Message: "Second"},
+	}
+
+	tests := []struct {
+		name     string
+		userId   string
+		want     int
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:   "Case #1 - Success (Author)",
+			userId: "1",
+			want:   2,
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				commentRepository.On("GetRevisions", "c1").Return(revisions, nil).Once()
+			},
+		},
+		{
+			name:   "Case #2 - Success (Moderator)",
+			userId: "2",
+			want:   2,
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				userRepository.On("GetOne", "2").Return(model.User{ID: "2", Role: model.UserRoleModerator}, nil).Once()
+				commentRepository.On("GetRevisions", "c1").Return(revisions, nil).Once()
+			},
+		},
+		{
+			name:   "Case #3 - Failed (Other user)",
+			userId: "3",
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				userRepository.On("GetOne", "3").Return(model.User{ID: "3", Role: model.UserRoleUser}, nil).Once()
+			},
+			wantErr: model.ErrorForbiddenAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := &CommentService{
+				CommentRepository: commentRepository,
+				UserRepository:    userRepository,
+			}
+			tt.mockFunc()
+			got, err := cs.GetRevisions("c1", tt.userId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CommentService.GetRevisions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("CommentService.GetRevisions() = %v, want %v revisions", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentService_Add(t *testing.T) {
+	commentRepository := mocks.NewICommentRepository(t)
+	photoRepository := mocks.NewIPhotoRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	followRepository := mocks.NewIFollowRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(userRepository, followRepository, photoRepository, blockRepository, nil)
+
+	cs := &CommentService{
+		CommentRepository: commentRepository,
+		PhotoRepository:   photoRepository,
+		VisibilityPolicy:  visibilityPolicy,
+	}
+
+	tests := []struct {
+		name     string
+		photoId  string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "Case #1 - Success (Follower on followers only photo)",
+			photoId: "p1",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(model.Photo{ID: "p1", UserID: "2", CommentPolicy: model.CommentPolicyFollowers}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				followRepository.On("GetAcceptedFollowingIDs", "1", []string{"2"}).Return([]string{"2"}, nil).Once()
+				commentRepository.On("Save", mock.Anything).Return(func(comment model.Comment) (model.Comment, error) {
+					return comment, nil
+				}).Once()
+			},
+		},
+		{
+			name:    "Case #2 - Failed (Not a follower on followers only photo)",
+			photoId: "p2",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p2").Return(model.Photo{ID: "p2", UserID: "2", CommentPolicy: model.CommentPolicyFollowers}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				followRepository.On("GetAcceptedFollowingIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+			},
+			wantErr: model.ErrorCommentsFollowersOnly,
+		},
+		{
+			name:    "Case #3 - Failed (Comments turned off)",
+			photoId: "p3",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p3").Return(model.Photo{ID: "p3", UserID: "1", CommentPolicy: model.CommentPolicyOff}, nil).Once()
+			},
+			wantErr: model.ErrorCommentsDisabled,
+		},
+		{
+			name:    "Case #4 - Failed (Photo hidden by moderation)",
+			photoId: "p4",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p4").Return(model.Photo{ID: "p4", UserID: "2", Hidden: true}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+		{
+			name:    "Case #5 - Failed (Blocked by the photo owner)",
+			photoId: "p5",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p5").Return(model.Photo{ID: "p5", UserID: "2"}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{"2"}, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+		{
+			name:    "Case #6 - Failed (Blocked on a draft)",
+			photoId: "p6",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p6").Return(model.Photo{ID: "p6", UserID: "2", Status: model.PhotoStatusDraft}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{"2"}, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			_, err := cs.Add(model.CommentCreateRequest{Message: "Nice"}, "1", tt.photoId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CommentService.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommentService_DeleteById(t *testing.T) {
+	commentRepository := mocks.NewICommentRepository(t)
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	cs := &CommentService{
+		CommentRepository: commentRepository,
+		PhotoRepository:   photoRepository,
+	}
+	comment := model.Comment{ID: "c1", UserID: "1", PhotoID: "p1"}
+
+	tests := []struct {
+		name     string
+		userId   string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:   "Case #1 - Success (Author)",
+			userId: "1",
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				commentRepository.On("Delete", "c1").Return(nil).Once()
+			},
+		},
+		{
+			name:   "Case #2 - Success (Photo owner)",
+			userId: "2",
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				photoRepository.On("GetOne", "p1").Return(model.Photo{ID: "p1", UserID: "2"}, nil).Once()
+				commentRepository.On("Delete", "c1").Return(nil).Once()
+			},
+		},
+		{
+			name:   "Case #3 - Failed (Other user)",
+			userId: "3",
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				photoRepository.On("GetOne", "p1").Return(model.Photo{ID: "p1", UserID: "2"}, nil).Once()
+			},
+			wantErr: model.ErrorForbiddenAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			err := cs.DeleteById(tt.userId, "c1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CommentService.DeleteById() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommentService_Pin(t *testing.T) {
+	commentRepository := mocks.NewICommentRepository(t)
+	photoRepository := mocks.NewIPhotoRepository(t)
+	reactionRepository := mocks.NewIReactionRepository(t)
+
+	cs := &CommentService{
+		CommentRepository:  commentRepository,
+		PhotoRepository:    photoRepository,
+		ReactionRepository: reactionRepository,
+		PinnedLimit:        3,
+	}
+	comment := model.Comment{ID: "c1", UserID: "1", PhotoID: "p1"}
+	pinnedAt := time.Now()
+
+	tests := []struct {
+		name     string
+		userId   string
+		want     bool
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:   "Case #1 - Success",
+			userId: "2",
+			want:   true,
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				photoRepository.On("GetOne", "p1").Return(model.Photo{ID: "p1", UserID: "2"}, nil).Once()
+				commentRepository.On("Pin", "c1", "p1", 3).Return(nil).Once()
+				commentRepository.On("GetOne", "c1").Return(model.Comment{ID: "c1", UserID: "1", PhotoID: "p1", PinnedAt: &pinnedAt}, nil).Once()
+				reactionRepository.On("CountByCommentIDs", []string{"c1"}).Return([]model.ReactionCount{}, nil).Once()
+			},
+		},
+		{
+			name:   "Case #2 - Failed (Limit reached)",
+			userId: "2",
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				photoRepository.On("GetOne", "p1").Return(model.Photo{ID: "p1", UserID: "2"}, nil).Once()
+				commentRepository.On("Pin", "c1", "p1", 3).Return(model.ErrorPinLimitReached).Once()
+			},
+			wantErr: model.ErrorPinLimitReached,
+		},
+		{
+			name:   "Case #3 - Failed (Not the photo owner)",
+			userId: "1",
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				photoRepository.On("GetOne", "p1").Return(model.Photo{ID: "p1", UserID: "2"}, nil).Once()
+			},
+			wantErr: model.ErrorForbiddenAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := cs.Pin("c1", tt.userId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CommentService.Pin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Pinned != tt.want {
+				t.Errorf("CommentService.Pin() pinned = %v, want %v", got.Pinned, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentService_GetByPhoto(t *testing.T) {
+	// The repository leaves hidden comments of others out for the viewer.
+	comments := []model.Comment{
+		{ID: "c1", UserID: "1", PhotoID: "p1", Message: "first"},
+	}
+
+	tests := []struct {
+		name    string
+		photos  []model.Photo
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "Case #1 - Success (Page and total of the comments the viewer sees)",
+			photos: []model.Photo{{ID: "p1", UserID: "1"}},
+			want:   []string{"c1"},
+		},
+		{
+			name:    "Case #2 - Failed (Photo not visible)",
+			photos:  []model.Photo{{ID: "p1", UserID: "1", Hidden: true}},
+			wantErr: model.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentRepository := mocks.NewICommentRepository(t)
+			photoRepository := mocks.NewIPhotoRepository(t)
+			userRepository := mocks.NewIUserRepository(t)
+			blockRepository := mocks.NewIBlockRepository(t)
+			reactionRepository := mocks.NewIReactionRepository(t)
+
+			cs := &CommentService{
+				CommentRepository:  commentRepository,
+				ReactionRepository: reactionRepository,
+				VisibilityPolicy:   NewVisibilityPolicy(userRepository, nil, photoRepository, blockRepository, nil),
+			}
+
+			photoRepository.On("GetByIDs", []string{"p1"}).Return(tt.photos, nil)
+			blockRepository.On("GetBlockedIDs", "2", mock.Anything).Return([]string{}, nil).Maybe()
+			userRepository.On("GetByIDs", mock.Anything).Return([]model.User{{ID: "1"}, {ID: "3"}}, nil).Maybe()
+			if tt.wantErr == nil {
+				commentRepository.On("GetByPhotoID", "p1", "2", model.PaginationRequest{Page: 1, Limit: 10}).Return(comments, int64(1), nil).Once()
+				reactionRepository.On("CountByCommentIDs", []string{"c1"}).Return([]model.ReactionCount{}, nil).Once()
+			}
+
+			got, err := cs.GetByPhoto(model.PaginationRequest{}, "p1", "2")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CommentService.GetByPhoto() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got.Comments) != len(tt.want) || got.Pagination.Total != int64(len(tt.want)) {
+				t.Fatalf("CommentService.GetByPhoto() = %d comments of %d, want %d", len(got.Comments), got.Pagination.Total, len(tt.want))
+			}
+			for i, comment := range got.Comments {
+				if comment.ID != tt.want[i] {
+					t.Errorf("CommentService.GetByPhoto()[%d] = %s, want %s", i, comment.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"mygram/helper"
+	"mygram/model"
+	"mygram/repository"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ContentFilter inspects text before it is saved and decides whether it is
+// allowed, held for moderator review or rejected.
+type ContentFilter interface {
+	Check(content model.FilterContent) (model.FilterResult, error)
+}
+
+// FilterPipeline runs filters in order. The first rejection wins, otherwise
+// the content is held when any filter holds it. Held content is saved hidden
+// and queued for moderators as an automated report.
+type FilterPipeline struct {
+	Filters          []ContentFilter
+	ReportRepository repository.IReportRepository
+}
+
+func NewFilterPipeline(reportRepository repository.IReportRepository, filters ...ContentFilter) *FilterPipeline {
+	return &FilterPipeline{
+		Filters:          filters,
+		ReportRepository: reportRepository,
+	}
+}
+
+// Check returns the combined result, with the rejecting filter's error when
+// the content is rejected. A nil pipeline allows everything.
+func (fp *FilterPipeline) Check(content model.FilterContent) (model.FilterResult, error) {
+	result := model.FilterResult{Verdict: model.FilterVerdictAllow}
+	if fp == nil {
+		return result, nil
+	}
+
+	for _, filter := range fp.Filters {
+		res, err := filter.Check(content)
+		if err != nil {
+			return model.FilterResult{}, err
+		}
+
+		switch res.Verdict {
+		case model.FilterVerdictReject:
+			if res.Err == nil {
+				res.Err = model.ErrorContentRejected
+			}
+			return res, res.Err
+		case model.FilterVerdictHold:
+			if result.Verdict != model.FilterVerdictHold {
+				result = res
+			}
+		}
+	}
+	return result, nil
+}
+
+// Hold queues held content in the moderation queue. Dismissing the report
+// publishes the content, resolving it with hide keeps it hidden. Content held
+// again after a review reopens its earlier report, as there is one per target.
+func (fp *FilterPipeline) Hold(result model.FilterResult, targetType string, targetId string, ownerId string, excerpt string) error {
+	report := model.Report{
+		ID:           helper.GenerateID(),
+		TargetType:   targetType,
+		TargetID:     targetId,
+		TargetUserID: ownerId,
+		Excerpt:      truncate(strings.TrimSpace(excerpt), 255),
+		Reason:       result.Reason,
+		Note:         result.Note,
+		Status:       model.ReportStatusOpen,
+	}
+
+	_, err := fp.ReportRepository.Save(report)
+	if errors.Is(err, model.ErrorAlreadyReported) {
+		_, err = fp.ReportRepository.Reopen(report)
+	}
+	return err
+}
+
+// WordListFilter matches whole words against a configurable list after
+// undoing common leetspeak, so "sp4m", "s.p.a.m" and "spaaam" all match "spam".
+type WordListFilter struct {
+	Words   map[string]bool
+	Verdict string
+}
+
+func NewWordListFilter(words []string, verdict string) *WordListFilter {
+	wordSet := make(map[string]bool)
+	for _, word := range words {
+		word = normalizeWord(word)
+		if word != "" {
+			wordSet[word] = true
+		}
+	}
+
+	return &WordListFilter{
+		Words:   wordSet,
+		Verdict: verdict,
+	}
+}
+
+var leetspeak = map[rune]rune{
+	'0': 'o',
+	'1': 'i',
+	'3': 'e',
+	'4': 'a',
+	'5': 's',
+	'7': 't',
+	'8': 'b',
+	'9': 'g',
+	'@': 'a',
+	'$': 's',
+	'!': 'i',
+	'|': 'l',
+}
+
+func (wlf *WordListFilter) Check(content model.FilterContent) (model.FilterResult, error) {
+	for _, token := range strings.Fields(content.Text) {
+		word := normalizeWord(token)
+		if wlf.Words[word] || wlf.Words[collapseRepeats(word)] {
+			return model.FilterResult{
+				Verdict: wlf.Verdict,
+				Err:     model.ErrorBlockedWords,
+				Reason:  model.ReportReasonOther,
+				Note:    "Held by word list filter",
+			}, nil
+		}
+	}
+	return model.FilterResult{Verdict: model.FilterVerdictAllow}, nil
+}
+
+// normalizeWord lowercases, maps leetspeak characters to letters and drops
+// everything else that is not a letter.
+func normalizeWord(word string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if mapped, ok := leetspeak[r]; ok {
+			r = mapped
+		}
+		if unicode.IsLetter(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+func collapseRepeats(word string) string {
+	var builder strings.Builder
+	var last rune
+	for i, r := range word {
+		if i > 0 && r == last {
+			continue
+		}
+		builder.WriteRune(r)
+		last = r
+	}
+	return builder.String()
+}
+
+// LinkLimitFilter holds text carrying more links than MaxLinks for review.
+type LinkLimitFilter struct {
+	MaxLinks int
+}
+
+func NewLinkLimitFilter(maxLinks int) *LinkLimitFilter {
+	return &LinkLimitFilter{
+		MaxLinks: maxLinks,
+	}
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+func (llf *LinkLimitFilter) Check(content model.FilterContent) (model.FilterResult, error) {
+	links := len(linkPattern.FindAllString(content.Text, -1))
+	if links > llf.MaxLinks {
+		return model.FilterResult{
+			Verdict: model.FilterVerdictHold,
+			Reason:  model.ReportReasonSpam,
+			Note:    fmt.Sprintf("Held by link filter, %d links", links),
+		}, nil
+	}
+	return model.FilterResult{Verdict: model.FilterVerdictAllow}, nil
+}
+
+// DuplicateCommentFilter rejects a comment repeating one the same user posted
+// within Window, ignoring case and spacing. An edited comment is not compared
+// with itself.
+type DuplicateCommentFilter struct {
+	CommentRepository repository.ICommentRepository
+	Window            time.Duration
+}
+
+func NewDuplicateCommentFilter(commentRepository repository.ICommentRepository, window time.Duration) *DuplicateCommentFilter {
+	return &DuplicateCommentFilter{
+		CommentRepository: commentRepository,
+		Window:            window,
+	}
+}
+
+func (dcf *DuplicateCommentFilter) Check(content model.FilterContent) (model.FilterResult, error) {
+	if content.Kind != model.FilterContentComment {
+		return model.FilterResult{Verdict: model.FilterVerdictAllow}, nil
+	}
+
+	recent, err := dcf.CommentRepository.GetRecentByUserID(content.UserID, time.Now().Add(-dcf.Window))
+	if err != nil {
+		return model.FilterResult{}, err
+	}
+
+	message := normalizeMessage(content.Text)
+	for _, comment := range recent {
+		if (content.ID == "" || comment.ID != content.ID) && normalizeMessage(comment.Message) == message {
+			return model.FilterResult{
+				Verdict: model.FilterVerdictReject,
+				Err:     model.ErrorDuplicateMessage,
+			}, nil
+		}
+	}
+	return model.FilterResult{Verdict: model.FilterVerdictAllow}, nil
+}
+
+func normalizeMessage(message string) string {
+	return strings.Join(strings.Fields(strings.ToLower(message)), " ")
+}
+
+// CommentRateFilter rejects a comment when the user already posted MaxComments
+// within Window. Edits are not new posts and always pass.
+type CommentRateFilter struct {
+	CommentRepository repository.ICommentRepository
+	MaxComments       int
+	Window            time.Duration
+}
+
+func NewCommentRateFilter(commentRepository repository.ICommentRepository, maxComments int, window time.Duration) *CommentRateFilter {
+	return &CommentRateFilter{
+		CommentRepository: commentRepository,
+		MaxComments:       maxComments,
+		Window:            window,
+	}
+}
+
+func (crf *CommentRateFilter) Check(content model.FilterContent) (model.FilterResult, error) {
+	if content.Kind != model.FilterContentComment || content.ID != "" {
+		return model.FilterResult{Verdict: model.FilterVerdictAllow}, nil
+	}
+
+	recent, err := crf.CommentRepository.GetRecentByUserID(content.UserID, time.Now().Add(-crf.Window))
+	if err != nil {
+		return model.FilterResult{}, err
+	}
+
+	if len(recent) >= crf.MaxComments {
+		return model.FilterResult{
+			Verdict: model.FilterVerdictReject,
+			Err:     model.ErrorPostingTooFast,
+		}, nil
+	}
+	return model.FilterResult{Verdict: model.FilterVerdictAllow}, nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFilterPipeline_Check(t *testing.T) {
+	commentRepository := mocks.NewICommentRepository(t)
+
+	wordList := NewWordListFilter([]string{"spam"}, model.FilterVerdictReject)
+	linkLimit := NewLinkLimitFilter(1)
+	duplicate := NewDuplicateCommentFilter(commentRepository, 0)
+	rate := NewCommentRateFilter(commentRepository, 2, 0)
+
+	tests := []struct {
+		name     string
+		fp       *FilterPipeline
+		content  model.FilterContent
+		want     string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:     "Case #1 - Allow (Nil pipeline)",
+			fp:       nil,
+			content:  model.FilterContent{UserID: "1", Kind: model.FilterContentComment, Text: "spam"},
+			want:     model.FilterVerdictAllow,
+			mockFunc: func() {},
+		},
+		{
+			name:     "Case #2 - Reject (Leetspeak blocked word)",
+			fp:       NewFilterPipeline(nil, wordList),
+			content:  model.FilterContent{UserID: "1", Kind: model.FilterContentCaption, Text: "buy S.P.4.A.A.M now"},
+			want:     model.FilterVerdictReject,
+			mockFunc: func() {},
+			wantErr:  model.ErrorBlockedWords,
+		},
+		{
+			name:     "Case #3 - Allow (Blocked word inside another word)",
+			fp:       NewFilterPipeline(nil, wordList),
+			content:  model.FilterContent{UserID: "1", Kind: model.FilterContentCaption, Text: "spamalot tickets"},
+			want:     model.FilterVerdictAllow,
+			mockFunc: func() {},
+		},
+		{
+			name:     "Case #4 - Hold (Too many links)",
+			fp:       NewFilterPipeline(nil, wordList, linkLimit),
+			content:  model.FilterContent{UserID: "1", Kind: model.FilterContentCaption, Text: "see https://a.io and www.b.io"},
+			want:     model.FilterVerdictHold,
+			mockFunc: func() {},
+		},
+		{
+			name:    "Case #5 - Reject (Duplicate comment)",
+			fp:      NewFilterPipeline(nil, duplicate),
+			content: model.FilterContent{UserID: "1", Kind: model.FilterContentComment, Text: "Nice  Photo"},
+			want:    model.FilterVerdictReject,
+			mockFunc: func() {
+				commentRepository.On("GetRecentByUserID", "1", mock.Anything).Return([]model.Comment{{Message: "nice photo"}}, nil).Once()
+			},
+			wantErr: model.ErrorDuplicateMessage,
+		},
+		{
+			name:    "Case #6 - Reject (Posting too fast wins over hold)",
+			fp:      NewFilterPipeline(nil, linkLimit, rate),
+			content: model.FilterContent{UserID: "1", Kind: model.FilterContentComment, Text: "https://a.io https://b.io"},
+			want:    model.FilterVerdictReject,
+			mockFunc: func() {
+				commentRepository.On("GetRecentByUserID", "1", mock.Anything).Return([]model.Comment{{ID: "1"}, {ID: "2"}}, nil).Once()
+			},
+			wantErr: model.ErrorPostingTooFast,
+		},
+		{
+			name:    "Case #7 - Allow (Edited comment is not a duplicate of itself)",
+			fp:      NewFilterPipeline(nil, duplicate),
+			content: model.FilterContent{ID: "c1", UserID: "1", Kind: model.FilterContentComment, Text: "Nice photo"},
+			want:    model.FilterVerdictAllow,
+			mockFunc: func() {
+				commentRepository.On("GetRecentByUserID", "1", mock.Anything).Return([]model.Comment{{ID: "c1", Message: "nice photo"}}, nil).Once()
+			},
+		},
+		{
+			name:     "Case #8 - Allow (Edits skip the rate limit)",
+			fp:       NewFilterPipeline(nil, rate),
+			content:  model.FilterContent{ID: "c1", UserID: "1", Kind: model.FilterContentComment, Text: "Nice photo"},
+			want:     model.FilterVerdictAllow,
+			mockFunc: func() {},
+		},
+		{
+			name:     "Case #9 - Allow (Comment filters skip captions)",
+			fp:       NewFilterPipeline(nil, duplicate, rate),
+			content:  model.FilterContent{UserID: "1", Kind: model.FilterContentCaption, Text: "nice photo"},
+			want:     model.FilterVerdictAllow,
+			mockFunc: func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.fp.Check(tt.content)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FilterPipeline.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Verdict != tt.want {
+				t.Errorf("FilterPipeline.Check() verdict = %v, want %v", got.Verdict, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterPipeline_Hold(t *testing.T) {
+	reportRepository := mocks.NewIReportRepository(t)
+	commentRepository := mocks.NewICommentRepository(t)
+	moderationActionRepository := mocks.NewIModerationActionRepository(t)
+
+	fp := NewFilterPipeline(reportRepository)
+	ms := &ModerationService{
+		ReportRepository:           reportRepository,
+		ModerationActionRepository: moderationActionRepository,
+		CommentRepository:          commentRepository,
+	}
+	result := model.FilterResult{Verdict: model.FilterVerdictHold, Reason: model.ReportReasonSpam}
+	held := model.Report{ID: "r1", TargetType: model.ReportTargetComment, TargetID: "c1", TargetUserID: "1", Status: model.ReportStatusOpen}
+
+	// Held for the first time
+	reportRepository.On("Save", mock.Anything).Return(held, nil).Once()
+	if err := fp.Hold(result, model.ReportTargetComment, "c1", "1", "https://a.io"); err != nil {
+		t.Fatalf("FilterPipeline.Hold() error = %v", err)
+	}
+
+	// Dismissed by a moderator
+	reportRepository.On("GetOne", "r1").Return(held, nil).Once()
+	commentRepository.On("UpdateHidden", "c1", false).Return(nil).Once()
+	moderationActionRepository.On("Save", mock.Anything).Return(model.ModerationAction{}, nil).Once()
+	reportRepository.On("CloseByTarget", model.ReportTargetComment, "c1", model.ReportStatusDismissed, "m1").Return(nil).Once()
+	if _, err := ms.Dismiss(model.ReportDismissRequest{}, "r1", "m1"); err != nil {
+		t.Fatalf("ModerationService.Dismiss() error = %v", err)
+	}
+
+	// Held again after an edit, the dismissed report is reopened
+	reportRepository.On("Save", mock.Anything).Return(model.Report{}, model.ErrorAlreadyReported).Once()
+	reportRepository.
+		On("Reopen", mock.MatchedBy(func(report model.Report) bool {
+			return report.ReporterID == "" && report.TargetID == "c1" && report.Excerpt == "https://b.io" && report.Status == model.ReportStatusOpen
+		})).
+		Return(held, nil).Once()
+	if err := fp.Hold(result, model.ReportTargetComment, "c1", "1", "https://b.io"); err != nil {
+		t.Fatalf("FilterPipeline.Hold() error = %v", err)
+	}
+
+	// A report that cannot be reopened is not swallowed
+	reportRepository.On("Save", mock.Anything).Return(model.Report{}, model.ErrorAlreadyReported).Once()
+	reportRepository.On("Reopen", mock.Anything).Return(model.Report{}, model.ErrorNotFound).Once()
+	if err := fp.Hold(result, model.ReportTargetComment, "c1", "1", "https://c.io"); !errors.Is(err, model.ErrorNotFound) {
+		t.Errorf("FilterPipeline.Hold() error = %v, want %v", err, model.ErrorNotFound)
+	}
+}
+
+func TestCommentService_Add_Held(t *testing.T) {
+	commentRepository := mocks.NewICommentRepository(t)
+	photoRepository := mocks.NewIPhotoRepository(t)
+	reportRepository := mocks.NewIReportRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(userRepository, nil, photoRepository, blockRepository, nil)
+
+	cs := NewCommentService(commentRepository, photoRepository, userRepository, nil, visibilityPolicy, NewFilterPipeline(reportRepository, NewLinkLimitFilter(0)), 0, 3)
+
+	photoRepository.On("GetOne", "p1").Return(model.Photo{ID: "p1", UserID: "2"}, nil).Once()
+	blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil)
+	userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+	commentRepository.
+		On("Save", mock.MatchedBy(func(comment model.Comment) bool { return comment.Hidden })).
+		Return(func(comment model.Comment) (model.Comment, error) { return comment, nil }).Once()
+	reportRepository.
+		On("Save", mock.MatchedBy(func(report model.Report) bool {
+			return report.ReporterID == "" && report.TargetType == model.ReportTargetComment && report.Reason == model.ReportReasonSpam
+		})).
+		Return(model.Report{}, nil).Once()
+
+	got, err := cs.Add(model.CommentCreateRequest{Message: "https://a.io"}, "1", "p1")
+	if err != nil {
+		t.Fatalf("CommentService.Add() error = %v", err)
+	}
+	if !got.PendingReview {
+		t.Errorf("CommentService.Add() pending_review = %v, want true", got.PendingReview)
+	}
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"errors"
+	"mygram/helper"
+	"mygram/model"
+	"mygram/repository"
+	"strings"
+	"time"
+)
+
+type ConversationService struct {
+	ConversationRepository repository.IConversationRepository
+	UserRepository         repository.IUserRepository
+	PhotoRepository        repository.IPhotoRepository
+	VisibilityPolicy       *VisibilityPolicy
+}
+
+func NewConversationService(conversationRepository repository.IConversationRepository, userRepository repository.IUserRepository, photoRepository repository.IPhotoRepository, visibilityPolicy *VisibilityPolicy) *ConversationService {
+	return &ConversationService{
+		ConversationRepository: conversationRepository,
+		UserRepository:         userRepository,
+		PhotoRepository:        photoRepository,
+		VisibilityPolicy:       visibilityPolicy,
+	}
+}
+
+// Create starts a conversation of userId with the given usernames. A single
+// username reopens the existing one-to-one conversation with that user, if
+// there is one. Users blocking or blocked by userId cannot be added.
+func (cs *ConversationService) Create(request model.ConversationCreateRequest, userId string) (model.ConversationResponse, error) {
+	otherIds := make([]string, 0, len(request.Usernames))
+	seen := make(map[string]bool)
+	for _, username := range request.Usernames {
+		user, err := cs.UserRepository.GetByUsername(strings.TrimSpace(username))
+		if err != nil {
+			return model.ConversationResponse{}, err
+		}
+		if user.ID == userId {
+			return model.ConversationResponse{}, model.ErrorCannotMessageSelf
+		}
+		if seen[user.ID] {
+			continue
+		}
+		seen[user.ID] = true
+		otherIds = append(otherIds, user.ID)
+	}
+	if len(otherIds) == 0 || len(otherIds) >= model.MaxConversationParticipants {
+		return model.ConversationResponse{}, model.ErrorInvalidParticipants
+	}
+
+	blocked, err := cs.VisibilityPolicy.BlockedUsers(userId, otherIds)
+	if err != nil {
+		return model.ConversationResponse{}, err
+	}
+	if len(blocked) > 0 {
+		return model.ConversationResponse{}, model.ErrorBlocked
+	}
+
+	conversation := model.Conversation{
+		ID:      helper.GenerateID(),
+		IsGroup: len(otherIds) > 1,
+		Participants: []model.ConversationParticipant{
+			{UserID: userId},
+		},
+	}
+	if conversation.IsGroup {
+		conversation.Title = strings.TrimSpace(request.Title)
+	} else {
+		directKey := model.DirectConversationKey(userId, otherIds[0])
+		conversation.DirectKey = &directKey
+	}
+	for _, otherId := range otherIds {
+		conversation.Participants = append(conversation.Participants, model.ConversationParticipant{
+			UserID: otherId,
+		})
+	}
+	for i := range conversation.Participants {
+		conversation.Participants[i].ConversationID = conversation.ID
+	}
+
+	res, err := cs.ConversationRepository.Save(conversation)
+	if err != nil {
+		return model.ConversationResponse{}, err
+	}
+
+	responses, err := cs.toConversationResponses(userId, []model.Conversation{res})
+	if err != nil {
+		return model.ConversationResponse{}, err
+	}
+	return responses[0], nil
+}
+
+// List returns the conversations of userId, the latest active first. One-to-one
+// conversations with a user blocking or blocked by userId are left out.
+func (cs *ConversationService) List(request model.PaginationRequest, userId string) (model.ConversationListResponse, error) {
+	pagination := request.Normalize()
+
+	res, total, err := cs.ConversationRepository.GetByUserID(userId, pagination)
+	if err != nil {
+		return model.ConversationListResponse{}, err
+	}
+
+	responses, err := cs.toConversationResponses(userId, res)
+	if err != nil {
+		return model.ConversationListResponse{}, err
+	}
+	return model.ConversationListResponse{
+		Conversations: responses,
+		Pagination:    model.ToPaginationResponse(pagination, total),
+	}, nil
+}
+
+// Messages lists the messages of a conversation userId takes part in, newest
+// first. Messages of blocked users are left out, as are shared photos the
+// viewer may not see.
+func (cs *ConversationService) Messages(request model.PaginationRequest, conversationId string, userId string) (model.MessageListResponse, error) {
+	pagination := request.Normalize()
+
+	conversation, err := cs.getForParticipant(conversationId, userId)
+	if err != nil {
+		return model.MessageListResponse{}, err
+	}
+
+	res, total, err := cs.ConversationRepository.GetMessages(conversation.ID, pagination)
+	if err != nil {
+		return model.MessageListResponse{}, err
+	}
+
+	messages, err := cs.toMessageResponses(userId, conversation, res)
+	if err != nil {
+		return model.MessageListResponse{}, err
+	}
+	return model.MessageListResponse{
+		ConversationID: conversation.ID,
+		Messages:       messages,
+		Pagination:     model.ToPaginationResponse(pagination, total),
+	}, nil
+}
+
+// Send posts a message from userId. The shared photo, if any, must be one the
+// sender can see. One-to-one messages need a recipient who still has an active
+// account and no block with the sender.
+func (cs *ConversationService) Send(request model.MessageCreateRequest, conversationId string, userId string) (model.MessageResponse, error) {
+	body := strings.TrimSpace(request.Body)
+	if body == "" && request.PhotoID == "" {
+		return model.MessageResponse{}, model.ErrorEmptyMessage
+	}
+	if len([]rune(body)) > model.MaxMessageLength {
+		return model.MessageResponse{}, model.ErrorMessageTooLong
+	}
+
+	conversation, err := cs.getForParticipant(conversationId, userId)
+	if err != nil {
+		return model.MessageResponse{}, err
+	}
+
+	if !conversation.IsGroup {
+		err = cs.checkRecipient(userId, directOtherID(conversation, userId))
+		if err != nil {
+			return model.MessageResponse{}, err
+		}
+	}
+
+	message := model.Message{
+		ID:             helper.GenerateID(),
+		ConversationID: conversation.ID,
+		SenderID:       userId,
+		Body:           body,
+		CreatedAt:      time.Now(),
+	}
+	if request.PhotoID != "" {
+		photo, err := cs.PhotoRepository.GetOne(request.PhotoID)
+		if err != nil {
+			return model.MessageResponse{}, err
+		}
+		visible, err := cs.VisibilityPolicy.VisiblePhotoIDs(userId, []string{photo.ID})
+		if err != nil {
+			return model.MessageResponse{}, err
+		}
+		if !visible[photo.ID] {
+			return model.MessageResponse{}, model.ErrorNotFound
+		}
+		message.PhotoID = &photo.ID
+		message.Photo = &photo
+	}
+
+	res, err := cs.ConversationRepository.SaveMessage(message)
+	if err != nil {
+		return model.MessageResponse{}, err
+	}
+
+	responses, err := cs.toMessageResponses(userId, conversation, []model.Message{res})
+	if err != nil {
+		return model.MessageResponse{}, err
+	}
+	return responses[0], nil
+}
+
+// MarkRead moves the read receipt of userId to now.
+func (cs *ConversationService) MarkRead(conversationId string, userId string) error {
+	conversation, err := cs.getForParticipant(conversationId, userId)
+	if err != nil {
+		return err
+	}
+	return cs.ConversationRepository.MarkRead(conversation.ID, userId, time.Now())
+}
+
+// getForParticipant returns the conversation if userId takes part in it. Others
+// get ErrorNotFound, as do participants of a one-to-one conversation with a
+// user blocking or blocked by them.
+func (cs *ConversationService) getForParticipant(conversationId string, userId string) (model.Conversation, error) {
+	conversation, err := cs.ConversationRepository.GetOne(conversationId)
+	if err != nil {
+		return model.Conversation{}, err
+	}
+	if !isParticipant(conversation, userId) {
+		return model.Conversation{}, model.ErrorNotFound
+	}
+
+	if !conversation.IsGroup {
+		canInteract, err := cs.VisibilityPolicy.CanInteract(userId, directOtherID(conversation, userId))
+		if err != nil {
+			return model.Conversation{}, err
+		}
+		if !canInteract {
+			return model.Conversation{}, model.ErrorNotFound
+		}
+	}
+	return conversation, nil
+}
+
+// checkRecipient rejects one-to-one messages to a user whose account is gone
+// or suspended.
+func (cs *ConversationService) checkRecipient(userId string, recipientId string) error {
+	recipient, err := cs.UserRepository.GetOne(recipientId)
+	if errors.Is(err, model.ErrorNotFound) {
+		return model.ErrorRecipientUnavailable
+	}
+	if err != nil {
+		return err
+	}
+	if recipient.IsSuspended(time.Now()) {
+		return model.ErrorRecipientUnavailable
+	}
+	return nil
+}
+
+func (cs *ConversationService) toConversationResponses(userId string, conversations []model.Conversation) ([]model.ConversationResponse, error) {
+	responses := make([]model.ConversationResponse, 0, len(conversations))
+	if len(conversations) == 0 {
+		return responses, nil
+	}
+
+	conversationIds := make([]string, 0, len(conversations))
+	userIds := make([]string, 0)
+	for _, conversation := range conversations {
+		conversationIds = append(conversationIds, conversation.ID)
+		for _, participant := range conversation.Participants {
+			userIds = append(userIds, participant.UserID)
+		}
+	}
+
+	usernames, err := cs.usernames(userIds)
+	if err != nil {
+		return []model.ConversationResponse{}, err
+	}
+	unread, err := cs.ConversationRepository.GetUnreadCounts(userId, conversationIds)
+	if err != nil {
+		return []model.ConversationResponse{}, err
+	}
+	unreadCounts := make(map[string]int64)
+	for _, count := range unread {
+		unreadCounts[count.ConversationID] = count.Count
+	}
+	lastMessages, err := cs.ConversationRepository.GetLastMessages(conversationIds)
+	if err != nil {
+		return []model.ConversationResponse{}, err
+	}
+	lastMessageOf := make(map[string]model.Message)
+	for _, message := range lastMessages {
+		lastMessageOf[message.ConversationID] = message
+	}
+
+	for _, conversation := range conversations {
+		response := model.ConversationResponse{
+			ID:           conversation.ID,
+			IsGroup:      conversation.IsGroup,
+			Title:        conversation.Title,
+			Participants: toParticipantResponses(conversation, usernames),
+			UnreadCount:  unreadCounts[conversation.ID],
+			CreatedAt:    conversation.CreatedAt,
+			UpdatedAt:    conversation.UpdatedAt,
+		}
+		if message, ok := lastMessageOf[conversation.ID]; ok {
+			messages, err := cs.toMessageResponses(userId, conversation, []model.Message{message})
+			if err != nil {
+				return []model.ConversationResponse{}, err
+			}
+			if len(messages) > 0 {
+				response.LastMessage = &messages[0]
+			}
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func (cs *ConversationService) toMessageResponses(userId string, conversation model.Conversation, messages []model.Message) ([]model.MessageResponse, error) {
+	senderIds := make([]string, 0, len(messages))
+	photoIds := make([]string, 0)
+	for _, message := range messages {
+		senderIds = append(senderIds, message.SenderID)
+		if message.PhotoID != nil {
+			photoIds = append(photoIds, *message.PhotoID)
+		}
+	}
+
+	blocked, err := cs.VisibilityPolicy.BlockedUsers(userId, senderIds)
+	if err != nil {
+		return []model.MessageResponse{}, err
+	}
+	visiblePhotos := make(map[string]bool)
+	if len(photoIds) > 0 {
+		visiblePhotos, err = cs.VisibilityPolicy.VisiblePhotoIDs(userId, photoIds)
+		if err != nil {
+			return []model.MessageResponse{}, err
+		}
+	}
+
+	responses := make([]model.MessageResponse, 0, len(messages))
+	for _, message := range messages {
+		if blocked[message.SenderID] {
+			continue
+		}
+		response := model.MessageResponse{
+			ID:             message.ID,
+			ConversationID: message.ConversationID,
+			SenderID:       message.SenderID,
+			Body:           message.Body,
+			ReadBy:         readBy(conversation, message),
+			CreatedAt:      message.CreatedAt,
+		}
+		if message.Photo != nil && visiblePhotos[message.Photo.ID] {
+			response.Photo = &model.MessagePhotoResponse{
+				ID:       message.Photo.ID,
+				Title:    message.Photo.Title,
+				PhotoURL: message.Photo.PhotoURL,
+			}
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// usernames maps user ids to usernames. Users missing from the result no
+// longer have an account.
+func (cs *ConversationService) usernames(userIds []string) (map[string]string, error) {
+	usernames := make(map[string]string)
+
+	users, err := cs.UserRepository.GetByIDs(uniqueIDs(userIds))
+	if err != nil {
+		return usernames, err
+	}
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+	return usernames, nil
+}
+
+func toParticipantResponses(conversation model.Conversation, usernames map[string]string) []model.ConversationParticipantResponse {
+	participants := make([]model.ConversationParticipantResponse, 0, len(conversation.Participants))
+	for _, participant := range conversation.Participants {
+		username, ok := usernames[participant.UserID]
+		participants = append(participants, model.ConversationParticipantResponse{
+			UserID:     participant.UserID,
+			Username:   username,
+			Deleted:    !ok,
+			LastReadAt: participant.LastReadAt,
+		})
+	}
+	return participants
+}
+
+// readBy lists the participants other than the sender whose read receipt
+// covers the message.
+func readBy(conversation model.Conversation, message model.Message) []string {
+	readers := make([]string, 0)
+	for _, participant := range conversation.Participants {
+		if participant.UserID == message.SenderID || participant.LastReadAt == nil {
+			continue
+		}
+		if !participant.LastReadAt.Before(message.CreatedAt) {
+			readers = append(readers, participant.UserID)
+		}
+	}
+	return readers
+}
+
+func isParticipant(conversation model.Conversation, userId string) bool {
+	for _, participant := range conversation.Participants {
+		if participant.UserID == userId {
+			return true
+		}
+	}
+	return false
+}
+
+// directOtherID is the participant of a one-to-one conversation other than
+// userId.
+func directOtherID(conversation model.Conversation, userId string) string {
+	for _, participant := range conversation.Participants {
+		if participant.UserID != userId {
+			return participant.UserID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestConversationService_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		usernames []string
+		blocked   []string
+		wantErr   error
+		wantGroup bool
+	}{
+		{name: "Direct", usernames: []string{"bob"}},
+		{name: "Group", usernames: []string{"bob", "carol", "bob"}, wantGroup: true},
+		{name: "Self", usernames: []string{"alice"}, wantErr: model.ErrorCannotMessageSelf},
+		{name: "No participants", usernames: []string{}, wantErr: model.ErrorInvalidParticipants},
+		{name: "Blocked", usernames: []string{"bob", "carol"}, blocked: []string{"3"}, wantErr: model.ErrorBlocked},
+	}
+	users := map[string]model.User{
+		"alice": {ID: "1", Username: "alice"},
+		"bob":   {ID: "2", Username: "bob"},
+		"carol": {ID: "3", Username: "carol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversationRepository := mocks.NewIConversationRepository(t)
+			userRepository := mocks.NewIUserRepository(t)
+			blockRepository := mocks.NewIBlockRepository(t)
+
+			cs := &ConversationService{
+				ConversationRepository: conversationRepository,
+				UserRepository:         userRepository,
+				VisibilityPolicy:       NewVisibilityPolicy(userRepository, nil, nil, blockRepository, nil),
+			}
+
+			for _, username := range tt.usernames {
+				userRepository.On("GetByUsername", username).Return(users[username], nil).Maybe()
+			}
+			blockRepository.On("GetBlockedIDs", "1", mock.Anything).Return(tt.blocked, nil).Maybe()
+
+			var saved model.Conversation
+			if tt.wantErr == nil {
+				conversationRepository.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+					saved = args.Get(0).(model.Conversation)
+				}).Return(func(conversation model.Conversation) model.Conversation {
+					return conversation
+				}, nil).Once()
+				userRepository.On("GetByIDs", mock.Anything).Return([]model.User{users["alice"], users["bob"], users["carol"]}, nil).Once()
+				conversationRepository.On("GetUnreadCounts", "1", mock.Anything).Return([]model.UnreadCount{}, nil).Once()
+				conversationRepository.On("GetLastMessages", mock.Anything).Return([]model.Message{}, nil).Once()
+			}
+
+			got, err := cs.Create(model.ConversationCreateRequest{Usernames: tt.usernames}, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConversationService.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.IsGroup != tt.wantGroup {
+				t.Errorf("ConversationService.Create() IsGroup = %v, want %v", got.IsGroup, tt.wantGroup)
+			}
+			if tt.wantGroup == (saved.DirectKey != nil) {
+				t.Errorf("ConversationService.Create() DirectKey = %v, want only for one-to-one", saved.DirectKey)
+			}
+			if len(saved.Participants) != len(got.Participants) || len(got.Participants) != 1+len(uniqueIDs(tt.usernames)) {
+				t.Errorf("ConversationService.Create() participants = %+v", got.Participants)
+			}
+		})
+	}
+}
+
+func TestConversationService_Send(t *testing.T) {
+	direct := model.Conversation{
+		ID:           "c1",
+		Participants: []model.ConversationParticipant{{ConversationID: "c1", UserID: "1"}, {ConversationID: "c1", UserID: "2"}},
+	}
+
+	tests := []struct {
+		name      string
+		request   model.MessageCreateRequest
+		recipient error
+		visible   bool
+		wantErr   error
+	}{
+		{name: "Body", request: model.MessageCreateRequest{Body: " hi "}},
+		{name: "Shared photo", request: model.MessageCreateRequest{PhotoID: "p1"}, visible: true},
+		{name: "Empty", request: model.MessageCreateRequest{Body: "  "}, wantErr: model.ErrorEmptyMessage},
+		{name: "Too long", request: model.MessageCreateRequest{Body: strings.Repeat("a", model.MaxMessageLength+1)}, wantErr: model.ErrorMessageTooLong},
+		{name: "Photo not visible", request: model.MessageCreateRequest{PhotoID: "p1"}, wantErr: model.ErrorNotFound},
+		{name: "Recipient deleted", request: model.MessageCreateRequest{Body: "hi"}, recipient: model.ErrorNotFound, wantErr: model.ErrorRecipientUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversationRepository := mocks.NewIConversationRepository(t)
+			userRepository := mocks.NewIUserRepository(t)
+			photoRepository := mocks.NewIPhotoRepository(t)
+			blockRepository := mocks.NewIBlockRepository(t)
+
+			cs := &ConversationService{
+				ConversationRepository: conversationRepository,
+				UserRepository:         userRepository,
+				PhotoRepository:        photoRepository,
+				VisibilityPolicy:       NewVisibilityPolicy(userRepository, nil, photoRepository, blockRepository, nil),
+			}
+
+			photo := model.Photo{ID: "p1", UserID: "3", Title: "shared"}
+			if tt.visible {
+				photo.UserID = "2"
+			}
+			conversationRepository.On("GetOne", "c1").Return(direct, nil).Maybe()
+			blockRepository.On("GetBlockedIDs", "1", mock.Anything).Return([]string{}, nil).Maybe()
+			userRepository.On("GetOne", "2").Return(model.User{ID: "2"}, tt.recipient).Maybe()
+			photoRepository.On("GetOne", "p1").Return(photo, nil).Maybe()
+			photoRepository.On("GetByIDs", []string{"p1"}).Return([]model.Photo{photo}, nil).Maybe()
+			userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Maybe()
+			userRepository.On("GetByIDs", []string{"3"}).Return([]model.User{}, nil).Maybe()
+			if tt.wantErr == nil {
+				conversationRepository.On("SaveMessage", mock.Anything).Return(func(message model.Message) model.Message {
+					return message
+				}, nil).Once()
+			}
+
+			got, err := cs.Send(tt.request, "c1", "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConversationService.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.Body != strings.TrimSpace(tt.request.Body) {
+				t.Errorf("ConversationService.Send() Body = %q", got.Body)
+			}
+			if (got.Photo != nil) != (tt.request.PhotoID != "") {
+				t.Errorf("ConversationService.Send() Photo = %+v, want photo %q", got.Photo, tt.request.PhotoID)
+			}
+		})
+	}
+}
+
+func TestConversationService_List(t *testing.T) {
+	conversationRepository := mocks.NewIConversationRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+
+	cs := &ConversationService{
+		ConversationRepository: conversationRepository,
+		UserRepository:         userRepository,
+		VisibilityPolicy:       NewVisibilityPolicy(userRepository, nil, nil, blockRepository, nil),
+	}
+
+	now := time.Now()
+	read := now.Add(-time.Minute)
+	// The repository leaves out the one-to-one conversation with blocked 3.
+	conversations := []model.Conversation{
+		{ID: "c1", Participants: []model.ConversationParticipant{{UserID: "1"}, {UserID: "2", LastReadAt: &now}}},
+		{ID: "c3", IsGroup: true, Participants: []model.ConversationParticipant{{UserID: "1"}, {UserID: "3"}, {UserID: "4", LastReadAt: &read}}},
+	}
+	messages := []model.Message{
+		{ID: "m1", ConversationID: "c1", SenderID: "1", Body: "read", CreatedAt: now.Add(-time.Second)},
+		{ID: "m3", ConversationID: "c3", SenderID: "3", Body: "blocked", CreatedAt: now},
+	}
+
+	conversationRepository.On("GetByUserID", "1", mock.Anything).Return(conversations, int64(2), nil).Once()
+	userRepository.On("GetByIDs", []string{"1", "2", "3", "4"}).Return([]model.User{{ID: "1"}, {ID: "2"}, {ID: "3"}}, nil).Once()
+	conversationRepository.On("GetUnreadCounts", "1", []string{"c1", "c3"}).Return([]model.UnreadCount{{ConversationID: "c3", Count: 2}}, nil).Once()
+	conversationRepository.On("GetLastMessages", []string{"c1", "c3"}).Return(messages, nil).Once()
+	blockRepository.On("GetBlockedIDs", "1", []string{"3"}).Return([]string{"3"}, nil).Once()
+
+	got, err := cs.List(model.PaginationRequest{}, "1")
+	if err != nil {
+		t.Fatalf("ConversationService.List() error = %v", err)
+	}
+
+	if len(got.Conversations) != 2 || got.Conversations[0].ID != "c1" || got.Conversations[1].ID != "c3" || got.Pagination.Total != 2 {
+		t.Fatalf("ConversationService.List() = %+v, want c1 and c3", got)
+	}
+	if last := got.Conversations[0].LastMessage; last == nil || len(last.ReadBy) != 1 || last.ReadBy[0] != "2" {
+		t.Errorf("ConversationService.List() last message = %+v, want read by 2", last)
+	}
+	group := got.Conversations[1]
+	if group.LastMessage != nil {
+		t.Errorf("ConversationService.List() last message = %+v, want blocked sender left out", group.LastMessage)
+	}
+	if group.UnreadCount != 2 {
+		t.Errorf("ConversationService.List() UnreadCount = %d, want 2", group.UnreadCount)
+	}
+	if !group.Participants[2].Deleted || group.Participants[1].Deleted {
+		t.Errorf("ConversationService.List() participants = %+v, want 4 deleted", group.Participants)
+	}
+}
+
+func TestConversationService_Messages_NotParticipant(t *testing.T) {
+	conversationRepository := mocks.NewIConversationRepository(t)
+
+	cs := &ConversationService{
+		ConversationRepository: conversationRepository,
+	}
+
+	conversationRepository.On("GetOne", "c1").Return(model.Conversation{
+		ID:           "c1",
+		IsGroup:      true,
+		Participants: []model.ConversationParticipant{{UserID: "2"}, {UserID: "3"}},
+	}, nil).Once()
+
+	_, err := cs.Messages(model.PaginationRequest{}, "c1", "1")
+	if !errors.Is(err, model.ErrorNotFound) {
+		t.Errorf("ConversationService.Messages() error = %v, want %v", err, model.ErrorNotFound)
+	}
+}
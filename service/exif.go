@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exifData holds the EXIF tags mygram keeps. GPS tags are never read, so the
+// location a photo was taken at cannot end up in the database.
+type exifData struct {
+	Make         string
+	Model        string
+	LensModel    string
+	ExposureTime string
+	FNumber      *float64
+	ISO          *int
+	FocalLength  *float64
+	TakenAt      *time.Time
+	Orientation  int
+}
+
+const (
+	exifTagMake             = 0x010F
+	exifTagModel            = 0x0110
+	exifTagOrientation      = 0x0112
+	exifTagDateTime         = 0x0132
+	exifTagExifIFD          = 0x8769
+	exifTagExposureTime     = 0x829A
+	exifTagFNumber          = 0x829D
+	exifTagISO              = 0x8827
+	exifTagDateTimeOriginal = 0x9003
+	exifTagFocalLength      = 0x920A
+	exifTagLensModel        = 0xA434
+)
+
+const exifDateTimeLayout = "2006:01:02 15:04:05"
+
+// exifTypeSizes maps TIFF field types to their size in bytes.
+var exifTypeSizes = map[uint16]uint64{
+	1:  1, // BYTE
+	2:  1, // ASCII
+	3:  2, // SHORT
+	4:  4, // LONG
+	5:  8, // RATIONAL
+	7:  1, // UNDEFINED
+	9:  4, // SLONG
+	10: 8, // SRATIONAL
+}
+
+type exifEntry struct {
+	Type  uint16
+	Count uint32
+	Value []byte
+}
+
+// parseJPEGExif reads the EXIF segment of a JPEG. A JPEG without EXIF, or with
+// an EXIF segment it cannot make sense of, yields empty data rather than an
+// error, metadata is a nice to have.
+func parseJPEGExif(data []byte) exifData {
+	segment := findExifSegment(data)
+	if segment == nil {
+		return exifData{}
+	}
+	return parseTIFF(segment)
+}
+
+// findExifSegment walks the JPEG markers up to the image data and returns the
+// TIFF payload of the first APP1 Exif segment.
+func findExifSegment(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			i++
+			continue
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			i += 2
+			continue
+		case marker == 0xD9 || marker == 0xDA:
+			return nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if length < 2 || i+2+length > len(data) {
+			return nil
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		i += 2 + length
+	}
+	return nil
+}
+
+func parseTIFF(tiff []byte) exifData {
+	result := exifData{}
+	if len(tiff) < 8 {
+		return result
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return result
+	}
+	if order.Uint16(tiff[2:4]) != 42 {
+		return result
+	}
+
+	ifd0 := readIFD(tiff, order, order.Uint32(tiff[4:8]))
+	result.Make = exifString(ifd0[exifTagMake])
+	result.Model = exifString(ifd0[exifTagModel])
+	if orientation, ok := exifShort(ifd0[exifTagOrientation], order); ok {
+		result.Orientation = int(orientation)
+	}
+	result.TakenAt = exifTime(ifd0[exifTagDateTime])
+
+	if offset, ok := exifLong(ifd0[exifTagExifIFD], order); ok {
+		exifIFD := readIFD(tiff, order, offset)
+		result.LensModel = exifString(exifIFD[exifTagLensModel])
+		result.ExposureTime = exifExposure(exifIFD[exifTagExposureTime], order)
+		result.FNumber = exifRational(exifIFD[exifTagFNumber], order)
+		result.FocalLength = exifRational(exifIFD[exifTagFocalLength], order)
+		if iso, ok := exifShort(exifIFD[exifTagISO], order); ok {
+			value := int(iso)
+			result.ISO = &value
+		}
+		if takenAt := exifTime(exifIFD[exifTagDateTimeOriginal]); takenAt != nil {
+			result.TakenAt = takenAt
+		}
+	}
+
+	return result
+}
+
+// readIFD reads the entries of one image file directory, skipping entries that
+// point outside the segment.
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32) map[uint16]exifEntry {
+	entries := make(map[uint16]exifEntry)
+	start := uint64(offset)
+	if start+2 > uint64(len(tiff)) {
+		return entries
+	}
+
+	count := uint64(order.Uint16(tiff[start : start+2]))
+	for i := uint64(0); i < count; i++ {
+		entryStart := start + 2 + i*12
+		if entryStart+12 > uint64(len(tiff)) {
+			break
+		}
+		raw := tiff[entryStart : entryStart+12]
+
+		tag := order.Uint16(raw[0:2])
+		entry := exifEntry{
+			Type:  order.Uint16(raw[2:4]),
+			Count: order.Uint32(raw[4:8]),
+		}
+		typeSize, ok := exifTypeSizes[entry.Type]
+		if !ok {
+			continue
+		}
+
+		size := typeSize * uint64(entry.Count)
+		if size <= 4 {
+			entry.Value = raw[8 : 8+size]
+		} else {
+			valueOffset := uint64(order.Uint32(raw[8:12]))
+			if valueOffset+size > uint64(len(tiff)) {
+				continue
+			}
+			entry.Value = tiff[valueOffset : valueOffset+size]
+		}
+		entries[tag] = entry
+	}
+	return entries
+}
+
+func exifString(entry exifEntry) string {
+	if entry.Type != 2 {
+		return ""
+	}
+	value := string(entry.Value)
+	if end := strings.IndexByte(value, 0); end >= 0 {
+		value = value[:end]
+	}
+	return strings.TrimSpace(strings.ToValidUTF8(value, ""))
+}
+
+func exifShort(entry exifEntry, order binary.ByteOrder) (uint16, bool) {
+	if entry.Type != 3 || len(entry.Value) < 2 {
+		return 0, false
+	}
+	return order.Uint16(entry.Value), true
+}
+
+func exifLong(entry exifEntry, order binary.ByteOrder) (uint32, bool) {
+	if entry.Type != 4 || len(entry.Value) < 4 {
+		return 0, false
+	}
+	return order.Uint32(entry.Value), true
+}
+
+func exifRationalParts(entry exifEntry, order binary.ByteOrder) (uint32, uint32, bool) {
+	if entry.Type != 5 || len(entry.Value) < 8 {
+		return 0, 0, false
+	}
+	numerator, denominator := order.Uint32(entry.Value[0:4]), order.Uint32(entry.Value[4:8])
+	if denominator == 0 {
+		return 0, 0, false
+	}
+	return numerator, denominator, true
+}
+
+func exifRational(entry exifEntry, order binary.ByteOrder) *float64 {
+	numerator, denominator, ok := exifRationalParts(entry, order)
+	if !ok {
+		return nil
+	}
+	value := math.Round(float64(numerator)/float64(denominator)*100) / 100
+	return &value
+}
+
+// exifExposure formats the exposure time the way cameras show it, "1/125"
+// below a second and "2.5" above.
+func exifExposure(entry exifEntry, order binary.ByteOrder) string {
+	numerator, denominator, ok := exifRationalParts(entry, order)
+	if !ok || numerator == 0 {
+		return ""
+	}
+	if numerator < denominator {
+		return "1/" + strconv.Itoa(int(math.Round(float64(denominator)/float64(numerator))))
+	}
+	return strconv.FormatFloat(float64(numerator)/float64(denominator), 'f', -1, 64)
+}
+
+// exifTime parses an EXIF timestamp. EXIF carries no time zone, it is kept as UTC.
+func exifTime(entry exifEntry) *time.Time {
+	value := exifString(entry)
+	if value == "" {
+		return nil
+	}
+	takenAt, err := time.Parse(exifDateTimeLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &takenAt
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type testExifEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+func exifASCII(tag uint16, value string) testExifEntry {
+	return testExifEntry{tag: tag, typ: 2, count: uint32(len(value) + 1), value: append([]byte(value), 0)}
+}
+
+func exifShortEntry(tag uint16, value uint16) testExifEntry {
+	data := make([]byte, 2)
+	binary.LittleEndian.PutUint16(data, value)
+	return testExifEntry{tag: tag, typ: 3, count: 1, value: data}
+}
+
+func exifLongEntry(tag uint16, value uint32) testExifEntry {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, value)
+	return testExifEntry{tag: tag, typ: 4, count: 1, value: data}
+}
+
+func exifRationalEntry(tag uint16, numerator uint32, denominator uint32) testExifEntry {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint32(data[0:4], numerator)
+	binary.LittleEndian.PutUint32(data[4:8], denominator)
+	return testExifEntry{tag: tag, typ: 5, count: 1, value: data}
+}
+
+// testIFDSize is the size of an IFD written by writeTestIFD.
+func testIFDSize(entries []testExifEntry) uint32 {
+	size := uint32(2 + len(entries)*12 + 4)
+	for _, entry := range entries {
+		if len(entry.value) > 4 {
+			size += uint32(len(entry.value))
+		}
+	}
+	return size
+}
+
+// writeTestIFD appends an IFD at the end of tiff, values larger than four
+// bytes follow the IFD.
+func writeTestIFD(tiff []byte, entries []testExifEntry) []byte {
+	offset := uint32(len(tiff))
+	dataOffset := offset + 2 + uint32(len(entries))*12 + 4
+
+	ifd := make([]byte, 2, dataOffset-offset)
+	binary.LittleEndian.PutUint16(ifd, uint16(len(entries)))
+	data := make([]byte, 0)
+	for _, entry := range entries {
+		raw := make([]byte, 12)
+		binary.LittleEndian.PutUint16(raw[0:2], entry.tag)
+		binary.LittleEndian.PutUint16(raw[2:4], entry.typ)
+		binary.LittleEndian.PutUint32(raw[4:8], entry.count)
+		if len(entry.value) <= 4 {
+			copy(raw[8:12], entry.value)
+		} else {
+			binary.LittleEndian.PutUint32(raw[8:12], dataOffset+uint32(len(data)))
+			data = append(data, entry.value...)
+		}
+		ifd = append(ifd, raw...)
+	}
+	ifd = append(ifd, 0, 0, 0, 0)
+	return append(append(tiff, ifd...), data...)
+}
+
+// testJPEG encodes a width x height JPEG carrying an EXIF segment with camera
+// information, a GPS position and the given orientation.
+func testJPEG(t *testing.T, width int, height int, orientation uint16) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 10), G: uint8(y * 10), B: 100, A: 255})
+		}
+	}
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+
+	ifd0 := []testExifEntry{
+		exifASCII(exifTagMake, "Canon"),
+		exifASCII(exifTagModel, "Canon EOS R6"),
+		exifShortEntry(exifTagOrientation, orientation),
+		exifASCII(exifTagDateTime, "2023:05:06 07:08:09"),
+	}
+	exifIFD := []testExifEntry{
+		exifRationalEntry(exifTagExposureTime, 1, 125),
+		exifRationalEntry(exifTagFNumber, 28, 10),
+		exifShortEntry(exifTagISO, 400),
+		exifASCII(exifTagDateTimeOriginal, "2023:04:01 10:20:30"),
+		exifRationalEntry(exifTagFocalLength, 50, 1),
+		exifASCII(exifTagLensModel, "EF50mm f/1.8 STM"),
+	}
+	gpsIFD := []testExifEntry{
+		exifASCII(0x0001, "S"),
+		exifRationalEntry(0x0002, 8, 1),
+	}
+
+	// The Exif and GPS IFDs follow IFD0, which gains two pointer entries.
+	exifOffset := 8 + testIFDSize(ifd0) + 2*12
+	ifd0 = append(ifd0,
+		exifLongEntry(exifTagExifIFD, exifOffset),
+		exifLongEntry(0x8825, exifOffset+testIFDSize(exifIFD)),
+	)
+
+	tiff := []byte{'I', 'I', 42, 0, 8, 0, 0, 0}
+	tiff = writeTestIFD(tiff, ifd0)
+	tiff = writeTestIFD(tiff, exifIFD)
+	tiff = writeTestIFD(tiff, gpsIFD)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:4], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	data := encoded.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func TestProcessJPEG(t *testing.T) {
+	var plain bytes.Buffer
+	if err := jpeg.Encode(&plain, image.NewRGBA(image.Rect(0, 0, 3, 2)), nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		wantWidth  int
+		wantHeight int
+		wantCamera string
+		wantErr    error
+	}{
+		{
+			name:       "Case #1 - Success (Rotated photo with EXIF)",
+			data:       testJPEG(t, 8, 4, 6),
+			wantWidth:  4,
+			wantHeight: 8,
+			wantCamera: "Canon EOS R6",
+		},
+		{
+			name:       "Case #2 - Success (Upright photo with EXIF)",
+			data:       testJPEG(t, 8, 4, 1),
+			wantWidth:  8,
+			wantHeight: 4,
+			wantCamera: "Canon EOS R6",
+		},
+		{
+			name:       "Case #3 - Success (No EXIF)",
+			data:       plain.Bytes(),
+			wantWidth:  3,
+			wantHeight: 2,
+		},
+		{
+			name:    "Case #4 - Failed (Not a JPEG)",
+			data:    []byte("\x89PNG\r\n\x1a\nnot really"),
+			wantErr: model.ErrorUnsupportedImage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProcessJPEG(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ProcessJPEG() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Metadata.Width != tt.wantWidth || got.Metadata.Height != tt.wantHeight {
+				t.Errorf("ProcessJPEG() size = %dx%d, want %dx%d", got.Metadata.Width, got.Metadata.Height, tt.wantWidth, tt.wantHeight)
+			}
+			if got.Metadata.CameraModel != tt.wantCamera {
+				t.Errorf("ProcessJPEG() camera = %q, want %q", got.Metadata.CameraModel, tt.wantCamera)
+			}
+			if bytes.Contains(got.Data, []byte("Exif")) || bytes.Contains(got.Data, []byte("Canon")) {
+				t.Errorf("ProcessJPEG() output still carries EXIF")
+			}
+
+			config, err := jpeg.DecodeConfig(bytes.NewReader(got.Data))
+			if err != nil {
+				t.Fatalf("jpeg.DecodeConfig() error = %v", err)
+			}
+			if config.Width != tt.wantWidth || config.Height != tt.wantHeight {
+				t.Errorf("ProcessJPEG() stored size = %dx%d, want %dx%d", config.Width, config.Height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestParseJPEGExif(t *testing.T) {
+	got := parseJPEGExif(testJPEG(t, 2, 2, 6))
+
+	if got.Make != "Canon" || got.Model != "Canon EOS R6" || got.LensModel != "EF50mm f/1.8 STM" {
+		t.Errorf("parseJPEGExif() camera = %q %q %q", got.Make, got.Model, got.LensModel)
+	}
+	if got.ExposureTime != "1/125" {
+		t.Errorf("parseJPEGExif() exposure = %q, want 1/125", got.ExposureTime)
+	}
+	if got.FNumber == nil || *got.FNumber != 2.8 || got.FocalLength == nil || *got.FocalLength != 50 {
+		t.Errorf("parseJPEGExif() f-number = %v, focal length = %v", got.FNumber, got.FocalLength)
+	}
+	if got.ISO == nil || *got.ISO != 400 {
+		t.Errorf("parseJPEGExif() iso = %v, want 400", got.ISO)
+	}
+	if got.TakenAt == nil || got.TakenAt.Format(exifDateTimeLayout) != "2023:04:01 10:20:30" {
+		t.Errorf("parseJPEGExif() taken at = %v, want the original time", got.TakenAt)
+	}
+	if got.Orientation != 6 {
+		t.Errorf("parseJPEGExif() orientation = %d, want 6", got.Orientation)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// A 3x2 image with distinct corners: a b c / d e f.
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for i, c := range []uint8{'a', 'b', 'c', 'd', 'e', 'f'} {
+		src.Set(i%3, i/3, color.NRGBA{R: c, A: 255})
+	}
+
+	tests := []struct {
+		orientation int
+		want        string
+	}{
+		{orientation: 1, want: "abc/def"},
+		{orientation: 2, want: "cba/fed"},
+		{orientation: 3, want: "fed/cba"},
+		{orientation: 4, want: "def/abc"},
+		{orientation: 5, want: "ad/be/cf"},
+		{orientation: 6, want: "da/eb/fc"},
+		{orientation: 7, want: "fc/eb/da"},
+		{orientation: 8, want: "cf/be/ad"},
+	}
+	for _, tt := range tests {
+		got := applyOrientation(src, tt.orientation)
+
+		var rows [][]byte
+		for y := 0; y < got.Bounds().Dy(); y++ {
+			row := make([]byte, 0)
+			for x := 0; x < got.Bounds().Dx(); x++ {
+				row = append(row, got.NRGBAAt(x, y).R)
+			}
+			rows = append(rows, row)
+		}
+		if string(bytes.Join(rows, []byte("/"))) != tt.want {
+			t.Errorf("applyOrientation(%d) = %s, want %s", tt.orientation, bytes.Join(rows, []byte("/")), tt.want)
+		}
+	}
+}
+
+// testGradientJPEG encodes a horizontal grey gradient, brightening to the
+// right or, when reversed, to the left.
+func testGradientJPEG(t *testing.T, reversed bool) []byte {
+	img := image.NewGray(image.Rect(0, 0, 72, 48))
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 72; x++ {
+			v := uint8(x * 3)
+			if reversed {
+				v = uint8((71 - x) * 3)
+			}
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+	return encoded.Bytes()
+}
+
+func TestDifferenceHash(t *testing.T) {
+	original, err := ProcessJPEG(testGradientJPEG(t, false))
+	if err != nil {
+		t.Fatalf("ProcessJPEG() error = %v", err)
+	}
+	reencoded, err := ProcessJPEG(original.Data)
+	if err != nil {
+		t.Fatalf("ProcessJPEG() error = %v", err)
+	}
+	mirrored, err := ProcessJPEG(testGradientJPEG(t, true))
+	if err != nil {
+		t.Fatalf("ProcessJPEG() error = %v", err)
+	}
+
+	if d := hammingDistance(original.Hash, reencoded.Hash); d > 2 {
+		t.Errorf("hammingDistance() re-encoded = %v, want at most 2", d)
+	}
+	if d := hammingDistance(original.Hash, mirrored.Hash); d < 32 {
+		t.Errorf("hammingDistance() mirrored = %v, want at least 32", d)
+	}
+}
+
+func TestPhotoService_Upload(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+	imageRepository := mocks.NewIImageRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository:   photoRepository,
+		ImageRepository:   imageRepository,
+		MaxUploadSize:     1 << 20,
+		DuplicateDistance: 6,
+	}
+
+	earlier := model.PhotoSimilarity{Photo: model.Photo{ID: "9", UserID: "1"}, Distance: 2}
+
+	tests := []struct {
+		name          string
+		data          []byte
+		action        string
+		mockFunc      func()
+		wantDuplicate []string
+		wantErr       bool
+	}{
+		{
+			name:   "Case #1 - Success",
+			data:   testJPEG(t, 8, 4, 6),
+			action: model.DuplicatePhotoWarn,
+			mockFunc: func() {
+				photoRepository.On("GetSimilar", mock.Anything, 6, "1", 5).Return([]model.PhotoSimilarity{}, nil).Once()
+				imageRepository.On("Save", mock.Anything, mock.Anything).Return("/uploads/p.jpg", nil).Once()
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool {
+						return photo.Metadata != nil && photo.Metadata.PhotoID == photo.ID && photo.Metadata.Width == 4 && photo.PhotoURL == "/uploads/p.jpg" &&
+							photo.PerceptualHash != nil
+					})).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name:   "Case #2 - Failed (Stored file removed when saving fails)",
+			data:   testJPEG(t, 8, 4, 1),
+			action: model.DuplicatePhotoWarn,
+			mockFunc: func() {
+				photoRepository.On("GetSimilar", mock.Anything, 6, "1", 5).Return([]model.PhotoSimilarity{}, nil).Once()
+				imageRepository.On("Save", mock.Anything, mock.Anything).Return("/uploads/p.jpg", nil).Once()
+				photoRepository.On("Save", mock.Anything).Return(model.Photo{}, model.MyError{Err: "db down"}).Once()
+				imageRepository.On("Delete", mock.Anything).Return(nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:     "Case #3 - Failed (Too large)",
+			data:     make([]byte, 1<<20+1),
+			mockFunc: func() {},
+			wantErr:  true,
+		},
+		{
+			name:   "Case #4 - Success (Near duplicate warned about)",
+			data:   testJPEG(t, 8, 4, 1),
+			action: model.DuplicatePhotoWarn,
+			mockFunc: func() {
+				photoRepository.On("GetSimilar", mock.Anything, 6, "1", 5).Return([]model.PhotoSimilarity{earlier}, nil).Once()
+				imageRepository.On("Save", mock.Anything, mock.Anything).Return("/uploads/p.jpg", nil).Once()
+				photoRepository.On("Save", mock.Anything).Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+			wantDuplicate: []string{"9"},
+		},
+		{
+			name:   "Case #5 - Failed (Near duplicate rejected)",
+			data:   testJPEG(t, 8, 4, 1),
+			action: model.DuplicatePhotoReject,
+			mockFunc: func() {
+				photoRepository.On("GetSimilar", mock.Anything, 6, "1", 5).Return([]model.PhotoSimilarity{earlier}, nil).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			ps.DuplicateAction = tt.action
+			got, err := ps.Upload(model.PhotoUploadRequest{Title: "Sunset"}, tt.data, "1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PhotoService.Upload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Metadata == nil {
+				t.Errorf("PhotoService.Upload() metadata hidden from its owner")
+			}
+			if !reflect.DeepEqual(got.DuplicateOf, tt.wantDuplicate) {
+				t.Errorf("PhotoService.Upload() duplicate_of = %v, want %v", got.DuplicateOf, tt.wantDuplicate)
+			}
+		})
+	}
+}
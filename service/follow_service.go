@@ -0,0 +1,115 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository"
+)
+
+type FollowService struct {
+	FollowRepository repository.IFollowRepository
+	UserRepository   repository.IUserRepository
+	VisibilityPolicy *VisibilityPolicy
+}
+
+func NewFollowService(followRepository repository.IFollowRepository, userRepository repository.IUserRepository, visibilityPolicy *VisibilityPolicy) *FollowService {
+	return &FollowService{
+		FollowRepository: followRepository,
+		UserRepository:   userRepository,
+		VisibilityPolicy: visibilityPolicy,
+	}
+}
+
+// Follow follows a public user right away. Following a private user creates a
+// pending request that the user has to approve.
+func (fs *FollowService) Follow(username string, followerId string) (model.FollowResponse, error) {
+	user, err := fs.UserRepository.GetByUsername(username)
+	if err != nil {
+		return model.FollowResponse{}, err
+	}
+
+	if user.ID == followerId {
+		return model.FollowResponse{}, model.ErrorCannotFollowSelf
+	}
+
+	canInteract, err := fs.VisibilityPolicy.CanInteract(followerId, user.ID)
+	if err != nil {
+		return model.FollowResponse{}, err
+	}
+	if !canInteract {
+		return model.FollowResponse{}, model.ErrorBlocked
+	}
+
+	status := model.FollowStatusAccepted
+	if user.IsPrivate {
+		status = model.FollowStatusPending
+	}
+
+	follow := model.Follow{
+		FollowerID:  followerId,
+		FollowingID: user.ID,
+		Status:      status,
+	}
+
+	res, err := fs.FollowRepository.Save(follow)
+	if err != nil {
+		return model.FollowResponse{}, err
+	}
+
+	return model.ToFollowResponse(res), nil
+}
+
+// Unfollow removes a follow or cancels a pending request.
+func (fs *FollowService) Unfollow(username string, followerId string) error {
+	user, err := fs.UserRepository.GetByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	return fs.FollowRepository.Delete(followerId, user.ID)
+}
+
+func (fs *FollowService) GetPendingRequests(userId string) ([]model.FollowRequestResponse, error) {
+	requestsResponse := make([]model.FollowRequestResponse, 0)
+
+	res, err := fs.FollowRepository.GetPendingRequests(userId)
+	if err != nil {
+		return []model.FollowRequestResponse{}, err
+	}
+
+	for _, follow := range res {
+		requestsResponse = append(requestsResponse, model.FollowRequestResponse{
+			UserID:    follow.FollowerID,
+			Username:  follow.Follower.Username,
+			CreatedAt: follow.CreatedAt,
+		})
+	}
+
+	return requestsResponse, nil
+}
+
+func (fs *FollowService) Approve(username string, userId string) error {
+	follower, err := fs.UserRepository.GetByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	return fs.FollowRepository.Accept(follower.ID, userId)
+}
+
+func (fs *FollowService) Reject(username string, userId string) error {
+	follower, err := fs.UserRepository.GetByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	follow, err := fs.FollowRepository.GetOne(follower.ID, userId)
+	if err != nil {
+		return err
+	}
+
+	if follow.Status != model.FollowStatusPending {
+		return model.ErrorNotFound
+	}
+
+	return fs.FollowRepository.Delete(follower.ID, userId)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository/mocks"
+	"reflect"
+	"testing"
+)
+
+func TestFollowService_Follow(t *testing.T) {
+	followRepository := mocks.NewIFollowRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(userRepository, followRepository, nil, blockRepository, nil)
+
+	type args struct {
+		username   string
+		followerId string
+	}
+	tests := []struct {
+		name     string
+		fs       *FollowService
+		args     args
+		want     model.FollowResponse
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success (Public account is followed right away)",
+			fs: &FollowService{
+				FollowRepository: followRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username:   "public",
+				followerId: "1",
+			},
+			want: model.FollowResponse{
+				FollowerID:  "1",
+				FollowingID: "2",
+				Status:      model.FollowStatusAccepted,
+			},
+			mockFunc: func() {
+				follow := model.Follow{FollowerID: "1", FollowingID: "2", Status: model.FollowStatusAccepted}
+				userRepository.On("GetByUsername", "public").Return(model.User{ID: "2"}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				followRepository.On("Save", follow).Return(follow, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Success (Private account gets a pending request)",
+			fs: &FollowService{
+				FollowRepository: followRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username:   "private",
+				followerId: "1",
+			},
+			want: model.FollowResponse{
+				FollowerID:  "1",
+				FollowingID: "3",
+				Status:      model.FollowStatusPending,
+			},
+			mockFunc: func() {
+				follow := model.Follow{FollowerID: "1", FollowingID: "3", Status: model.FollowStatusPending}
+				userRepository.On("GetByUsername", "private").Return(model.User{ID: "3", IsPrivate: true}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"3"}).Return([]string{}, nil).Once()
+				followRepository.On("Save", follow).Return(follow, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #3 - Failed (Cannot follow self)",
+			fs: &FollowService{
+				FollowRepository: followRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username:   "me",
+				followerId: "1",
+			},
+			want: model.FollowResponse{},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "me").Return(model.User{ID: "1"}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #4 - Failed (Blocked)",
+			fs: &FollowService{
+				FollowRepository: followRepository,
+				UserRepository:   userRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				username:   "blocker",
+				followerId: "1",
+			},
+			want: model.FollowResponse{},
+			mockFunc: func() {
+				userRepository.On("GetByUsername", "blocker").Return(model.User{ID: "4"}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"4"}).Return([]string{"4"}, nil).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.fs.Follow(tt.args.username, tt.args.followerId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FollowService.Follow() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FollowService.Follow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
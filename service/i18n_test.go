@@ -0,0 +1,89 @@
+package service
+
+import (
+	"mygram/i18n"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// usedKeys collects the catalog keys the code refers to: the codes of the
+// errors in model/error.go, the messages of valid tags and the resources named by
+// notFoundAs in controllers.
+func usedKeys(t *testing.T) map[string]bool {
+	patterns := []struct {
+		glob    string
+		pattern *regexp.Regexp
+		prefix  string
+	}{
+		{glob: "../model/error.go", pattern: regexp.MustCompile(`Code:\s+"([a-z_]+)"`), prefix: "error."},
+		{glob: "../model/*.go", pattern: regexp.MustCompile(`~(validation\.[a-z_]+)`)},
+		{glob: "../controller/*.go", pattern: regexp.MustCompile(`notFoundAs\(err, "([A-Za-z]+)"\)`), prefix: "error.not_found."},
+	}
+
+	keys := map[string]bool{"validation.invalid": true, "validation.type": true}
+	for _, p := range patterns {
+		files, err := filepath.Glob(p.glob)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			source, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, match := range p.pattern.FindAllStringSubmatch(string(source), -1) {
+				keys[p.prefix+strings.ToLower(match[1])] = true
+			}
+		}
+	}
+	return keys
+}
+
+func TestCatalogs_Complete(t *testing.T) {
+	keys := usedKeys(t)
+	for _, language := range i18n.Languages() {
+		for _, key := range i18n.Keys(language) {
+			keys[key] = true
+		}
+	}
+
+	for _, language := range i18n.Languages() {
+		t.Run(language, func(t *testing.T) {
+			catalog := map[string]bool{}
+			for _, key := range i18n.Keys(language) {
+				catalog[key] = true
+			}
+			for key := range keys {
+				if !catalog[key] {
+					t.Errorf("catalog %s is missing key %s", language, key)
+				}
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "No header", acceptLanguage: "", want: "en"},
+		{name: "Region of a supported language", acceptLanguage: "id-ID", want: "id"},
+		{name: "Highest quality wins", acceptLanguage: "en;q=0.5, id;q=0.8", want: "id"},
+		{name: "Unsupported languages are skipped", acceptLanguage: "fr-FR, de;q=0.9, id;q=0.1", want: "id"},
+		{name: "Excluded language", acceptLanguage: "id;q=0", want: "en"},
+		{name: "Nothing supported", acceptLanguage: "ja, fr", want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := i18n.Negotiate(tt.acceptLanguage); got != tt.want {
+				t.Errorf("Negotiate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
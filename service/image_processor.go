@@ -0,0 +1,118 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"mygram/model"
+)
+
+// MaxImagePixels bounds the decoded size of an upload, a small file can
+// still claim huge dimensions.
+const MaxImagePixels = 50_000_000
+
+const jpegQuality = 90
+
+// ProcessedImage is an upload ready to be stored: re-encoded without any
+// EXIF, upright, together with the metadata read from the original and the
+// perceptual hash of the upright pixels.
+type ProcessedImage struct {
+	Data     []byte
+	Metadata model.PhotoMetadata
+	Hash     uint64
+}
+
+// ProcessJPEG reads the EXIF of a JPEG, applies its orientation to the pixels
+// and re-encodes the image. The encoder writes no EXIF, so GPS and every other
+// tag of the original are dropped from the stored file.
+func ProcessJPEG(data []byte) (ProcessedImage, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || format != "jpeg" {
+		return ProcessedImage{}, model.ErrorUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxImagePixels {
+		return ProcessedImage{}, model.ErrorImageTooLarge
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return ProcessedImage{}, model.ErrorUnsupportedImage
+	}
+
+	exif := parseJPEGExif(data)
+	orientation := exif.Orientation
+	if orientation < 1 || orientation > 8 {
+		orientation = 1
+	}
+	upright := applyOrientation(img, orientation)
+
+	var buffer bytes.Buffer
+	err = jpeg.Encode(&buffer, upright, &jpeg.Options{Quality: jpegQuality})
+	if err != nil {
+		return ProcessedImage{}, err
+	}
+
+	bounds := upright.Bounds()
+	return ProcessedImage{
+		Data: buffer.Bytes(),
+		Hash: differenceHash(upright),
+		Metadata: model.PhotoMetadata{
+			CameraMake:   truncate(exif.Make, 100),
+			CameraModel:  truncate(exif.Model, 100),
+			LensModel:    truncate(exif.LensModel, 100),
+			ExposureTime: truncate(exif.ExposureTime, 20),
+			FNumber:      exif.FNumber,
+			ISO:          exif.ISO,
+			FocalLength:  exif.FocalLength,
+			TakenAt:      exif.TakenAt,
+			Orientation:  orientation,
+			Width:        bounds.Dx(),
+			Height:       bounds.Dy(),
+		},
+	}, nil
+}
+
+// applyOrientation returns img transformed so that it displays upright for
+// EXIF orientation 1 to 8. Orientations 5 to 8 swap width and height.
+func applyOrientation(img image.Image, orientation int) *image.NRGBA {
+	bounds := img.Bounds()
+	src := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	if orientation == 1 {
+		return src
+	}
+
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = width-1-x, y
+			case 3:
+				sx, sy = width-1-x, height-1-y
+			case 4:
+				sx, sy = x, height-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, height-1-x
+			case 7:
+				sx, sy = width-1-y, height-1-x
+			case 8:
+				sx, sy = width-1-y, x
+			}
+			srcOffset := src.PixOffset(sx, sy)
+			dstOffset := dst.PixOffset(x, y)
+			copy(dst.Pix[dstOffset:dstOffset+4], src.Pix[srcOffset:srcOffset+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MediaService serves uploaded photo and story files to the viewers allowed
+// to see the photo or story they belong to.
+type MediaService struct {
+	PhotoRepository  repository.IPhotoRepository
+	StoryRepository  repository.IStoryRepository
+	ImageRepository  repository.IImageRepository
+	VisibilityPolicy *VisibilityPolicy
+}
+
+func NewMediaService(photoRepository repository.IPhotoRepository, storyRepository repository.IStoryRepository, imageRepository repository.IImageRepository, visibilityPolicy *VisibilityPolicy) *MediaService {
+	return &MediaService{
+		PhotoRepository:  photoRepository,
+		StoryRepository:  storyRepository,
+		ImageRepository:  imageRepository,
+		VisibilityPolicy: visibilityPolicy,
+	}
+}
+
+// GetFile returns where the uploaded file name is stored when viewerId may see
+// the photo or story it belongs to. Files of drafts, hidden photos, private
+// accounts the viewer does not follow, blocked users and expired stories are
+// answered with ErrorNotFound, like any name that is not an upload.
+func (ms *MediaService) GetFile(name string, viewerId string) (string, error) {
+	name = filepath.Base(name)
+	if !strings.HasSuffix(name, ".jpg") {
+		return "", model.ErrorNotFound
+	}
+
+	var visible bool
+	var err error
+	if strings.HasPrefix(name, "story-") {
+		visible, err = ms.canViewStory(strings.TrimSuffix(strings.TrimPrefix(name, "story-"), ".jpg"), viewerId)
+	} else {
+		visible, err = ms.canViewPhoto(strings.TrimSuffix(name, ".jpg"), viewerId)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !visible {
+		return "", model.ErrorNotFound
+	}
+
+	return ms.ImageRepository.Path(name)
+}
+
+func (ms *MediaService) canViewPhoto(id string, viewerId string) (bool, error) {
+	photo, err := ms.PhotoRepository.GetOne(id)
+	if err != nil {
+		return false, err
+	}
+
+	if isPhotoHiddenFrom(viewerId, photo) {
+		return false, nil
+	}
+
+	return ms.VisibilityPolicy.CanView(viewerId, photo.UserID)
+}
+
+func (ms *MediaService) canViewStory(id string, viewerId string) (bool, error) {
+	story, err := ms.StoryRepository.GetOne(id)
+	if err != nil {
+		return false, err
+	}
+	if !story.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+	if story.UserID == viewerId {
+		return true, nil
+	}
+
+	return ms.VisibilityPolicy.CanView(viewerId, story.UserID)
+}
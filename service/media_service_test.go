@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"testing"
+	"time"
+)
+
+func TestMediaService_GetFile(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+	storyRepository := mocks.NewIStoryRepository(t)
+	imageRepository := mocks.NewIImageRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	followRepository := mocks.NewIFollowRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+
+	ms := &MediaService{
+		PhotoRepository:  photoRepository,
+		StoryRepository:  storyRepository,
+		ImageRepository:  imageRepository,
+		VisibilityPolicy: NewVisibilityPolicy(userRepository, followRepository, nil, blockRepository, nil),
+	}
+
+	photo := model.Photo{ID: "p1", UserID: "1", Status: model.PhotoStatusPublished}
+	draft := model.Photo{ID: "p1", UserID: "1", Status: model.PhotoStatusDraft}
+	hidden := model.Photo{ID: "p1", UserID: "1", Status: model.PhotoStatusPublished, Hidden: true}
+	story := model.Story{ID: "s1", UserID: "1", ExpiresAt: time.Now().Add(time.Hour)}
+	expired := model.Story{ID: "s1", UserID: "1", ExpiresAt: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name     string
+		fileName string
+		viewerId string
+		mockFunc func()
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "Case #1 - Success (Public photo)",
+			fileName: "p1.jpg",
+			viewerId: "2",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(photo, nil).Once()
+				blockRepository.On("GetBlockedIDs", "2", []string{"1"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"1"}).Return([]model.User{{ID: "1"}}, nil).Once()
+				imageRepository.On("Path", "p1.jpg").Return("uploads/p1.jpg", nil).Once()
+			},
+			want: "uploads/p1.jpg",
+		},
+		{
+			name:     "Case #2 - Success (Owner's draft)",
+			fileName: "p1.jpg",
+			viewerId: "1",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(draft, nil).Once()
+				imageRepository.On("Path", "p1.jpg").Return("uploads/p1.jpg", nil).Once()
+			},
+			want: "uploads/p1.jpg",
+		},
+		{
+			name:     "Case #3 - Failed (Someone else's draft)",
+			fileName: "p1.jpg",
+			viewerId: "2",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(draft, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+		{
+			name:     "Case #4 - Failed (Hidden photo)",
+			fileName: "p1.jpg",
+			viewerId: "2",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(hidden, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+		{
+			name:     "Case #5 - Failed (Private account not followed)",
+			fileName: "p1.jpg",
+			viewerId: "2",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(photo, nil).Once()
+				blockRepository.On("GetBlockedIDs", "2", []string{"1"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"1"}).Return([]model.User{{ID: "1", IsPrivate: true}}, nil).Once()
+				followRepository.On("GetAcceptedFollowingIDs", "2", []string{"1"}).Return([]string{}, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+		{
+			name:     "Case #6 - Success (Story of a followed account)",
+			fileName: "story-s1.jpg",
+			viewerId: "2",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(story, nil).Once()
+				blockRepository.On("GetBlockedIDs", "2", []string{"1"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"1"}).Return([]model.User{{ID: "1", IsPrivate: true}}, nil).Once()
+				followRepository.On("GetAcceptedFollowingIDs", "2", []string{"1"}).Return([]string{"1"}, nil).Once()
+				imageRepository.On("Path", "story-s1.jpg").Return("uploads/story-s1.jpg", nil).Once()
+			},
+			want: "uploads/story-s1.jpg",
+		},
+		{
+			name:     "Case #7 - Failed (Expired story)",
+			fileName: "story-s1.jpg",
+			viewerId: "1",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(expired, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+		{
+			name:     "Case #8 - Failed (Story of a blocked user)",
+			fileName: "story-s1.jpg",
+			viewerId: "3",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(story, nil).Once()
+				blockRepository.On("GetBlockedIDs", "3", []string{"1"}).Return([]string{"1"}, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+		{
+			name:     "Case #9 - Failed (Not an upload)",
+			fileName: ".env",
+			viewerId: "1",
+			mockFunc: func() {},
+			wantErr:  model.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ms.GetFile(tt.fileName, tt.viewerId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("MediaService.GetFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MediaService.GetFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"fmt"
+	"mygram/helper"
+	"mygram/model"
+	"mygram/repository"
+	"strings"
+	"time"
+)
+
+type ModerationService struct {
+	ReportRepository           repository.IReportRepository
+	ModerationActionRepository repository.IModerationActionRepository
+	PhotoRepository            repository.IPhotoRepository
+	CommentRepository          repository.ICommentRepository
+	UserRepository             repository.IUserRepository
+	VisibilityPolicy           *VisibilityPolicy
+	AutoHideThreshold          int
+}
+
+func NewModerationService(reportRepository repository.IReportRepository, moderationActionRepository repository.IModerationActionRepository, photoRepository repository.IPhotoRepository, commentRepository repository.ICommentRepository, userRepository repository.IUserRepository, visibilityPolicy *VisibilityPolicy, autoHideThreshold int) *ModerationService {
+	return &ModerationService{
+		ReportRepository:           reportRepository,
+		ModerationActionRepository: moderationActionRepository,
+		PhotoRepository:            photoRepository,
+		CommentRepository:          commentRepository,
+		UserRepository:             userRepository,
+		VisibilityPolicy:           visibilityPolicy,
+		AutoHideThreshold:          autoHideThreshold,
+	}
+}
+
+func (ms *ModerationService) ReportPhoto(request model.ReportCreateRequest, photoId string, reporterId string) (model.ReportResponse, error) {
+	photo, err := ms.PhotoRepository.GetOne(photoId)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	visible, err := ms.VisibilityPolicy.FilterPhotos(reporterId, []model.Photo{photo})
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+	if len(visible) == 0 {
+		return model.ReportResponse{}, model.ErrorNotFound
+	}
+
+	return ms.report(request, model.Report{
+		TargetType:   model.ReportTargetPhoto,
+		TargetID:     photo.ID,
+		TargetUserID: photo.UserID,
+		Excerpt:      photo.Title + " " + photo.PhotoURL,
+	}, reporterId)
+}
+
+func (ms *ModerationService) ReportComment(request model.ReportCreateRequest, commentId string, reporterId string) (model.ReportResponse, error) {
+	comment, err := ms.CommentRepository.GetOne(commentId)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	visible, err := ms.VisibilityPolicy.FilterComments(reporterId, []model.Comment{comment})
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+	if len(visible) == 0 {
+		return model.ReportResponse{}, model.ErrorNotFound
+	}
+
+	return ms.report(request, model.Report{
+		TargetType:   model.ReportTargetComment,
+		TargetID:     comment.ID,
+		TargetUserID: comment.UserID,
+		Excerpt:      comment.Message,
+	}, reporterId)
+}
+
+// ReportUser reports an account. Blocked users can still be reported, blocking
+// someone who harasses you should not stop you from flagging them.
+func (ms *ModerationService) ReportUser(request model.ReportCreateRequest, username string, reporterId string) (model.ReportResponse, error) {
+	user, err := ms.UserRepository.GetByUsername(username)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	return ms.report(request, model.Report{
+		TargetType:   model.ReportTargetUser,
+		TargetID:     user.ID,
+		TargetUserID: user.ID,
+		Excerpt:      user.Username,
+	}, reporterId)
+}
+
+func (ms *ModerationService) GetQueue(request model.ReportListRequest) (model.ReportListResponse, error) {
+	reportsResponse := make([]model.ReportResponse, 0)
+	pagination := request.PaginationRequest.Normalize()
+
+	res, total, err := ms.ReportRepository.Get(request.Status, pagination)
+	if err != nil {
+		return model.ReportListResponse{}, err
+	}
+
+	for _, report := range res {
+		reportsResponse = append(reportsResponse, model.ToReportResponse(report))
+	}
+
+	return model.ReportListResponse{
+		Reports:    reportsResponse,
+		Pagination: model.ToPaginationResponse(pagination, total),
+	}, nil
+}
+
+// Claim assigns a report to the moderator so others do not review it at the
+// same time. Claiming your own claimed report again is a no-op.
+func (ms *ModerationService) Claim(id string, moderatorId string) (model.ReportResponse, error) {
+	report, err := ms.getReviewableReport(id, moderatorId)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+	if report.Status == model.ReportStatusClaimed {
+		return model.ToReportResponse(report), nil
+	}
+
+	err = ms.ReportRepository.Claim(id, moderatorId)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	err = ms.record(report, moderatorId, model.ModerationActionClaim, "")
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	report.Status = model.ReportStatusClaimed
+	report.ModeratorID = &moderatorId
+	return model.ToReportResponse(report), nil
+}
+
+// Resolve applies the moderator's action to the reported target and closes
+// every pending report on it. Hiding only applies to photos and comments,
+// warnings and suspensions apply to the target's owner.
+func (ms *ModerationService) Resolve(request model.ReportResolveRequest, id string, moderatorId string) (model.ReportResponse, error) {
+	report, err := ms.getReviewableReport(id, moderatorId)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	switch request.Action {
+	case model.ModerationActionHide:
+		if report.TargetType == model.ReportTargetUser {
+			return model.ReportResponse{}, model.ErrorInvalidModerationAction
+		}
+		err = ms.setHidden(report, true)
+	case model.ModerationActionSuspend:
+		days := request.SuspendDays
+		if days == 0 {
+			days = model.DefaultSuspendDays
+		}
+		suspendedUntil := time.Now().AddDate(0, 0, days)
+		err = ms.UserRepository.UpdateSuspension(report.TargetUserID, &suspendedUntil)
+	case model.ModerationActionWarn:
+		// The recorded action is the warning, the user reads it from GET /me/warnings.
+	default:
+		return model.ReportResponse{}, model.ErrorInvalidModerationAction
+	}
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	err = ms.record(report, moderatorId, request.Action, request.Note)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	err = ms.ReportRepository.CloseByTarget(report.TargetType, report.TargetID, model.ReportStatusResolved, moderatorId)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	report.Status = model.ReportStatusResolved
+	report.ModeratorID = &moderatorId
+	return model.ToReportResponse(report), nil
+}
+
+// Dismiss closes every pending report on the target as unfounded and restores
+// content that was hidden automatically while it waited for review.
+func (ms *ModerationService) Dismiss(request model.ReportDismissRequest, id string, moderatorId string) (model.ReportResponse, error) {
+	report, err := ms.getReviewableReport(id, moderatorId)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	// Content hidden by a moderator can no longer be reported, so an open
+	// report on hidden content means it was hidden automatically.
+	err = ms.setHidden(report, false)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	err = ms.record(report, moderatorId, model.ModerationActionDismiss, request.Note)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	err = ms.ReportRepository.CloseByTarget(report.TargetType, report.TargetID, model.ReportStatusDismissed, moderatorId)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	report.Status = model.ReportStatusDismissed
+	report.ModeratorID = &moderatorId
+	return model.ToReportResponse(report), nil
+}
+
+func (ms *ModerationService) GetActions(request model.PaginationRequest) (model.ModerationActionListResponse, error) {
+	actionsResponse := make([]model.ModerationActionResponse, 0)
+	pagination := request.Normalize()
+
+	res, total, err := ms.ModerationActionRepository.Get(pagination)
+	if err != nil {
+		return model.ModerationActionListResponse{}, err
+	}
+
+	for _, action := range res {
+		actionsResponse = append(actionsResponse, model.ToModerationActionResponse(action))
+	}
+
+	return model.ModerationActionListResponse{
+		Actions:    actionsResponse,
+		Pagination: model.ToPaginationResponse(pagination, total),
+	}, nil
+}
+
+// GetReposts lists photos of other accounts whose perceptual hash is within
+// the requested distance of the photo, closest first.
+func (ms *ModerationService) GetReposts(request model.PhotoRepostRequest, photoId string) (model.PhotoRepostListResponse, error) {
+	repostsResponse := make([]model.PhotoRepostResponse, 0)
+
+	maxDistance := request.MaxDistance
+	if maxDistance == 0 {
+		maxDistance = model.DefaultRepostDistance
+	}
+	if maxDistance < 0 || maxDistance > model.MaxRepostDistance {
+		return model.PhotoRepostListResponse{}, model.ErrorInvalidRepostDistance
+	}
+
+	photo, err := ms.PhotoRepository.GetOne(photoId)
+	if err != nil {
+		return model.PhotoRepostListResponse{}, err
+	}
+	if photo.PerceptualHash == nil {
+		return model.PhotoRepostListResponse{}, model.ErrorPhotoNotHashed
+	}
+
+	// The photo's own account is skipped after the query, so ask for one
+	// extra page of results to make up for it.
+	res, err := ms.PhotoRepository.GetSimilar(*photo.PerceptualHash, maxDistance, "", 2*model.MaxRepostResults)
+	if err != nil {
+		return model.PhotoRepostListResponse{}, err
+	}
+
+	for _, val := range res {
+		if val.Photo.UserID == photo.UserID {
+			continue
+		}
+		if len(repostsResponse) == model.MaxRepostResults {
+			break
+		}
+		repostsResponse = append(repostsResponse, model.PhotoRepostResponse{
+			ID:           val.Photo.ID,
+			UserID:       val.Photo.UserID,
+			Title:        val.Photo.Title,
+			PhotoURL:     val.Photo.PhotoURL,
+			Hidden:       val.Photo.Hidden,
+			Distance:     val.Distance,
+			PostedBefore: val.Photo.CreatedAt.Before(photo.CreatedAt),
+			CreatedAt:    val.Photo.CreatedAt,
+		})
+	}
+
+	return model.PhotoRepostListResponse{
+		PhotoID: photo.ID,
+		Reposts: repostsResponse,
+	}, nil
+}
+
+func (ms *ModerationService) GetWarnings(userId string) ([]model.WarningResponse, error) {
+	warningsResponse := make([]model.WarningResponse, 0)
+
+	res, err := ms.ModerationActionRepository.GetByTargetUserID(userId, model.ModerationActionWarn)
+	if err != nil {
+		return []model.WarningResponse{}, err
+	}
+
+	for _, action := range res {
+		warningsResponse = append(warningsResponse, model.WarningResponse{
+			TargetType: action.TargetType,
+			TargetID:   action.TargetID,
+			Note:       action.Note,
+			CreatedAt:  action.CreatedAt,
+		})
+	}
+
+	return warningsResponse, nil
+}
+
+func (ms *ModerationService) report(request model.ReportCreateRequest, target model.Report, reporterId string) (model.ReportResponse, error) {
+	if target.TargetUserID == reporterId {
+		return model.ReportResponse{}, model.ErrorCannotReportSelf
+	}
+
+	report := target
+	report.ID = helper.GenerateID()
+	report.ReporterID = reporterId
+	report.Excerpt = truncate(strings.TrimSpace(report.Excerpt), 255)
+	report.Reason = request.Reason
+	report.Note = request.Note
+	report.Status = model.ReportStatusOpen
+
+	res, err := ms.ReportRepository.Save(report)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	err = ms.autoHide(res)
+	if err != nil {
+		return model.ReportResponse{}, err
+	}
+
+	return model.ToReportResponse(res), nil
+}
+
+// autoHide hides a photo or comment once the number of users with a pending
+// report on it reaches the threshold. Content that is already hidden is left
+// alone, so the hide is recorded once until reviewed.
+func (ms *ModerationService) autoHide(report model.Report) error {
+	if ms.AutoHideThreshold <= 0 || report.TargetType == model.ReportTargetUser {
+		return nil
+	}
+
+	count, err := ms.ReportRepository.CountOpenByTarget(report.TargetType, report.TargetID)
+	if err != nil {
+		return err
+	}
+	if count < int64(ms.AutoHideThreshold) {
+		return nil
+	}
+
+	hidden, err := ms.isHidden(report)
+	if err != nil || hidden {
+		return err
+	}
+
+	err = ms.setHidden(report, true)
+	if err != nil {
+		return err
+	}
+
+	return ms.record(report, "", model.ModerationActionAutoHide, fmt.Sprintf("Reported by %d users", count))
+}
+
+// getReviewableReport returns a report that is still open, or claimed by the
+// given moderator.
+func (ms *ModerationService) getReviewableReport(id string, moderatorId string) (model.Report, error) {
+	report, err := ms.ReportRepository.GetOne(id)
+	if err != nil {
+		return model.Report{}, err
+	}
+
+	switch report.Status {
+	case model.ReportStatusResolved, model.ReportStatusDismissed:
+		return model.Report{}, model.ErrorReportClosed
+	case model.ReportStatusClaimed:
+		if report.ModeratorID == nil || *report.ModeratorID != moderatorId {
+			return model.Report{}, model.ErrorReportClaimed
+		}
+	}
+
+	return report, nil
+}
+
+func (ms *ModerationService) isHidden(report model.Report) (bool, error) {
+	switch report.TargetType {
+	case model.ReportTargetPhoto:
+		photo, err := ms.PhotoRepository.GetOne(report.TargetID)
+		return photo.Hidden, err
+	case model.ReportTargetComment:
+		comment, err := ms.CommentRepository.GetOne(report.TargetID)
+		return comment.Hidden, err
+	}
+	return false, nil
+}
+
+func (ms *ModerationService) setHidden(report model.Report, hidden bool) error {
+	switch report.TargetType {
+	case model.ReportTargetPhoto:
+		return ms.PhotoRepository.UpdateHidden(report.TargetID, hidden)
+	case model.ReportTargetComment:
+		return ms.CommentRepository.UpdateHidden(report.TargetID, hidden)
+	}
+	return nil
+}
+
+// record stores a moderation decision. An empty moderatorId marks a decision
+// taken automatically.
+func (ms *ModerationService) record(report model.Report, moderatorId string, action string, note string) error {
+	moderationAction := model.ModerationAction{
+		ID:           helper.GenerateID(),
+		ReportID:     &report.ID,
+		TargetType:   report.TargetType,
+		TargetID:     report.TargetID,
+		TargetUserID: report.TargetUserID,
+		Action:       action,
+		Note:         note,
+	}
+	if moderatorId != "" {
+		moderationAction.ModeratorID = &moderatorId
+	}
+
+	_, err := ms.ModerationActionRepository.Save(moderationAction)
+	return err
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length])
+}
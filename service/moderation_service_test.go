@@ -0,0 +1,349 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository/mocks"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestModerationService_ReportPhoto(t *testing.T) {
+	reportRepository := mocks.NewIReportRepository(t)
+	moderationActionRepository := mocks.NewIModerationActionRepository(t)
+	photoRepository := mocks.NewIPhotoRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(userRepository, nil, photoRepository, blockRepository, nil)
+
+	photo := model.Photo{ID: "1", UserID: "2", Title: "Spam", PhotoURL: "https://img/1.jpg"}
+
+	type args struct {
+		request    model.ReportCreateRequest
+		photoId    string
+		reporterId string
+	}
+	tests := []struct {
+		name     string
+		ms       *ModerationService
+		args     args
+		want     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success (Below threshold)",
+			ms: &ModerationService{
+				ReportRepository:           reportRepository,
+				ModerationActionRepository: moderationActionRepository,
+				PhotoRepository:            photoRepository,
+				VisibilityPolicy:           visibilityPolicy,
+				AutoHideThreshold:          3,
+			},
+			args: args{
+				request:    model.ReportCreateRequest{Reason: model.ReportReasonSpam},
+				photoId:    "1",
+				reporterId: "1",
+			},
+			want: model.ReportStatusOpen,
+			mockFunc: func() {
+				photoRepository.On("GetOne", "1").Return(photo, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				reportRepository.On("Save", mock.Anything).Return(func(report model.Report) (model.Report, error) {
+					return report, nil
+				}).Once()
+				reportRepository.On("CountOpenByTarget", model.ReportTargetPhoto, "1").Return(int64(2), nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Success (Threshold reached hides photo)",
+			ms: &ModerationService{
+				ReportRepository:           reportRepository,
+				ModerationActionRepository: moderationActionRepository,
+				PhotoRepository:            photoRepository,
+				VisibilityPolicy:           visibilityPolicy,
+				AutoHideThreshold:          3,
+			},
+			args: args{
+				request:    model.ReportCreateRequest{Reason: model.ReportReasonSpam},
+				photoId:    "1",
+				reporterId: "3",
+			},
+			want: model.ReportStatusOpen,
+			mockFunc: func() {
+				photoRepository.On("GetOne", "1").Return(photo, nil).Twice()
+				blockRepository.On("GetBlockedIDs", "3", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				reportRepository.On("Save", mock.Anything).Return(func(report model.Report) (model.Report, error) {
+					return report, nil
+				}).Once()
+				reportRepository.On("CountOpenByTarget", model.ReportTargetPhoto, "1").Return(int64(3), nil).Once()
+				photoRepository.On("UpdateHidden", "1", true).Return(nil).Once()
+				moderationActionRepository.
+					On("Save", mock.MatchedBy(func(action model.ModerationAction) bool {
+						return action.Action == model.ModerationActionAutoHide && action.ModeratorID == nil && action.TargetUserID == "2"
+					})).
+					Return(model.ModerationAction{}, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #3 - Success (Past threshold hides photo a moderator left visible)",
+			ms: &ModerationService{
+				ReportRepository:           reportRepository,
+				ModerationActionRepository: moderationActionRepository,
+				PhotoRepository:            photoRepository,
+				VisibilityPolicy:           visibilityPolicy,
+				AutoHideThreshold:          3,
+			},
+			args: args{
+				request:    model.ReportCreateRequest{Reason: model.ReportReasonSpam},
+				photoId:    "1",
+				reporterId: "4",
+			},
+			want: model.ReportStatusOpen,
+			mockFunc: func() {
+				photoRepository.On("GetOne", "1").Return(photo, nil).Twice()
+				blockRepository.On("GetBlockedIDs", "4", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				reportRepository.On("Save", mock.Anything).Return(func(report model.Report) (model.Report, error) {
+					return report, nil
+				}).Once()
+				reportRepository.On("CountOpenByTarget", model.ReportTargetPhoto, "1").Return(int64(4), nil).Once()
+				photoRepository.On("UpdateHidden", "1", true).Return(nil).Once()
+				moderationActionRepository.
+					On("Save", mock.MatchedBy(func(action model.ModerationAction) bool {
+						return action.Action == model.ModerationActionAutoHide && action.Note == "Reported by 4 users"
+					})).
+					Return(model.ModerationAction{}, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #4 - Success (Past threshold leaves hidden photo alone)",
+			ms: &ModerationService{
+				ReportRepository:           reportRepository,
+				ModerationActionRepository: moderationActionRepository,
+				PhotoRepository:            photoRepository,
+				VisibilityPolicy:           visibilityPolicy,
+				AutoHideThreshold:          3,
+			},
+			args: args{
+				request:    model.ReportCreateRequest{Reason: model.ReportReasonSpam},
+				photoId:    "1",
+				reporterId: "5",
+			},
+			want: model.ReportStatusOpen,
+			mockFunc: func() {
+				hiddenPhoto := photo
+				hiddenPhoto.Hidden = true
+
+				photoRepository.On("GetOne", "1").Return(photo, nil).Once()
+				blockRepository.On("GetBlockedIDs", "5", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				reportRepository.On("Save", mock.Anything).Return(func(report model.Report) (model.Report, error) {
+					return report, nil
+				}).Once()
+				reportRepository.On("CountOpenByTarget", model.ReportTargetPhoto, "1").Return(int64(5), nil).Once()
+				photoRepository.On("GetOne", "1").Return(hiddenPhoto, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #5 - Failed (Own photo)",
+			ms: &ModerationService{
+				ReportRepository:           reportRepository,
+				ModerationActionRepository: moderationActionRepository,
+				PhotoRepository:            photoRepository,
+				VisibilityPolicy:           visibilityPolicy,
+				AutoHideThreshold:          3,
+			},
+			args: args{
+				request:    model.ReportCreateRequest{Reason: model.ReportReasonSpam},
+				photoId:    "1",
+				reporterId: "2",
+			},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "1").Return(photo, nil).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.ms.ReportPhoto(tt.args.request, tt.args.photoId, tt.args.reporterId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ModerationService.ReportPhoto() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Status != tt.want {
+				t.Errorf("ModerationService.ReportPhoto() status = %v, want %v", got.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestModerationService_Resolve(t *testing.T) {
+	reportRepository := mocks.NewIReportRepository(t)
+	moderationActionRepository := mocks.NewIModerationActionRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+
+	otherModerator := "9"
+
+	type args struct {
+		request     model.ReportResolveRequest
+		id          string
+		moderatorId string
+	}
+	tests := []struct {
+		name     string
+		ms       *ModerationService
+		args     args
+		want     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success (Suspend with default days)",
+			ms: &ModerationService{
+				ReportRepository:           reportRepository,
+				ModerationActionRepository: moderationActionRepository,
+				UserRepository:             userRepository,
+			},
+			args: args{
+				request:     model.ReportResolveRequest{Action: model.ModerationActionSuspend},
+				id:          "r1",
+				moderatorId: "8",
+			},
+			want: model.ReportStatusResolved,
+			mockFunc: func() {
+				reportRepository.On("GetOne", "r1").Return(model.Report{ID: "r1", TargetType: model.ReportTargetUser, TargetID: "2", TargetUserID: "2", Status: model.ReportStatusOpen}, nil).Once()
+				userRepository.On("UpdateSuspension", "2", mock.Anything).Return(nil).Once()
+				moderationActionRepository.
+					On("Save", mock.MatchedBy(func(action model.ModerationAction) bool {
+						return action.Action == model.ModerationActionSuspend && *action.ModeratorID == "8"
+					})).
+					Return(model.ModerationAction{}, nil).Once()
+				reportRepository.On("CloseByTarget", model.ReportTargetUser, "2", model.ReportStatusResolved, "8").Return(nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Failed (Hide a user)",
+			ms: &ModerationService{
+				ReportRepository:           reportRepository,
+				ModerationActionRepository: moderationActionRepository,
+				UserRepository:             userRepository,
+			},
+			args: args{
+				request:     model.ReportResolveRequest{Action: model.ModerationActionHide},
+				id:          "r1",
+				moderatorId: "8",
+			},
+			mockFunc: func() {
+				reportRepository.On("GetOne", "r1").Return(model.Report{ID: "r1", TargetType: model.ReportTargetUser, TargetID: "2", TargetUserID: "2", Status: model.ReportStatusOpen}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #3 - Failed (Claimed by another moderator)",
+			ms: &ModerationService{
+				ReportRepository:           reportRepository,
+				ModerationActionRepository: moderationActionRepository,
+				UserRepository:             userRepository,
+			},
+			args: args{
+				request:     model.ReportResolveRequest{Action: model.ModerationActionWarn},
+				id:          "r2",
+				moderatorId: "8",
+			},
+			mockFunc: func() {
+				reportRepository.On("GetOne", "r2").Return(model.Report{ID: "r2", Status: model.ReportStatusClaimed, ModeratorID: &otherModerator}, nil).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := tt.ms.Resolve(tt.args.request, tt.args.id, tt.args.moderatorId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ModerationService.Resolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Status != tt.want {
+				t.Errorf("ModerationService.Resolve() status = %v, want %v", got.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestModerationService_GetReposts(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ms := &ModerationService{
+		PhotoRepository: photoRepository,
+	}
+
+	hash := int64(0x0f0f)
+	createdAt := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	photo := model.Photo{ID: "1", UserID: "2", PerceptualHash: &hash, CreatedAt: createdAt}
+
+	tests := []struct {
+		name     string
+		request  model.PhotoRepostRequest
+		mockFunc func()
+		want     []model.PhotoRepostResponse
+		wantErr  bool
+	}{
+		{
+			name:    "Case #1 - Success (Own photos skipped)",
+			request: model.PhotoRepostRequest{},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "1").Return(photo, nil).Once()
+				photoRepository.On("GetSimilar", hash, model.DefaultRepostDistance, "", 2*model.MaxRepostResults).Return([]model.PhotoSimilarity{
+					{Photo: photo, Distance: 0},
+					{Photo: model.Photo{ID: "3", UserID: "4", CreatedAt: createdAt.Add(-time.Hour)}, Distance: 1},
+					{Photo: model.Photo{ID: "5", UserID: "2"}, Distance: 2},
+					{Photo: model.Photo{ID: "6", UserID: "7", CreatedAt: createdAt.Add(time.Hour)}, Distance: 4},
+				}, nil).Once()
+			},
+			want: []model.PhotoRepostResponse{
+				{ID: "3", UserID: "4", Distance: 1, PostedBefore: true, CreatedAt: createdAt.Add(-time.Hour)},
+				{ID: "6", UserID: "7", Distance: 4, PostedBefore: false, CreatedAt: createdAt.Add(time.Hour)},
+			},
+		},
+		{
+			name:     "Case #2 - Failed (Distance out of range)",
+			request:  model.PhotoRepostRequest{MaxDistance: model.MaxRepostDistance + 1},
+			mockFunc: func() {},
+			wantErr:  true,
+		},
+		{
+			name:    "Case #3 - Failed (Photo not uploaded)",
+			request: model.PhotoRepostRequest{MaxDistance: 4},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "1").Return(model.Photo{ID: "1", UserID: "2"}, nil).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ms.GetReposts(tt.request, "1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ModerationService.GetReposts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got.Reposts, tt.want) {
+				t.Errorf("ModerationService.GetReposts() = %v, want %v", got.Reposts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"image"
+	"math/bits"
+)
+
+// differenceHash computes a 64 bit dHash: the image is shrunk to 9x8 grey
+// blocks and each bit tells whether a block is darker than its right
+// neighbour. Re-encoding, resizing and small edits flip few bits, so near
+// duplicates sit within a small Hamming distance.
+func differenceHash(img *image.NRGBA) uint64 {
+	const width, height = 9, 8
+
+	bounds := img.Bounds()
+	var grey [height][width]float64
+	for by := 0; by < height; by++ {
+		y0, y1 := blockRange(bounds.Dy(), by, height)
+		for bx := 0; bx < width; bx++ {
+			x0, x1 := blockRange(bounds.Dx(), bx, width)
+
+			var sum float64
+			for y := y0; y < y1; y++ {
+				offset := img.PixOffset(bounds.Min.X+x0, bounds.Min.Y+y)
+				for x := x0; x < x1; x++ {
+					pixel := img.Pix[offset : offset+3]
+					sum += 0.299*float64(pixel[0]) + 0.587*float64(pixel[1]) + 0.114*float64(pixel[2])
+					offset += 4
+				}
+			}
+			grey[by][bx] = sum / float64((y1-y0)*(x1-x0))
+		}
+	}
+
+	var hash uint64
+	for by := 0; by < height; by++ {
+		for bx := 0; bx < width-1; bx++ {
+			hash <<= 1
+			if grey[by][bx] < grey[by][bx+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// blockRange splits size into count blocks and returns the bounds of block
+// index, at least one pixel wide.
+func blockRange(size int, index int, count int) (int, int) {
+	start := index * size / count
+	end := (index + 1) * size / count
+	if end <= start {
+		end = start + 1
+	}
+	if end > size {
+		start, end = size-1, size
+	}
+	return start, end
+}
+
+func hammingDistance(a uint64, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -1,22 +1,48 @@
 package service
 
 import (
+	"errors"
+	"math"
 	"mygram/helper"
 	"mygram/model"
 	"mygram/repository"
+	"sort"
+	"time"
 )
 
 type PhotoService struct {
-	PhotoRepository repository.IPhotoRepository
+	PhotoRepository    repository.IPhotoRepository
+	BookmarkRepository repository.IBookmarkRepository
+	ReactionRepository repository.IReactionRepository
+	ImageRepository    repository.IImageRepository
+	VisibilityPolicy   *VisibilityPolicy
+	CaptionFilter      *FilterPipeline
+	Captioner          Captioner
+	// MaxUploadSize is the largest accepted upload in bytes.
+	MaxUploadSize int64
+	// DuplicateDistance is the Hamming distance under which an upload looks
+	// like an earlier photo of the same user, negative turns the check off.
+	// DuplicateAction is whether such uploads are warned about or rejected.
+	DuplicateDistance int
+	DuplicateAction   string
 }
 
-func NewPhotoService(photoRepository repository.IPhotoRepository) *PhotoService {
+func NewPhotoService(photoRepository repository.IPhotoRepository, bookmarkRepository repository.IBookmarkRepository, reactionRepository repository.IReactionRepository, imageRepository repository.IImageRepository, visibilityPolicy *VisibilityPolicy, captionFilter *FilterPipeline, captioner Captioner, maxUploadSize int64, duplicateDistance int, duplicateAction string) *PhotoService {
 	return &PhotoService{
-		PhotoRepository: photoRepository,
+		PhotoRepository:    photoRepository,
+		BookmarkRepository: bookmarkRepository,
+		ReactionRepository: reactionRepository,
+		ImageRepository:    imageRepository,
+		VisibilityPolicy:   visibilityPolicy,
+		CaptionFilter:      captionFilter,
+		Captioner:          captioner,
+		MaxUploadSize:      maxUploadSize,
+		DuplicateDistance:  duplicateDistance,
+		DuplicateAction:    duplicateAction,
 	}
 }
 
-func (ps *PhotoService) GetAll() ([]model.PhotoResponse, error) {
+func (ps *PhotoService) GetAll(userId string) ([]model.PhotoResponse, error) {
 	photosResponse := make([]model.PhotoResponse, 0)
 
 	res, err := ps.PhotoRepository.Get()
@@ -25,92 +51,308 @@ func (ps *PhotoService) GetAll() ([]model.PhotoResponse, error) {
 		return []model.PhotoResponse{}, err
 	}
 
+	res, err = ps.VisibilityPolicy.FilterFeedPhotos(userId, res)
+	if err != nil {
+		return []model.PhotoResponse{}, err
+	}
+
+	photoIds := make([]string, 0, len(res))
+	commentIds := make([]string, 0)
 	for _, val := range res {
-		commentResponse := make([]model.CommentInPhotoResponse, 0)
+		photoIds = append(photoIds, val.ID)
 		for _, comment := range val.Comments {
+			commentIds = append(commentIds, comment.ID)
+		}
+	}
+	bookmarked, err := ps.bookmarkedPhotoIDs(userId, photoIds)
+	if err != nil {
+		return []model.PhotoResponse{}, err
+	}
+	reactions, err := reactionCounts(ps.ReactionRepository, commentIds)
+	if err != nil {
+		return []model.PhotoResponse{}, err
+	}
+
+	for _, val := range res {
+		commentResponse := make([]model.CommentInPhotoResponse, 0)
+		for _, comment := range pinnedFirst(val.Comments) {
 			commentResponse = append(commentResponse, model.CommentInPhotoResponse{
 				ID:        comment.ID,
 				UserID:    comment.UserID,
 				Message:   comment.Message,
+				Pinned:    comment.PinnedAt != nil,
+				Reactions: reactionsOf(reactions, comment.ID),
 				CreatedAt: comment.CreatedAt,
 				UpdatedAt: comment.UpdatedAt,
 			})
 		}
 		photosResponse = append(photosResponse, model.PhotoResponse{
-			ID:        val.ID,
-			UserID:    val.UserID,
-			Title:     val.Title,
-			Caption:   val.Caption,
-			PhotoURL:  val.PhotoURL,
-			Comments:  commentResponse,
-			CreatedAt: val.CreatedAt,
-			UpdatedAt: val.UpdatedAt,
+			ID:             val.ID,
+			UserID:         val.UserID,
+			Title:          val.Title,
+			Caption:        val.Caption,
+			PhotoURL:       val.PhotoURL,
+			Media:          model.ToPhotoMediaResponse(val),
+			AltText:        model.CoverAltText(val),
+			BookmarkedByMe: bookmarked[val.ID],
+			Location:       model.ToPhotoLocationResponse(val),
+			Metadata:       model.ToPhotoMetadataResponse(val, userId),
+			CommentPolicy:  val.CommentPolicy,
+			Status:         val.Status,
+			PublishAt:      val.PublishAt,
+			Comments:       commentResponse,
+			CreatedAt:      val.CreatedAt,
+			UpdatedAt:      val.UpdatedAt,
 		})
 	}
 
 	return photosResponse, nil
 }
 
-func (ps *PhotoService) GetById(id string) (model.PhotoResponse, error) {
+func (ps *PhotoService) GetById(id string, userId string) (model.PhotoResponse, error) {
 	photo, err := ps.PhotoRepository.GetOne(id)
 
 	if err != nil {
 		return model.PhotoResponse{}, err
 	}
 
-	var commentResponse []model.CommentInPhotoResponse
+	visible, err := ps.VisibilityPolicy.FilterPhotos(userId, []model.Photo{photo})
+	if err != nil {
+		return model.PhotoResponse{}, err
+	}
+	if len(visible) == 0 {
+		return model.PhotoResponse{}, model.ErrorNotFound
+	}
+	photo = visible[0]
+
+	bookmarked, err := ps.bookmarkedPhotoIDs(userId, []string{photo.ID})
+	if err != nil {
+		return model.PhotoResponse{}, err
+	}
+
+	commentIds := make([]string, 0, len(photo.Comments))
 	for _, comment := range photo.Comments {
+		commentIds = append(commentIds, comment.ID)
+	}
+	reactions, err := reactionCounts(ps.ReactionRepository, commentIds)
+	if err != nil {
+		return model.PhotoResponse{}, err
+	}
+
+	var commentResponse []model.CommentInPhotoResponse
+	for _, comment := range pinnedFirst(photo.Comments) {
 		commentResponse = append(commentResponse, model.CommentInPhotoResponse{
 			ID:        comment.ID,
 			UserID:    comment.UserID,
 			Message:   comment.Message,
+			Pinned:    comment.PinnedAt != nil,
+			Reactions: reactionsOf(reactions, comment.ID),
 			CreatedAt: comment.CreatedAt,
 			UpdatedAt: comment.UpdatedAt,
 		})
 	}
 
 	return model.PhotoResponse{
-		ID:        photo.ID,
-		UserID:    photo.UserID,
-		Title:     photo.Title,
-		Caption:   photo.Caption,
-		PhotoURL:  photo.PhotoURL,
-		Comments:  commentResponse,
-		CreatedAt: photo.CreatedAt,
-		UpdatedAt: photo.UpdatedAt,
+		ID:             photo.ID,
+		UserID:         photo.UserID,
+		Title:          photo.Title,
+		Caption:        photo.Caption,
+		PhotoURL:       photo.PhotoURL,
+		Media:          model.ToPhotoMediaResponse(photo),
+		AltText:        model.CoverAltText(photo),
+		BookmarkedByMe: bookmarked[photo.ID],
+		Location:       model.ToPhotoLocationResponse(photo),
+		Metadata:       model.ToPhotoMetadataResponse(photo, userId),
+		CommentPolicy:  photo.CommentPolicy,
+		Status:         photo.Status,
+		PublishAt:      photo.PublishAt,
+		Comments:       commentResponse,
+		CreatedAt:      photo.CreatedAt,
+		UpdatedAt:      photo.UpdatedAt,
 	}, nil
 }
 
 func (ps *PhotoService) Add(request model.PhotoCreateRequest, userId string) (model.PhotoCreateResponse, error) {
+	status, publishAt, err := model.ResolvePhotoStatus(request.Status, request.PublishAt, time.Now())
+	if err != nil {
+		return model.PhotoCreateResponse{}, err
+	}
+
+	items, err := model.ResolvePhotoMedia(request.Media, request.PhotoURL, request.AltText)
+	if err != nil {
+		return model.PhotoCreateResponse{}, err
+	}
+
 	id := helper.GenerateID()
+	media := toPhotoMedia(id, items)
+	suggestAltText(ps.Captioner, media, request.Title, request.Caption)
+
 	photo := model.Photo{
-		ID:       id,
-		Title:    request.Title,
-		Caption:  request.Caption,
-		PhotoURL: request.PhotoURL,
-		UserID:   userId,
+		ID:        id,
+		Title:     request.Title,
+		Caption:   request.Caption,
+		PhotoURL:  media[0].URL,
+		Media:     media,
+		AltText:   model.JoinAltText(media),
+		Status:    status,
+		PublishAt: publishAt,
+	}
+
+	if request.Location != nil && !request.Location.IsEmpty() {
+		err := request.Location.Validate()
+		if err != nil {
+			return model.PhotoCreateResponse{}, err
+		}
+		photo.Latitude = request.Location.Latitude
+		photo.Longitude = request.Location.Longitude
+		photo.PlaceName = request.Location.PlaceName
 	}
 
+	return ps.create(photo, userId)
+}
+
+// Upload stores an uploaded JPEG as a new photo. The stored file is
+// re-encoded upright and without EXIF, the camera information read from it
+// is kept as the photo metadata. Uploads looking like an earlier photo of the
+// same user are rejected or flagged in DuplicateOf, depending on
+// DuplicateAction.
+func (ps *PhotoService) Upload(request model.PhotoUploadRequest, data []byte, userId string) (model.PhotoCreateResponse, error) {
+	if int64(len(data)) > ps.MaxUploadSize {
+		return model.PhotoCreateResponse{}, model.ErrorImageTooLarge
+	}
+
+	status, publishAt, err := model.ResolvePhotoStatus(request.Status, request.PublishAt, time.Now())
+	if err != nil {
+		return model.PhotoCreateResponse{}, err
+	}
+
+	altText := model.NormalizeAltText(request.AltText)
+	err = model.ValidateAltText(altText)
+	if err != nil {
+		return model.PhotoCreateResponse{}, err
+	}
+
+	processed, err := ProcessJPEG(data)
+	if err != nil {
+		return model.PhotoCreateResponse{}, err
+	}
+
+	hash := int64(processed.Hash)
+	duplicateOf, err := ps.duplicatesOf(hash, userId)
+	if err != nil {
+		return model.PhotoCreateResponse{}, err
+	}
+	if len(duplicateOf) > 0 && ps.DuplicateAction == model.DuplicatePhotoReject {
+		return model.PhotoCreateResponse{}, model.ErrorDuplicatePhoto
+	}
+
+	id := helper.GenerateID()
+	fileName := imageFileName(id)
+	photoURL, err := ps.ImageRepository.Save(fileName, processed.Data)
+	if err != nil {
+		return model.PhotoCreateResponse{}, err
+	}
+
+	metadata := processed.Metadata
+	metadata.PhotoID = id
+	media := toPhotoMedia(id, []model.PhotoMediaRequest{{URL: photoURL, AltText: altText}})
+	suggestAltText(ps.Captioner, media, request.Title, request.Caption)
+
+	photo := model.Photo{
+		ID:            id,
+		Title:         request.Title,
+		Caption:       request.Caption,
+		PhotoURL:      photoURL,
+		Media:         media,
+		AltText:       model.JoinAltText(media),
+		ShareMetadata: request.ShareMetadata,
+		Metadata:      &metadata,
+		Status:        status,
+		PublishAt:     publishAt,
+
+		PerceptualHash: &hash,
+	}
+
+	res, err := ps.create(photo, userId)
+	if err != nil {
+		ps.ImageRepository.Delete(fileName)
+		return model.PhotoCreateResponse{}, err
+	}
+	res.DuplicateOf = duplicateOf
+	return res, nil
+}
+
+// maxDuplicatesListed caps the earlier photos named in an upload warning.
+const maxDuplicatesListed = 5
+
+// duplicatesOf returns the ids of the user's photos whose perceptual hash is
+// within DuplicateDistance of hash.
+func (ps *PhotoService) duplicatesOf(hash int64, userId string) ([]string, error) {
+	if ps.DuplicateDistance < 0 {
+		return nil, nil
+	}
+
+	res, err := ps.PhotoRepository.GetSimilar(hash, ps.DuplicateDistance, userId, maxDuplicatesListed)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, val := range res {
+		ids = append(ids, val.Photo.ID)
+	}
+	return ids, nil
+}
+
+// create runs the caption filter and saves a new photo of userId.
+func (ps *PhotoService) create(photo model.Photo, userId string) (model.PhotoCreateResponse, error) {
+	filterResult, err := ps.CaptionFilter.Check(model.FilterContent{
+		UserID: userId,
+		Kind:   model.FilterContentCaption,
+		Text:   photo.Caption,
+	})
+	if err != nil {
+		return model.PhotoCreateResponse{}, err
+	}
+
+	photo.UserID = userId
+	photo.Hidden = filterResult.Verdict == model.FilterVerdictHold
+	photo.CommentPolicy = model.CommentPolicyEveryone
+
 	res, err := ps.PhotoRepository.Save(photo)
 	if err != nil {
 		return model.PhotoCreateResponse{}, err
 	}
 
+	if res.Hidden {
+		err = ps.CaptionFilter.Hold(filterResult, model.ReportTargetPhoto, res.ID, userId, res.Caption)
+		if err != nil {
+			return model.PhotoCreateResponse{}, err
+		}
+	}
+
 	return model.PhotoCreateResponse{
-		ID:        res.ID,
-		UserID:    res.UserID,
-		Title:     res.Title,
-		Caption:   res.Caption,
-		PhotoURL:  res.PhotoURL,
-		CreatedAt: res.CreatedAt,
+		ID:            res.ID,
+		UserID:        res.UserID,
+		Title:         res.Title,
+		Caption:       res.Caption,
+		PhotoURL:      res.PhotoURL,
+		Media:         model.ToPhotoMediaResponse(res),
+		AltText:       model.CoverAltText(res),
+		Location:      model.ToPhotoLocationResponse(res),
+		Metadata:      model.ToPhotoMetadataResponse(res, userId),
+		ShareMetadata: res.ShareMetadata,
+		PendingReview: res.Hidden,
+		Status:        res.Status,
+		PublishAt:     res.PublishAt,
+		CreatedAt:     res.CreatedAt,
 	}, nil
-
 }
 
 func (ps *PhotoService) UpdateById(request model.PhotoUpdateRequest, id string, userId string) (model.PhotoUpdateResponse, error) {
 	getById, err := ps.PhotoRepository.GetOne(id)
 	if err != nil {
-		if err != model.ErrorNotFound {
+		if !errors.Is(err, model.ErrorNotFound) {
 			return model.PhotoUpdateResponse{}, err
 		}
 		return model.PhotoUpdateResponse{}, model.ErrorNotFound
@@ -120,32 +362,248 @@ func (ps *PhotoService) UpdateById(request model.PhotoUpdateRequest, id string,
 		return model.PhotoUpdateResponse{}, model.ErrorForbiddenAccess
 	}
 
-	photo := model.Photo{
-		Title:    request.Title,
-		Caption:  request.Caption,
-		PhotoURL: request.PhotoURL,
+	if request.Location != nil && !request.Location.IsEmpty() {
+		err = request.Location.Validate()
+		if err != nil {
+			return model.PhotoUpdateResponse{}, err
+		}
+	}
+
+	media, err := updatedPhotoMedia(request, getById)
+	if err != nil {
+		return model.PhotoUpdateResponse{}, err
+	}
+	suggestAltText(ps.Captioner, media, request.Title, request.Caption)
+
+	status, publishAt, statusChanged, err := updatedPhotoStatus(request, getById, time.Now())
+	if err != nil {
+		return model.PhotoUpdateResponse{}, err
+	}
+
+	filterResult, err := ps.CaptionFilter.Check(model.FilterContent{
+		UserID: userId,
+		Kind:   model.FilterContentCaption,
+		Text:   request.Caption,
+	})
+	if err != nil {
+		return model.PhotoUpdateResponse{}, err
+	}
+
+	// Every change is written in one transaction, so a failing write never
+	// leaves the photo half updated or a held caption visible.
+	update := model.PhotoUpdate{
+		Title:         request.Title,
+		Caption:       request.Caption,
+		Location:      request.Location,
+		Media:         media,
+		ShareMetadata: request.ShareMetadata,
+		Hide:          filterResult.Verdict == model.FilterVerdictHold,
+	}
+	if statusChanged {
+		update.Status, update.PublishAt = &status, publishAt
 	}
 
-	res, err := ps.PhotoRepository.Update(photo, id)
+	res, err := ps.PhotoRepository.Update(update, id)
 	if err != nil {
 		return model.PhotoUpdateResponse{}, err
 	}
+	res.PhotoURL, res.Media, res.AltText = getById.PhotoURL, getById.Media, getById.AltText
+	res.Hidden = getById.Hidden
+	res.ShareMetadata = getById.ShareMetadata
+	res.Status, res.PublishAt = getById.Status, getById.PublishAt
+	res.Latitude, res.Longitude, res.PlaceName = getById.Latitude, getById.Longitude, getById.PlaceName
+
+	if request.Location != nil {
+		res.Latitude, res.Longitude, res.PlaceName = request.Location.Latitude, request.Location.Longitude, request.Location.PlaceName
+	}
+	if media != nil {
+		res.PhotoURL, res.Media, res.AltText = media[0].URL, media, model.JoinAltText(media)
+	}
+	if request.ShareMetadata != nil {
+		res.ShareMetadata = *request.ShareMetadata
+	}
+	if statusChanged {
+		res.Status, res.PublishAt = status, publishAt
+	}
+
+	if update.Hide {
+		err = ps.CaptionFilter.Hold(filterResult, model.ReportTargetPhoto, id, userId, res.Caption)
+		if err != nil {
+			return model.PhotoUpdateResponse{}, err
+		}
+		res.Hidden = true
+	}
 
 	return model.PhotoUpdateResponse{
-		ID:        res.ID,
-		UserID:    res.UserID,
-		Title:     res.Title,
-		Caption:   res.Caption,
-		PhotoURL:  res.PhotoURL,
-		CreatedAt: res.CreatedAt,
-		UpdatedAt: res.UpdatedAt,
+		ID:            res.ID,
+		UserID:        res.UserID,
+		Title:         res.Title,
+		Caption:       res.Caption,
+		PhotoURL:      res.PhotoURL,
+		Media:         model.ToPhotoMediaResponse(res),
+		AltText:       model.CoverAltText(res),
+		Location:      model.ToPhotoLocationResponse(res),
+		ShareMetadata: res.ShareMetadata,
+		PendingReview: res.Hidden,
+		Status:        res.Status,
+		PublishAt:     res.PublishAt,
+		CreatedAt:     res.CreatedAt,
+		UpdatedAt:     res.UpdatedAt,
+	}, nil
+}
+
+// Nearby lists photos around a point, closest first. Only the closest
+// MaxNearbyResults photos are ranked, the ones the viewer may see are paginated.
+func (ps *PhotoService) Nearby(request model.PhotoNearbyRequest, userId string) (model.PhotoNearbyListResponse, error) {
+	photosResponse := make([]model.PhotoNearbyResponse, 0)
+	pagination := request.PaginationRequest.Normalize()
+
+	if request.Latitude == nil || request.Longitude == nil {
+		return model.PhotoNearbyListResponse{}, model.ErrorIncompleteLocation
+	}
+	err := model.ValidateCoordinates(*request.Latitude, *request.Longitude)
+	if err != nil {
+		return model.PhotoNearbyListResponse{}, err
+	}
+
+	radiusKm := request.RadiusKm
+	if radiusKm == 0 {
+		radiusKm = model.DefaultNearbyRadiusKm
+	}
+	if radiusKm < 0 || radiusKm > model.MaxNearbyRadiusKm {
+		return model.PhotoNearbyListResponse{}, model.ErrorInvalidRadius
+	}
+
+	res, err := ps.PhotoRepository.GetNearby(*request.Latitude, *request.Longitude, radiusKm, model.MaxNearbyResults)
+	if err != nil {
+		return model.PhotoNearbyListResponse{}, err
+	}
+
+	photos := make([]model.Photo, 0, len(res))
+	for _, val := range res {
+		photos = append(photos, val.Photo)
+	}
+	visible, err := ps.VisibilityPolicy.FilterPhotos(userId, photos)
+	if err != nil {
+		return model.PhotoNearbyListResponse{}, err
+	}
+	visibleIds := make(map[string]bool, len(visible))
+	for _, photo := range visible {
+		visibleIds[photo.ID] = true
+	}
+
+	nearby := make([]model.PhotoDistance, 0, len(visible))
+	for _, val := range res {
+		if visibleIds[val.Photo.ID] {
+			nearby = append(nearby, val)
+		}
+	}
+
+	start := pagination.Offset()
+	if start > len(nearby) {
+		start = len(nearby)
+	}
+	end := start + pagination.Limit
+	if end > len(nearby) {
+		end = len(nearby)
+	}
+
+	for _, val := range nearby[start:end] {
+		photosResponse = append(photosResponse, model.PhotoNearbyResponse{
+			ID:         val.Photo.ID,
+			UserID:     val.Photo.UserID,
+			Title:      val.Photo.Title,
+			Caption:    val.Photo.Caption,
+			PhotoURL:   val.Photo.PhotoURL,
+			Location:   *model.ToPhotoLocationResponse(val.Photo),
+			DistanceKm: math.Round(val.DistanceKm*100) / 100,
+		})
+	}
+
+	return model.PhotoNearbyListResponse{
+		Photos:     photosResponse,
+		Pagination: model.ToPaginationResponse(pagination, int64(len(nearby))),
+	}, nil
+}
+
+// MissingAltText lists the user's photos with media lacking alt text, or only
+// carrying a suggestion, newest first.
+func (ps *PhotoService) MissingAltText(request model.PaginationRequest, userId string) (model.PhotoMissingAltTextListResponse, error) {
+	photosResponse := make([]model.PhotoMissingAltTextResponse, 0)
+	pagination := request.Normalize()
+
+	res, total, err := ps.PhotoRepository.GetMissingAltText(userId, pagination)
+	if err != nil {
+		return model.PhotoMissingAltTextListResponse{}, err
+	}
+
+	for _, photo := range res {
+		photosResponse = append(photosResponse, model.ToPhotoMissingAltTextResponse(photo))
+	}
+
+	return model.PhotoMissingAltTextListResponse{
+		Photos:     photosResponse,
+		Pagination: model.ToPaginationResponse(pagination, total),
+	}, nil
+}
+
+// Drafts lists the user's drafts and scheduled photos, the ones due first.
+func (ps *PhotoService) Drafts(request model.PaginationRequest, userId string) (model.PhotoDraftListResponse, error) {
+	photosResponse := make([]model.PhotoDraftResponse, 0)
+	pagination := request.Normalize()
+
+	res, total, err := ps.PhotoRepository.GetUnpublished(userId, pagination)
+	if err != nil {
+		return model.PhotoDraftListResponse{}, err
+	}
+
+	for _, photo := range res {
+		photosResponse = append(photosResponse, model.PhotoDraftResponse{
+			ID:        photo.ID,
+			Title:     photo.Title,
+			Caption:   photo.Caption,
+			PhotoURL:  photo.PhotoURL,
+			Media:     model.ToPhotoMediaResponse(photo),
+			Status:    photo.Status,
+			PublishAt: photo.PublishAt,
+			CreatedAt: photo.CreatedAt,
+			UpdatedAt: photo.UpdatedAt,
+		})
+	}
+
+	return model.PhotoDraftListResponse{
+		Photos:     photosResponse,
+		Pagination: model.ToPaginationResponse(pagination, total),
+	}, nil
+}
+
+// UpdateCommentSettings sets who may comment on the owner's photo. Existing
+// comments stay, the owner can delete them separately.
+func (ps *PhotoService) UpdateCommentSettings(request model.PhotoCommentSettingsRequest, id string, userId string) (model.PhotoCommentSettingsResponse, error) {
+	photo, err := ps.PhotoRepository.GetOne(id)
+	if err != nil {
+		return model.PhotoCommentSettingsResponse{}, err
+	}
+
+	if photo.UserID != userId {
+		return model.PhotoCommentSettingsResponse{}, model.ErrorForbiddenAccess
+	}
+
+	err = ps.PhotoRepository.UpdateCommentPolicy(id, request.CommentPolicy)
+	if err != nil {
+		return model.PhotoCommentSettingsResponse{}, err
+	}
+
+	return model.PhotoCommentSettingsResponse{
+		PhotoID:       photo.ID,
+		CommentPolicy: request.CommentPolicy,
 	}, nil
 }
 
 func (ps *PhotoService) DeleteById(id string, userId string) error {
 	getById, err := ps.PhotoRepository.GetOne(id)
 	if err != nil {
-		if err != model.ErrorNotFound {
+		if !errors.Is(err, model.ErrorNotFound) {
 			return err
 		}
 		return model.ErrorNotFound
@@ -161,5 +619,134 @@ func (ps *PhotoService) DeleteById(id string, userId string) error {
 		return err
 	}
 
+	if getById.Metadata != nil {
+		return ps.ImageRepository.Delete(imageFileName(id))
+	}
+
 	return nil
 }
+
+// toPhotoMedia numbers media items in request order.
+func toPhotoMedia(photoId string, items []model.PhotoMediaRequest) []model.PhotoMedia {
+	media := make([]model.PhotoMedia, 0, len(items))
+	for i, item := range items {
+		media = append(media, model.PhotoMedia{
+			ID:       helper.GenerateID(),
+			PhotoID:  photoId,
+			Position: i,
+			URL:      item.URL,
+			AltText:  item.AltText,
+		})
+	}
+	return media
+}
+
+// updatedPhotoStatus returns the status and publish time requested for photo
+// and whether they differ from the current ones. A draft or scheduled photo
+// can move between both or be published, a published photo stays published.
+func updatedPhotoStatus(request model.PhotoUpdateRequest, photo model.Photo, now time.Time) (string, *time.Time, bool, error) {
+	if (request.Status == "" || request.Status == photo.Status) && request.PublishAt == nil {
+		return photo.Status, photo.PublishAt, false, nil
+	}
+	if photo.IsPublished() {
+		return "", nil, false, model.ErrorPhotoAlreadyPublished
+	}
+
+	requested := request.Status
+	if requested == "" {
+		requested = photo.Status
+	}
+	status, publishAt, err := model.ResolvePhotoStatus(requested, request.PublishAt, now)
+	if err != nil {
+		return "", nil, false, err
+	}
+	return status, publishAt, true, nil
+}
+
+// updatedPhotoMedia returns the media replacing those of photo, or nil when
+// they stay. A media list replaces every item, a new photo URL or alt text
+// only the cover. A new cover drops its alt text, it described the previous
+// image.
+func updatedPhotoMedia(request model.PhotoUpdateRequest, photo model.Photo) ([]model.PhotoMedia, error) {
+	if request.Media != nil {
+		if len(request.Media) == 0 {
+			return nil, model.ErrorInvalidMediaCount
+		}
+		altText := ""
+		if request.AltText != nil {
+			altText = *request.AltText
+		}
+		items, err := model.ResolvePhotoMedia(request.Media, request.PhotoURL, altText)
+		if err != nil {
+			return nil, err
+		}
+		return toPhotoMedia(photo.ID, items), nil
+	}
+
+	coverChanged := request.PhotoURL != "" && request.PhotoURL != photo.PhotoURL
+	if !coverChanged && request.AltText == nil {
+		return nil, nil
+	}
+
+	current := photo.Media
+	if len(current) == 0 {
+		current = []model.PhotoMedia{{PhotoID: photo.ID, URL: photo.PhotoURL, AltText: photo.AltText}}
+	}
+	media := make([]model.PhotoMedia, 0, len(current))
+	for _, item := range current {
+		item.ID = helper.GenerateID()
+		media = append(media, item)
+	}
+
+	cover := &media[0]
+	if coverChanged {
+		if len([]rune(request.PhotoURL)) > 255 {
+			return nil, model.ErrorMediaURLTooLong
+		}
+		cover.URL, cover.AltText, cover.AltTextGenerated = request.PhotoURL, "", false
+	}
+	if request.AltText != nil {
+		altText := model.NormalizeAltText(*request.AltText)
+		err := model.ValidateAltText(altText)
+		if err != nil {
+			return nil, err
+		}
+		cover.AltText, cover.AltTextGenerated = altText, false
+	}
+	return media, nil
+}
+
+// imageFileName is the stored file of an uploaded photo.
+func imageFileName(photoId string) string {
+	return photoId + ".jpg"
+}
+
+// bookmarkedPhotoIDs returns the subset of photoIds bookmarked by userId as a set.
+func (ps *PhotoService) bookmarkedPhotoIDs(userId string, photoIds []string) (map[string]bool, error) {
+	bookmarked := make(map[string]bool)
+
+	res, err := ps.BookmarkRepository.GetBookmarkedPhotoIDs(userId, photoIds)
+	if err != nil {
+		return bookmarked, err
+	}
+
+	for _, photoId := range res {
+		bookmarked[photoId] = true
+	}
+	return bookmarked, nil
+}
+
+// pinnedFirst orders pinned comments first, oldest pin on top, keeping the
+// order of the other comments.
+func pinnedFirst(comments []model.Comment) []model.Comment {
+	sorted := make([]model.Comment, len(comments))
+	copy(sorted, comments)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].PinnedAt == nil || sorted[j].PinnedAt == nil {
+			return sorted[i].PinnedAt != nil && sorted[j].PinnedAt == nil
+		}
+		return sorted[i].PinnedAt.Before(*sorted[j].PinnedAt)
+	})
+	return sorted
+}
@@ -0,0 +1,646 @@
+package service
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPhotoService_Nearby(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(userRepository, nil, photoRepository, blockRepository, nil)
+
+	ps := &PhotoService{
+		PhotoRepository:  photoRepository,
+		VisibilityPolicy: visibilityPolicy,
+	}
+
+	latitude, longitude := -8.65, 115.21
+	farLatitude := 91.0
+	nearby := []model.PhotoDistance{
+		{Photo: model.Photo{ID: "p1", UserID: "1", Latitude: &latitude, Longitude: &longitude}, DistanceKm: 0.123},
+		{Photo: model.Photo{ID: "p2", UserID: "2", Latitude: &latitude, Longitude: &longitude}, DistanceKm: 1.5},
+		{Photo: model.Photo{ID: "p3", UserID: "3", Latitude: &latitude, Longitude: &longitude}, DistanceKm: 2},
+	}
+
+	tests := []struct {
+		name     string
+		request  model.PhotoNearbyRequest
+		want     []string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "Case #1 - Success (Blocked owner skipped, default radius)",
+			request: model.PhotoNearbyRequest{Latitude: &latitude, Longitude: &longitude},
+			want:    []string{"p1", "p3"},
+			mockFunc: func() {
+				photoRepository.On("GetNearby", latitude, longitude, float64(model.DefaultNearbyRadiusKm), model.MaxNearbyResults).Return(nearby, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2", "3"}).Return([]string{"2"}, nil).Once()
+				userRepository.On("GetByIDs", []string{"3"}).Return([]model.User{{ID: "3"}}, nil).Once()
+			},
+		},
+		{
+			name:     "Case #2 - Failed (Missing longitude)",
+			request:  model.PhotoNearbyRequest{Latitude: &latitude},
+			mockFunc: func() {},
+			wantErr:  model.ErrorIncompleteLocation,
+		},
+		{
+			name:     "Case #3 - Failed (Latitude out of range)",
+			request:  model.PhotoNearbyRequest{Latitude: &farLatitude, Longitude: &longitude},
+			mockFunc: func() {},
+			wantErr:  model.ErrorInvalidCoordinates,
+		},
+		{
+			name:     "Case #4 - Failed (Radius too large)",
+			request:  model.PhotoNearbyRequest{Latitude: &latitude, Longitude: &longitude, RadiusKm: 500},
+			mockFunc: func() {},
+			wantErr:  model.ErrorInvalidRadius,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ps.Nearby(tt.request, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PhotoService.Nearby() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Photos) != len(tt.want) {
+				t.Errorf("PhotoService.Nearby() = %v, want %v", got.Photos, tt.want)
+				return
+			}
+			for i, id := range tt.want {
+				if got.Photos[i].ID != id {
+					t.Errorf("PhotoService.Nearby() = %v, want %v", got.Photos, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPhotoService_Add(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository: photoRepository,
+	}
+
+	latitude, longitude := -8.65, 115.21
+	badLongitude := 181.0
+
+	tests := []struct {
+		name     string
+		request  model.PhotoCreateRequest
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "Case #1 - Success (With location)",
+			request: model.PhotoCreateRequest{
+				Title:    "Beach",
+				PhotoURL: "https://img/1.jpg",
+				Location: &model.PhotoLocationRequest{Latitude: &latitude, Longitude: &longitude, PlaceName: "Kuta"},
+			},
+			mockFunc: func() {
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool {
+						return *photo.Latitude == latitude && *photo.Longitude == longitude && photo.PlaceName == "Kuta"
+					})).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name: "Case #2 - Failed (Longitude out of range)",
+			request: model.PhotoCreateRequest{
+				Title:    "Beach",
+				PhotoURL: "https://img/1.jpg",
+				Location: &model.PhotoLocationRequest{Latitude: &latitude, Longitude: &badLongitude},
+			},
+			mockFunc: func() {},
+			wantErr:  model.ErrorInvalidCoordinates,
+		},
+		{
+			name: "Case #3 - Failed (Place without coordinates)",
+			request: model.PhotoCreateRequest{
+				Title:    "Beach",
+				PhotoURL: "https://img/1.jpg",
+				Location: &model.PhotoLocationRequest{PlaceName: "Kuta"},
+			},
+			mockFunc: func() {},
+			wantErr:  model.ErrorIncompleteLocation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			_, err := ps.Add(tt.request, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PhotoService.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPhotoService_Add_Media(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository: photoRepository,
+	}
+
+	tooMany := make([]model.PhotoMediaRequest, model.MaxPhotoMedia+1)
+	for i := range tooMany {
+		tooMany[i] = model.PhotoMediaRequest{URL: "https://img/x.jpg"}
+	}
+
+	tests := []struct {
+		name     string
+		request  model.PhotoCreateRequest
+		want     []string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "Case #1 - Success (Single photo URL)",
+			request: model.PhotoCreateRequest{Title: "Beach", PhotoURL: "https://img/1.jpg"},
+			want:    []string{"https://img/1.jpg"},
+			mockFunc: func() {
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool {
+						return photo.PhotoURL == "https://img/1.jpg" && len(photo.Media) == 1
+					})).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name: "Case #2 - Success (Carousel, first item is the cover)",
+			request: model.PhotoCreateRequest{
+				Title: "Trip",
+				Media: []model.PhotoMediaRequest{{URL: "https://img/2.jpg", AltText: "Sunrise"}, {URL: "https://img/3.jpg"}},
+			},
+			want: []string{"https://img/2.jpg", "https://img/3.jpg"},
+			mockFunc: func() {
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool {
+						return photo.PhotoURL == "https://img/2.jpg" && photo.Media[1].Position == 1 && photo.Media[0].AltText == "Sunrise"
+					})).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name:     "Case #3 - Failed (No photo URL or media)",
+			request:  model.PhotoCreateRequest{Title: "Empty"},
+			mockFunc: func() {},
+			wantErr:  model.ErrorPhotoURLRequired,
+		},
+		{
+			name:     "Case #4 - Failed (More than 10 items)",
+			request:  model.PhotoCreateRequest{Title: "Dump", Media: tooMany},
+			mockFunc: func() {},
+			wantErr:  model.ErrorInvalidMediaCount,
+		},
+		{
+			name: "Case #5 - Failed (Photo URL is not the first item)",
+			request: model.PhotoCreateRequest{
+				Title:    "Trip",
+				PhotoURL: "https://img/9.jpg",
+				Media:    []model.PhotoMediaRequest{{URL: "https://img/2.jpg"}},
+			},
+			mockFunc: func() {},
+			wantErr:  model.ErrorPhotoURLMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ps.Add(tt.request, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PhotoService.Add() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Media) != len(tt.want) {
+				t.Errorf("PhotoService.Add() media = %v, want %v", got.Media, tt.want)
+				return
+			}
+			for i, url := range tt.want {
+				if got.Media[i].URL != url || got.Media[i].Position != i {
+					t.Errorf("PhotoService.Add() media = %v, want %v", got.Media, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPhotoService_UpdateById_Media(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository: photoRepository,
+	}
+
+	carousel := model.Photo{
+		ID:       "p1",
+		UserID:   "1",
+		PhotoURL: "https://img/1.jpg",
+		Media: []model.PhotoMedia{
+			{PhotoID: "p1", Position: 0, URL: "https://img/1.jpg", AltText: "Old cover"},
+			{PhotoID: "p1", Position: 1, URL: "https://img/2.jpg", AltText: "Second"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		request  model.PhotoUpdateRequest
+		want     []string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "Case #1 - Success (Same photo URL keeps the carousel)",
+			request: model.PhotoUpdateRequest{Title: "Trip", PhotoURL: "https://img/1.jpg"},
+			want:    []string{"https://img/1.jpg", "https://img/2.jpg"},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(carousel, nil).Once()
+				photoRepository.On("Update", mock.Anything, "p1").Return(model.Photo{ID: "p1", UserID: "1", Title: "Trip"}, nil).Once()
+			},
+		},
+		{
+			name:    "Case #2 - Success (New photo URL replaces the cover only)",
+			request: model.PhotoUpdateRequest{Title: "Trip", PhotoURL: "https://img/9.jpg"},
+			want:    []string{"https://img/9.jpg", "https://img/2.jpg"},
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(carousel, nil).Once()
+				photoRepository.
+					On("Update", mock.MatchedBy(func(update model.PhotoUpdate) bool {
+						media := update.Media
+						return len(media) == 2 && media[0].AltText == "" && media[1].AltText == "Second"
+					}), "p1").
+					Return(model.Photo{ID: "p1", UserID: "1", Title: "Trip"}, nil).Once()
+			},
+		},
+		{
+			name:     "Case #3 - Failed (Empty media list)",
+			request:  model.PhotoUpdateRequest{Title: "Trip", Media: []model.PhotoMediaRequest{}},
+			mockFunc: func() { photoRepository.On("GetOne", "p1").Return(carousel, nil).Once() },
+			wantErr:  model.ErrorInvalidMediaCount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ps.UpdateById(tt.request, "p1", "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PhotoService.UpdateById() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Media) != len(tt.want) {
+				t.Errorf("PhotoService.UpdateById() media = %v, want %v", got.Media, tt.want)
+				return
+			}
+			for i, url := range tt.want {
+				if got.Media[i].URL != url {
+					t.Errorf("PhotoService.UpdateById() media = %v, want %v", got.Media, tt.want)
+				}
+			}
+			if len(tt.want) > 0 && got.PhotoURL != tt.want[0] {
+				t.Errorf("PhotoService.UpdateById() photo_url = %v, want %v", got.PhotoURL, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestPhotoService_Add_AltText(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository: photoRepository,
+		Captioner:       NewStubCaptioner(),
+	}
+
+	tests := []struct {
+		name          string
+		request       model.PhotoCreateRequest
+		want          []string
+		wantGenerated []bool
+		mockFunc      func()
+		wantErr       error
+	}{
+		{
+			name:          "Case #1 - Success (Owner alt text kept, whitespace collapsed)",
+			request:       model.PhotoCreateRequest{Title: "Beach", PhotoURL: "https://img/1.jpg", AltText: "  Waves   at dusk "},
+			want:          []string{"Waves at dusk"},
+			wantGenerated: []bool{false},
+			mockFunc: func() {
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool { return photo.AltText == "Waves at dusk" })).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name: "Case #2 - Success (Empty alt text suggested)",
+			request: model.PhotoCreateRequest{
+				Title: "Trip",
+				Media: []model.PhotoMediaRequest{{URL: "https://img/2.jpg", AltText: "Sunrise"}, {URL: "https://img/3.jpg"}},
+			},
+			want:          []string{"Sunrise", `Image 2 of 2 from the post Trip`},
+			wantGenerated: []bool{false, true},
+			mockFunc: func() {
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool {
+						return photo.AltText == "Sunrise\nImage 2 of 2 from the post Trip"
+					})).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name:     "Case #3 - Failed (File name as alt text)",
+			request:  model.PhotoCreateRequest{Title: "Beach", PhotoURL: "https://img/1.jpg", AltText: "IMG_2041.JPG"},
+			mockFunc: func() {},
+			wantErr:  model.ErrorInvalidAltText,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ps.Add(tt.request, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PhotoService.Add() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for i, altText := range tt.want {
+				if got.Media[i].AltText != altText || got.Media[i].AltTextGenerated != tt.wantGenerated[i] {
+					t.Errorf("PhotoService.Add() media = %+v, want alt text %v generated %v", got.Media, tt.want, tt.wantGenerated)
+				}
+			}
+			if len(tt.want) > 0 && got.AltText != tt.want[0] {
+				t.Errorf("PhotoService.Add() alt_text = %v, want %v", got.AltText, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestPhotoService_MissingAltText(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository: photoRepository,
+	}
+
+	photoRepository.On("GetMissingAltText", "1", model.PaginationRequest{Page: 1, Limit: model.DefaultPageLimit}).Return([]model.Photo{
+		{ID: "p1", PhotoURL: "https://img/1.jpg"},
+		{ID: "p2", PhotoURL: "https://img/2.jpg", Media: []model.PhotoMedia{
+			{Position: 0, URL: "https://img/2.jpg", AltText: "Sunrise"},
+			{Position: 1, URL: "https://img/3.jpg", AltText: "Image 2 of 3", AltTextGenerated: true},
+			{Position: 2, URL: "https://img/4.jpg"},
+		}},
+	}, int64(2), nil).Once()
+
+	got, err := ps.MissingAltText(model.PaginationRequest{}, "1")
+	if err != nil {
+		t.Fatalf("PhotoService.MissingAltText() error = %v", err)
+	}
+	if len(got.Photos) != 2 || got.Pagination.Total != 2 {
+		t.Fatalf("PhotoService.MissingAltText() = %+v", got)
+	}
+	if len(got.Photos[0].MissingPositions) != 1 || got.Photos[0].MissingPositions[0] != 0 {
+		t.Errorf("PhotoService.MissingAltText() legacy photo missing = %v, want [0]", got.Photos[0].MissingPositions)
+	}
+	if len(got.Photos[1].MissingPositions) != 1 || got.Photos[1].MissingPositions[0] != 2 ||
+		len(got.Photos[1].SuggestedPositions) != 1 || got.Photos[1].SuggestedPositions[0] != 1 {
+		t.Errorf("PhotoService.MissingAltText() carousel = %+v", got.Photos[1])
+	}
+}
+
+func TestPhotoService_Add_Status(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository: photoRepository,
+	}
+
+	tomorrow := time.Now().Add(24 * time.Hour)
+	yesterday := time.Now().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		request  model.PhotoCreateRequest
+		want     string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "Case #1 - Success (Published by default)",
+			request: model.PhotoCreateRequest{Title: "Beach", PhotoURL: "https://img/1.jpg"},
+			want:    model.PhotoStatusPublished,
+			mockFunc: func() {
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool {
+						return photo.Status == model.PhotoStatusPublished && photo.PublishAt != nil
+					})).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name:    "Case #2 - Success (Draft)",
+			request: model.PhotoCreateRequest{Title: "Beach", PhotoURL: "https://img/1.jpg", Status: model.PhotoStatusDraft, PublishAt: &tomorrow},
+			want:    model.PhotoStatusDraft,
+			mockFunc: func() {
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool {
+						return photo.Status == model.PhotoStatusDraft && photo.PublishAt == nil
+					})).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name:    "Case #3 - Success (Scheduled)",
+			request: model.PhotoCreateRequest{Title: "Beach", PhotoURL: "https://img/1.jpg", Status: model.PhotoStatusScheduled, PublishAt: &tomorrow},
+			want:    model.PhotoStatusScheduled,
+			mockFunc: func() {
+				photoRepository.
+					On("Save", mock.MatchedBy(func(photo model.Photo) bool {
+						return photo.Status == model.PhotoStatusScheduled && photo.PublishAt.Equal(tomorrow)
+					})).
+					Return(func(photo model.Photo) (model.Photo, error) { return photo, nil }).Once()
+			},
+		},
+		{
+			name:     "Case #4 - Failed (Scheduled in the past)",
+			request:  model.PhotoCreateRequest{Title: "Beach", PhotoURL: "https://img/1.jpg", Status: model.PhotoStatusScheduled, PublishAt: &yesterday},
+			mockFunc: func() {},
+			wantErr:  model.ErrorInvalidPublishAt,
+		},
+		{
+			name:     "Case #5 - Failed (Unknown status)",
+			request:  model.PhotoCreateRequest{Title: "Beach", PhotoURL: "https://img/1.jpg", Status: "archived"},
+			mockFunc: func() {},
+			wantErr:  model.ErrorInvalidPhotoStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ps.Add(tt.request, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PhotoService.Add() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Status != tt.want {
+				t.Errorf("PhotoService.Add() status = %v, want %v", got.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhotoService_UpdateById_Status(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository: photoRepository,
+	}
+
+	tomorrow := time.Now().Add(24 * time.Hour)
+	draft := model.Photo{ID: "p1", UserID: "1", PhotoURL: "https://img/1.jpg", Status: model.PhotoStatusDraft}
+	published := model.Photo{ID: "p1", UserID: "1", PhotoURL: "https://img/1.jpg", Status: model.PhotoStatusPublished}
+
+	tests := []struct {
+		name     string
+		request  model.PhotoUpdateRequest
+		want     string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "Case #1 - Success (Status left out keeps the draft)",
+			request: model.PhotoUpdateRequest{Title: "Trip"},
+			want:    model.PhotoStatusDraft,
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(draft, nil).Once()
+				photoRepository.On("Update", mock.Anything, "p1").Return(model.Photo{ID: "p1", UserID: "1", Title: "Trip"}, nil).Once()
+			},
+		},
+		{
+			name:    "Case #2 - Success (Draft scheduled)",
+			request: model.PhotoUpdateRequest{Title: "Trip", Status: model.PhotoStatusScheduled, PublishAt: &tomorrow},
+			want:    model.PhotoStatusScheduled,
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(draft, nil).Once()
+				photoRepository.
+					On("Update", mock.MatchedBy(func(update model.PhotoUpdate) bool {
+						return update.Status != nil && *update.Status == model.PhotoStatusScheduled && update.PublishAt == &tomorrow
+					}), "p1").
+					Return(model.Photo{ID: "p1", UserID: "1", Title: "Trip"}, nil).Once()
+			},
+		},
+		{
+			name:     "Case #3 - Failed (Published photo back to draft)",
+			request:  model.PhotoUpdateRequest{Title: "Trip", Status: model.PhotoStatusDraft},
+			mockFunc: func() { photoRepository.On("GetOne", "p1").Return(published, nil).Once() },
+			wantErr:  model.ErrorPhotoAlreadyPublished,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ps.UpdateById(tt.request, "p1", "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PhotoService.UpdateById() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Status != tt.want {
+				t.Errorf("PhotoService.UpdateById() status = %v, want %v", got.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhotoService_UpdateById_Held(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+	reportRepository := mocks.NewIReportRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository: photoRepository,
+		CaptionFilter:   NewFilterPipeline(reportRepository, NewLinkLimitFilter(0)),
+	}
+
+	photoRepository.On("GetOne", "p1").Return(model.Photo{ID: "p1", UserID: "1", PhotoURL: "https://img/1.jpg", Status: model.PhotoStatusPublished}, nil).Once()
+	photoRepository.
+		On("Update", mock.MatchedBy(func(update model.PhotoUpdate) bool { return update.Hide && update.Caption == "https://a.io" }), "p1").
+		Return(model.Photo{ID: "p1", UserID: "1", Title: "Trip", Caption: "https://a.io"}, nil).Once()
+	reportRepository.
+		On("Save", mock.MatchedBy(func(report model.Report) bool { return report.TargetType == model.ReportTargetPhoto })).
+		Return(model.Report{}, nil).Once()
+
+	got, err := ps.UpdateById(model.PhotoUpdateRequest{Title: "Trip", Caption: "https://a.io"}, "p1", "1")
+	if err != nil {
+		t.Fatalf("PhotoService.UpdateById() error = %v", err)
+	}
+	if !got.PendingReview {
+		t.Errorf("PhotoService.UpdateById() pending_review = %v, want true", got.PendingReview)
+	}
+}
+
+func TestPhotoService_GetById_Draft(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	bookmarkRepository := mocks.NewIBookmarkRepository(t)
+	reactionRepository := mocks.NewIReactionRepository(t)
+
+	ps := &PhotoService{
+		PhotoRepository:    photoRepository,
+		BookmarkRepository: bookmarkRepository,
+		ReactionRepository: reactionRepository,
+		VisibilityPolicy:   NewVisibilityPolicy(userRepository, nil, photoRepository, blockRepository, nil),
+	}
+
+	draft := model.Photo{ID: "p1", UserID: "1", PhotoURL: "https://img/1.jpg", Status: model.PhotoStatusDraft}
+
+	tests := []struct {
+		name     string
+		viewerId string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:     "Case #1 - Success (Owner sees the draft)",
+			viewerId: "1",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(draft, nil).Once()
+				bookmarkRepository.On("GetBookmarkedPhotoIDs", "1", []string{"p1"}).Return([]string{}, nil).Once()
+				reactionRepository.On("CountByCommentIDs", []string{}).Return([]model.ReactionCount{}, nil).Once()
+			},
+		},
+		{
+			name:     "Case #2 - Failed (Hidden from everyone else)",
+			viewerId: "2",
+			mockFunc: func() {
+				photoRepository.On("GetOne", "p1").Return(draft, nil).Once()
+				blockRepository.On("GetBlockedIDs", "2", []string{"1"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"1"}).Return([]model.User{{ID: "1"}}, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ps.GetById("p1", tt.viewerId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PhotoService.GetById() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Status != model.PhotoStatusDraft {
+				t.Errorf("PhotoService.GetById() status = %v, want %v", got.Status, model.PhotoStatusDraft)
+			}
+		})
+	}
+}
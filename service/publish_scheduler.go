@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"log"
+	"mygram/repository"
+	"time"
+)
+
+// PublishScheduler publishes scheduled photos once their publish time has
+// come. Every app instance runs one, PhotoRepository.PublishDue hands each due
+// photo to a single instance so it is published exactly once.
+type PublishScheduler struct {
+	PhotoRepository repository.IPhotoRepository
+	Interval        time.Duration
+}
+
+func NewPublishScheduler(photoRepository repository.IPhotoRepository, interval time.Duration) *PublishScheduler {
+	return &PublishScheduler{
+		PhotoRepository: photoRepository,
+		Interval:        interval,
+	}
+}
+
+// PublishDue publishes the photos due at now and returns their ids.
+func (ps *PublishScheduler) PublishDue(now time.Time) ([]string, error) {
+	res, err := ps.PhotoRepository.PublishDue(now)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(res))
+	for _, photo := range res {
+		ids = append(ids, photo.ID)
+	}
+	return ids, nil
+}
+
+// Run publishes due photos every Interval until ctx is done. Failures are
+// logged and retried on the next tick.
+func (ps *PublishScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(ps.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			ids, err := ps.PublishDue(now)
+			if err != nil {
+				log.Printf("publish scheduler: %v", err)
+				continue
+			}
+			if len(ids) > 0 {
+				log.Printf("publish scheduler: published %d photos", len(ids))
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository/mocks"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPublishScheduler_PublishDue(t *testing.T) {
+	photoRepository := mocks.NewIPhotoRepository(t)
+
+	ps := NewPublishScheduler(photoRepository, time.Minute)
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success",
+			mockFunc: func() {
+				photoRepository.On("PublishDue", now).Return([]model.Photo{{ID: "1"}, {ID: "2"}}, nil).Once()
+			},
+			want: []string{"1", "2"},
+		},
+		{
+			name: "Case #2 - Success (Already published by another instance)",
+			mockFunc: func() {
+				photoRepository.On("PublishDue", now).Return([]model.Photo{}, nil).Once()
+			},
+			want: []string{},
+		},
+		{
+			name: "Case #3 - Failed",
+			mockFunc: func() {
+				photoRepository.On("PublishDue", now).Return(nil, model.MyError{Err: "db down"}).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ps.PublishDue(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PublishScheduler.PublishDue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PublishScheduler.PublishDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
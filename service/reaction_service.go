@@ -0,0 +1,120 @@
+package service
+
+import (
+	"mygram/helper"
+	"mygram/model"
+	"mygram/repository"
+	"strings"
+)
+
+type ReactionService struct {
+	ReactionRepository repository.IReactionRepository
+	CommentRepository  repository.ICommentRepository
+	VisibilityPolicy   *VisibilityPolicy
+	Emojis             map[string]bool
+}
+
+func NewReactionService(reactionRepository repository.IReactionRepository, commentRepository repository.ICommentRepository, visibilityPolicy *VisibilityPolicy, emojis []string) *ReactionService {
+	emojiSet := make(map[string]bool)
+	for _, emoji := range emojis {
+		emojiSet[normalizeEmoji(emoji)] = true
+	}
+
+	return &ReactionService{
+		ReactionRepository: reactionRepository,
+		CommentRepository:  commentRepository,
+		VisibilityPolicy:   visibilityPolicy,
+		Emojis:             emojiSet,
+	}
+}
+
+// React adds one reaction per user and emoji on a comment the user can see.
+// Comments of blocked users are not visible, so they cannot be reacted to.
+func (rs *ReactionService) React(commentId string, emoji string, userId string) (model.ReactionResponse, error) {
+	emoji = normalizeEmoji(emoji)
+	if !rs.Emojis[emoji] {
+		return model.ReactionResponse{}, model.ErrorInvalidReaction
+	}
+
+	comment, err := rs.CommentRepository.GetOne(commentId)
+	if err != nil {
+		return model.ReactionResponse{}, err
+	}
+
+	visible, err := rs.VisibilityPolicy.FilterComments(userId, []model.Comment{comment})
+	if err != nil {
+		return model.ReactionResponse{}, err
+	}
+	if len(visible) == 0 {
+		return model.ReactionResponse{}, model.ErrorNotFound
+	}
+
+	_, err = rs.ReactionRepository.Save(model.CommentReaction{
+		ID:        helper.GenerateID(),
+		CommentID: comment.ID,
+		UserID:    userId,
+		Emoji:     emoji,
+	})
+	if err != nil {
+		return model.ReactionResponse{}, err
+	}
+
+	return rs.toReactionResponse(comment.ID, emoji)
+}
+
+func (rs *ReactionService) Unreact(commentId string, emoji string, userId string) (model.ReactionResponse, error) {
+	emoji = normalizeEmoji(emoji)
+
+	err := rs.ReactionRepository.Delete(commentId, userId, emoji)
+	if err != nil {
+		return model.ReactionResponse{}, err
+	}
+
+	return rs.toReactionResponse(commentId, emoji)
+}
+
+func (rs *ReactionService) toReactionResponse(commentId string, emoji string) (model.ReactionResponse, error) {
+	reactions, err := reactionCounts(rs.ReactionRepository, []string{commentId})
+	if err != nil {
+		return model.ReactionResponse{}, err
+	}
+
+	return model.ReactionResponse{
+		CommentID: commentId,
+		Emoji:     emoji,
+		Reactions: reactionsOf(reactions, commentId),
+	}, nil
+}
+
+// reactionCounts loads the reaction counts of every comment in one query,
+// keyed by comment ID then emoji.
+func reactionCounts(reactionRepository repository.IReactionRepository, commentIds []string) (map[string]map[string]int64, error) {
+	reactions := make(map[string]map[string]int64)
+
+	res, err := reactionRepository.CountByCommentIDs(commentIds)
+	if err != nil {
+		return reactions, err
+	}
+
+	for _, count := range res {
+		if reactions[count.CommentID] == nil {
+			reactions[count.CommentID] = make(map[string]int64)
+		}
+		reactions[count.CommentID][count.Emoji] = count.Count
+	}
+	return reactions, nil
+}
+
+// reactionsOf returns the counts of one comment, empty when nobody reacted.
+func reactionsOf(reactions map[string]map[string]int64, commentId string) map[string]int64 {
+	if counts, ok := reactions[commentId]; ok {
+		return counts
+	}
+	return make(map[string]int64)
+}
+
+// normalizeEmoji drops the emoji presentation selector, so "❤️" and "❤" are
+// the same reaction.
+func normalizeEmoji(emoji string) string {
+	return strings.ReplaceAll(strings.TrimSpace(emoji), "\ufe0f", "")
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReactionService_React(t *testing.T) {
+	reactionRepository := mocks.NewIReactionRepository(t)
+	commentRepository := mocks.NewICommentRepository(t)
+	photoRepository := mocks.NewIPhotoRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	visibilityPolicy := NewVisibilityPolicy(userRepository, nil, photoRepository, blockRepository, nil)
+
+	rs := NewReactionService(reactionRepository, commentRepository, visibilityPolicy, []string{"👍", "❤️"})
+	comment := model.Comment{ID: "c1", UserID: "1", PhotoID: "p1"}
+
+	tests := []struct {
+		name      string
+		commentId string
+		emoji     string
+		want      map[string]int64
+		mockFunc  func()
+		wantErr   error
+	}{
+		{
+			name:      "Case #1 - Success (Emoji presentation selector ignored)",
+			commentId: "c1",
+			emoji:     "❤",
+			want:      map[string]int64{"❤": 1, "👍": 2},
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				photoRepository.On("GetByIDs", []string{"p1"}).Return([]model.Photo{{ID: "p1", UserID: "1"}}, nil).Once()
+				reactionRepository.
+					On("Save", mock.MatchedBy(func(reaction model.CommentReaction) bool {
+						return reaction.CommentID == "c1" && reaction.UserID == "1" && reaction.Emoji == "❤"
+					})).
+					Return(model.CommentReaction{}, nil).Once()
+				reactionRepository.On("CountByCommentIDs", []string{"c1"}).Return([]model.ReactionCount{
+					{CommentID: "c1", Emoji: "❤", Count: 1},
+					{CommentID: "c1", Emoji: "👍", Count: 2},
+				}, nil).Once()
+			},
+		},
+		{
+			name:      "Case #2 - Failed (Emoji not in the set)",
+			commentId: "c1",
+			emoji:     "🍕",
+			mockFunc:  func() {},
+			wantErr:   model.ErrorInvalidReaction,
+		},
+		{
+			name:      "Case #3 - Failed (Already reacted)",
+			commentId: "c1",
+			emoji:     "👍",
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c1").Return(comment, nil).Once()
+				photoRepository.On("GetByIDs", []string{"p1"}).Return([]model.Photo{{ID: "p1", UserID: "1"}}, nil).Once()
+				reactionRepository.On("Save", mock.Anything).Return(model.CommentReaction{}, model.ErrorAlreadyReacted).Once()
+			},
+			wantErr: model.ErrorAlreadyReacted,
+		},
+		{
+			name:      "Case #4 - Failed (Hidden comment of another user)",
+			commentId: "c2",
+			emoji:     "👍",
+			mockFunc: func() {
+				commentRepository.On("GetOne", "c2").Return(model.Comment{ID: "c2", UserID: "2", PhotoID: "p1", Hidden: true}, nil).Once()
+				blockRepository.On("GetBlockedIDs", "1", []string{"2"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"2"}).Return([]model.User{{ID: "2"}}, nil).Once()
+				photoRepository.On("GetByIDs", []string{"p1"}).Return([]model.Photo{{ID: "p1", UserID: "1"}}, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := rs.React(tt.commentId, tt.emoji, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReactionService.React() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Reactions) != len(tt.want) {
+				t.Errorf("ReactionService.React() reactions = %v, want %v", got.Reactions, tt.want)
+				return
+			}
+			for emoji, count := range tt.want {
+				if got.Reactions[emoji] != count {
+					t.Errorf("ReactionService.React() reactions = %v, want %v", got.Reactions, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository"
+)
+
+type SearchService struct {
+	SearchRepository repository.ISearchRepository
+	VisibilityPolicy *VisibilityPolicy
+}
+
+func NewSearchService(searchRepository repository.ISearchRepository, visibilityPolicy *VisibilityPolicy) *SearchService {
+	return &SearchService{
+		SearchRepository: searchRepository,
+		VisibilityPolicy: visibilityPolicy,
+	}
+}
+
+func (ss *SearchService) Search(request model.SearchRequest, viewerId string) (model.SearchResponse, error) {
+	var search func(query string, pagination model.PaginationRequest) ([]model.SearchResult, int64, error)
+
+	if request.Type == "" {
+		request.Type = model.SearchTypePhoto
+	}
+	pagination := request.PaginationRequest.Normalize()
+
+	switch request.Type {
+	case model.SearchTypePhoto:
+		search = ss.SearchRepository.SearchPhotos
+	case model.SearchTypeUser:
+		search = ss.SearchRepository.SearchUsers
+	case model.SearchTypeComment:
+		search = ss.SearchRepository.SearchComments
+	default:
+		return model.SearchResponse{}, model.ErrorInvalidSearchType
+	}
+
+	results, total, err := ss.searchVisible(search, request.Query, pagination, viewerId)
+	if err != nil {
+		return model.SearchResponse{}, err
+	}
+
+	return model.SearchResponse{
+		Query:      request.Query,
+		Type:       request.Type,
+		Results:    results,
+		Pagination: model.ToPaginationResponse(pagination, total),
+	}, nil
+}
+
+// searchVisible walks the ranked hits in batches until the requested page can
+// be filled with results the viewer may see, so hidden hits never leave holes
+// in a page. At most model.MaxSearchScan hits are scanned, pages past them only
+// cost the first batch. The total is approximate: hidden hits are only
+// subtracted for the batches scanned.
+func (ss *SearchService) searchVisible(search func(string, model.PaginationRequest) ([]model.SearchResult, int64, error), query string, pagination model.PaginationRequest, viewerId string) ([]model.SearchResult, int64, error) {
+	visible := make([]model.SearchResult, 0)
+	var hidden int64
+
+	maxScan := model.MaxSearchScan
+	if pagination.Offset() >= maxScan {
+		maxScan = model.MaxPageLimit
+	}
+
+	batch := model.PaginationRequest{Page: 1, Limit: model.MaxPageLimit}
+	for {
+		results, total, err := search(query, batch)
+		if err != nil {
+			return []model.SearchResult{}, 0, err
+		}
+
+		filtered, err := ss.filterVisible(results, viewerId)
+		if err != nil {
+			return []model.SearchResult{}, 0, err
+		}
+		hidden += int64(len(results) - len(filtered))
+		visible = append(visible, filtered...)
+
+		scanned := batch.Offset() + len(results)
+		if len(visible) >= pagination.Offset()+pagination.Limit || len(results) == 0 || int64(scanned) >= total || scanned >= maxScan {
+			start := pagination.Offset()
+			if start > len(visible) {
+				start = len(visible)
+			}
+			end := start + pagination.Limit
+			if end > len(visible) {
+				end = len(visible)
+			}
+			return visible[start:end], total - hidden, nil
+		}
+		batch.Page++
+	}
+}
+
+// filterVisible drops photo and comment hits the viewer may not see. User hits
+// are kept so private accounts stay discoverable, their content is not. Users
+// with a block in either direction are dropped entirely.
+func (ss *SearchService) filterVisible(results []model.SearchResult, viewerId string) ([]model.SearchResult, error) {
+	ownerIds := make([]string, 0, len(results))
+	userIds := make([]string, 0)
+	photoIds := make([]string, 0)
+	for _, result := range results {
+		if result.Type == model.SearchTypeUser {
+			userIds = append(userIds, result.UserID)
+			continue
+		}
+		ownerIds = append(ownerIds, result.UserID)
+		if result.PhotoID != "" {
+			photoIds = append(photoIds, result.PhotoID)
+		}
+	}
+
+	blocked, err := ss.VisibilityPolicy.BlockedUsers(viewerId, userIds)
+	if err != nil {
+		return []model.SearchResult{}, err
+	}
+
+	owners, err := ss.VisibilityPolicy.VisibleOwners(viewerId, ownerIds)
+	if err != nil {
+		return []model.SearchResult{}, err
+	}
+	photos, err := ss.VisibilityPolicy.VisiblePhotoIDs(viewerId, photoIds)
+	if err != nil {
+		return []model.SearchResult{}, err
+	}
+
+	filtered := make([]model.SearchResult, 0, len(results))
+	for _, result := range results {
+		if result.Type == model.SearchTypeUser {
+			if blocked[result.UserID] {
+				continue
+			}
+		} else if !owners[result.UserID] || (result.PhotoID != "" && !photos[result.PhotoID]) {
+			continue
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered, nil
+}
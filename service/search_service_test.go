@@ -0,0 +1,304 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository"
+	"mygram/repository/mocks"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	now := time.Now()
+	searchRepository := repository.NewInMemorySearchRepository(
+		[]model.Photo{
+			{ID: "1", UserID: "1", Title: "Sunset in Bali", Caption: "Golden hour", CreatedAt: now},
+			{ID: "2", UserID: "2", Title: "Beach", Caption: "Sunset at Kuta beach", CreatedAt: now.Add(time.Minute)},
+			{ID: "3", UserID: "2", Title: "Mountain", Caption: "Morning hike", AltText: "A hiker on a volcano ridge", CreatedAt: now},
+			{ID: "4", UserID: "3", Title: "Private sunset", Caption: "", CreatedAt: now},
+			{ID: "5", UserID: "4", Title: "Sunset from a blocker", Caption: "", CreatedAt: now},
+		},
+		[]model.User{
+			{ID: "1", Username: "adiwahyudi", CreatedAt: now},
+			{ID: "4", Username: "blocker", CreatedAt: now},
+		},
+		[]model.Comment{
+			{ID: "1", UserID: "1", PhotoID: "2", Message: "What a sunset!", CreatedAt: now},
+			{ID: "2", UserID: "1", PhotoID: "2", Message: `<script>alert("xss")</script>`, CreatedAt: now},
+		},
+	)
+
+	users := map[string]model.User{
+		"1": {ID: "1"},
+		"2": {ID: "2"},
+		"3": {ID: "3", IsPrivate: true},
+		"4": {ID: "4"},
+	}
+	photos := map[string]model.Photo{
+		"2": {ID: "2", UserID: "2"},
+		"3": {ID: "3", UserID: "2"},
+	}
+	userRepository := mocks.NewIUserRepository(t)
+	userRepository.On("GetByIDs", mock.Anything).Return(func(ids []string) ([]model.User, error) {
+		found := make([]model.User, 0)
+		for _, id := range ids {
+			found = append(found, users[id])
+		}
+		return found, nil
+	}).Maybe()
+	photoRepository := mocks.NewIPhotoRepository(t)
+	photoRepository.On("GetByIDs", mock.Anything).Return(func(ids []string) ([]model.Photo, error) {
+		found := make([]model.Photo, 0)
+		for _, id := range ids {
+			found = append(found, photos[id])
+		}
+		return found, nil
+	}).Maybe()
+	followRepository := mocks.NewIFollowRepository(t)
+	followRepository.On("GetAcceptedFollowingIDs", "1", []string{"3"}).Return([]string{}, nil).Maybe()
+	blockRepository := mocks.NewIBlockRepository(t)
+	blockRepository.On("GetBlockedIDs", "1", mock.Anything).Return(func(userId string, ids []string) ([]string, error) {
+		blocked := make([]string, 0)
+		for _, id := range ids {
+			if id == "4" {
+				blocked = append(blocked, id)
+			}
+		}
+		return blocked, nil
+	}).Maybe()
+	visibilityPolicy := NewVisibilityPolicy(userRepository, followRepository, photoRepository, blockRepository, nil)
+
+	type args struct {
+		request  model.SearchRequest
+		viewerId string
+	}
+	tests := []struct {
+		name    string
+		ss      *SearchService
+		args    args
+		want    model.SearchResponse
+		wantErr bool
+	}{
+		{
+			name: "Case #1 - Success (Photo ranked by title before caption)",
+			ss: &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request:  model.SearchRequest{Query: "sunset"},
+				viewerId: "1",
+			},
+			want: model.SearchResponse{
+				Query: "sunset",
+				Type:  model.SearchTypePhoto,
+				Results: []model.SearchResult{
+					{ID: "1", Type: model.SearchTypePhoto, UserID: "1", Rank: 1, Highlight: "<mark>Sunset</mark> in Bali Golden hour", CreatedAt: now},
+					{ID: "2", Type: model.SearchTypePhoto, UserID: "2", Rank: 0.4, Highlight: "Beach <mark>Sunset</mark> at Kuta beach", CreatedAt: now.Add(time.Minute)},
+				},
+				Pagination: model.PaginationResponse{Page: 1, Limit: model.DefaultPageLimit, Total: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #2 - Success (Paginated)",
+			ss: &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request: model.SearchRequest{
+					Query:             "sunset",
+					Type:              model.SearchTypePhoto,
+					PaginationRequest: model.PaginationRequest{Page: 2, Limit: 1},
+				},
+				viewerId: "1",
+			},
+			want: model.SearchResponse{
+				Query: "sunset",
+				Type:  model.SearchTypePhoto,
+				Results: []model.SearchResult{
+					{ID: "2", Type: model.SearchTypePhoto, UserID: "2", Rank: 0.4, Highlight: "Beach <mark>Sunset</mark> at Kuta beach", CreatedAt: now.Add(time.Minute)},
+				},
+				Pagination: model.PaginationResponse{Page: 2, Limit: 1, Total: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #3 - Success (Comment)",
+			ss: &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request:  model.SearchRequest{Query: "sunset", Type: model.SearchTypeComment},
+				viewerId: "1",
+			},
+			want: model.SearchResponse{
+				Query: "sunset",
+				Type:  model.SearchTypeComment,
+				Results: []model.SearchResult{
+					{ID: "1", Type: model.SearchTypeComment, UserID: "1", PhotoID: "2", Rank: 1, Highlight: "What a <mark>sunset</mark>!", CreatedAt: now},
+				},
+				Pagination: model.PaginationResponse{Page: 1, Limit: model.DefaultPageLimit, Total: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #4 - Success (No match)",
+			ss: &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request:  model.SearchRequest{Query: "sunset", Type: model.SearchTypeUser},
+				viewerId: "1",
+			},
+			want: model.SearchResponse{
+				Query:      "sunset",
+				Type:       model.SearchTypeUser,
+				Results:    []model.SearchResult{},
+				Pagination: model.PaginationResponse{Page: 1, Limit: model.DefaultPageLimit, Total: 0},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #5 - Failed (Invalid type)",
+			ss: &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request:  model.SearchRequest{Query: "sunset", Type: "album"},
+				viewerId: "1",
+			},
+			want:    model.SearchResponse{},
+			wantErr: true,
+		},
+		{
+			name: "Case #6 - Success (Photo found by alt text)",
+			ss: &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request:  model.SearchRequest{Query: "volcano"},
+				viewerId: "1",
+			},
+			want: model.SearchResponse{
+				Query: "volcano",
+				Type:  model.SearchTypePhoto,
+				Results: []model.SearchResult{
+					{ID: "3", Type: model.SearchTypePhoto, UserID: "2", Rank: 0.2, Highlight: "Mountain Morning hike A hiker on a <mark>volcano</mark> ridge", CreatedAt: now},
+				},
+				Pagination: model.PaginationResponse{Page: 1, Limit: model.DefaultPageLimit, Total: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #7 - Success (Blocked user hidden)",
+			ss: &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request:  model.SearchRequest{Query: "blocker", Type: model.SearchTypeUser},
+				viewerId: "1",
+			},
+			want: model.SearchResponse{
+				Query:      "blocker",
+				Type:       model.SearchTypeUser,
+				Results:    []model.SearchResult{},
+				Pagination: model.PaginationResponse{Page: 1, Limit: model.DefaultPageLimit, Total: 0},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Case #8 - Success (Highlight escapes markup)",
+			ss: &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			},
+			args: args{
+				request:  model.SearchRequest{Query: "alert", Type: model.SearchTypeComment},
+				viewerId: "1",
+			},
+			want: model.SearchResponse{
+				Query: "alert",
+				Type:  model.SearchTypeComment,
+				Results: []model.SearchResult{
+					{ID: "2", Type: model.SearchTypeComment, UserID: "1", PhotoID: "2", Rank: 1, Highlight: "&lt;script&gt;<mark>alert</mark>(&#34;xss&#34;)&lt;/script&gt;", CreatedAt: now},
+				},
+				Pagination: model.PaginationResponse{Page: 1, Limit: model.DefaultPageLimit, Total: 1},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ss.Search(tt.args.request, tt.args.viewerId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SearchService.Search() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchService.Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchService_SearchScanLimit(t *testing.T) {
+	blockRepository := mocks.NewIBlockRepository(t)
+	blockRepository.On("GetBlockedIDs", "1", []string{"4"}).Return([]string{"4"}, nil).Maybe()
+	photoRepository := mocks.NewIPhotoRepository(t)
+	photoRepository.On("GetByIDs", []string{}).Return([]model.Photo{}, nil).Maybe()
+	visibilityPolicy := NewVisibilityPolicy(nil, nil, photoRepository, blockRepository, nil)
+
+	// Every hit belongs to a user blocking the viewer.
+	blocked := make([]model.SearchResult, model.MaxPageLimit)
+	for i := range blocked {
+		blocked[i] = model.SearchResult{ID: "p", Type: model.SearchTypePhoto, UserID: "4"}
+	}
+
+	tests := []struct {
+		name      string
+		page      int
+		batches   int
+		wantTotal int64
+	}{
+		{
+			name:      "Case #1 - Stops after the scan limit",
+			page:      1,
+			batches:   model.MaxSearchScan / model.MaxPageLimit,
+			wantTotal: 10000 - model.MaxSearchScan,
+		},
+		{
+			name:      "Case #2 - Page past the scan limit reads one batch",
+			page:      100000,
+			batches:   1,
+			wantTotal: 10000 - model.MaxPageLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchRepository := mocks.NewISearchRepository(t)
+			searchRepository.On("SearchPhotos", "sunset", mock.Anything).Return(blocked, int64(10000), nil).Times(tt.batches)
+			ss := &SearchService{
+				SearchRepository: searchRepository,
+				VisibilityPolicy: visibilityPolicy,
+			}
+
+			got, err := ss.Search(model.SearchRequest{Query: "sunset", PaginationRequest: model.PaginationRequest{Page: tt.page}}, "1")
+			if err != nil {
+				t.Fatalf("SearchService.Search() error = %v", err)
+			}
+			if len(got.Results) != 0 || got.Pagination.Total != tt.wantTotal {
+				t.Errorf("SearchService.Search() = %d results, total %d, want 0 results, total %d", len(got.Results), got.Pagination.Total, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -1,23 +1,34 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"mygram/helper"
 	"mygram/model"
 	"mygram/repository"
+	"strings"
+	"time"
 )
 
 type SocialMediaService struct {
 	SocialMediaRepository repository.ISocialMediaRepository
+	UserRepository        repository.IUserRepository
+	VisibilityPolicy      *VisibilityPolicy
+	Verifier              *SocialMediaVerifier
 }
 
-func NewSocialMediaService(socialMediaRepository repository.ISocialMediaRepository) *SocialMediaService {
+func NewSocialMediaService(socialMediaRepository repository.ISocialMediaRepository, userRepository repository.IUserRepository, visibilityPolicy *VisibilityPolicy, verifier *SocialMediaVerifier) *SocialMediaService {
 	return &SocialMediaService{
 		SocialMediaRepository: socialMediaRepository,
+		UserRepository:        userRepository,
+		VisibilityPolicy:      visibilityPolicy,
+		Verifier:              verifier,
 	}
 }
 
-func (sms *SocialMediaService) GetAll() ([]model.SocialMediaResponse, error) {
+func (sms *SocialMediaService) GetAll(viewerId string) ([]model.SocialMediaResponse, error) {
 	socialMediaRespons := make([]model.SocialMediaResponse, 0)
 
 	res, err := sms.SocialMediaRepository.Get()
@@ -26,12 +37,23 @@ func (sms *SocialMediaService) GetAll() ([]model.SocialMediaResponse, error) {
 		return []model.SocialMediaResponse{}, err
 	}
 
+	res, err = sms.VisibilityPolicy.FilterSocialMedia(viewerId, res)
+	if err != nil {
+		return []model.SocialMediaResponse{}, err
+	}
+
 	for _, val := range res {
 		socialMediaRespons = append(socialMediaRespons, model.SocialMediaResponse{
 			ID:             val.ID,
 			UserID:         val.UserID,
 			Name:           val.Name,
 			SocialMediaURL: val.SocialMediaURL,
+			Platform:       val.Platform,
+			Handle:         val.Handle,
+			DisplayURL:     model.DisplaySocialMediaURL(val.SocialMediaURL),
+			Position:       val.Position,
+			Visibility:     val.Visibility,
+			VerifiedAt:     val.VerifiedAt,
 			CreatedAt:      val.CreatedAt,
 			UpdatedAt:      val.UpdatedAt,
 		})
@@ -41,11 +63,43 @@ func (sms *SocialMediaService) GetAll() ([]model.SocialMediaResponse, error) {
 	return socialMediaRespons, nil
 }
 
-func (sms *SocialMediaService) GetById(id string) (model.SocialMediaResponse, error) {
-	socialMedia, err := sms.SocialMediaRepository.GetOne(id)
+// GetByUser lists the links of a user, given by username or id, that viewerId
+// may see, in the owner's order.
+func (sms *SocialMediaService) GetByUser(usernameOrId string, viewerId string) ([]model.SocialMediaResponse, error) {
+	user, err := sms.UserRepository.GetByUsername(usernameOrId)
+	if errors.Is(err, model.ErrorNotFound) {
+		user, err = sms.UserRepository.GetOne(usernameOrId)
+	}
+	if err != nil {
+		return []model.SocialMediaResponse{}, err
+	}
 
+	res, err := sms.SocialMediaRepository.GetByUserID(user.ID)
 	if err != nil {
-		if err != model.ErrorNotFound {
+		return []model.SocialMediaResponse{}, err
+	}
+
+	res, err = sms.VisibilityPolicy.FilterSocialMedia(viewerId, res)
+	if err != nil {
+		return []model.SocialMediaResponse{}, err
+	}
+	return toSocialMediaResponses(res), nil
+}
+
+// Mine lists all links of userId in their order, private ones included.
+func (sms *SocialMediaService) Mine(userId string) ([]model.SocialMediaResponse, error) {
+	res, err := sms.SocialMediaRepository.GetByUserID(userId)
+	if err != nil {
+		return []model.SocialMediaResponse{}, err
+	}
+	return toSocialMediaResponses(res), nil
+}
+
+func (sms *SocialMediaService) GetById(id string, viewerId string) (model.SocialMediaResponse, error) {
+	socialMedia, err := sms.getVisible(id, viewerId)
+
+	if err != nil {
+		if !errors.Is(err, model.ErrorNotFound) {
 			return model.SocialMediaResponse{}, err
 		}
 		return model.SocialMediaResponse{}, model.ErrorNotFound
@@ -56,25 +110,53 @@ func (sms *SocialMediaService) GetById(id string) (model.SocialMediaResponse, er
 		UserID:         socialMedia.UserID,
 		Name:           socialMedia.Name,
 		SocialMediaURL: socialMedia.SocialMediaURL,
+		Platform:       socialMedia.Platform,
+		Handle:         socialMedia.Handle,
+		DisplayURL:     model.DisplaySocialMediaURL(socialMedia.SocialMediaURL),
+		Position:       socialMedia.Position,
+		Visibility:     socialMedia.Visibility,
+		VerifiedAt:     socialMedia.VerifiedAt,
 		CreatedAt:      socialMedia.CreatedAt,
 		UpdatedAt:      socialMedia.UpdatedAt,
 	}, nil
 }
 
 func (sms *SocialMediaService) Add(request model.SocialMediaCreateRequest, userId string) (model.SocialMediaCreateResponse, error) {
+	visibility := request.Visibility
+	if visibility == "" {
+		visibility = model.SocialMediaVisibilityPublic
+	}
+	if !model.ValidSocialMediaVisibility(visibility) {
+		return model.SocialMediaCreateResponse{}, model.ErrorInvalidSocialMediaVisibility
+	}
+
+	link, existing, err := sms.resolveLink(request.Platform, request.SocialMediaURL, userId, "")
+	if err != nil {
+		return model.SocialMediaCreateResponse{}, err
+	}
+
+	// New links go last.
+	position := 0
+	for _, socialMedia := range existing {
+		if socialMedia.Position >= position {
+			position = socialMedia.Position + 1
+		}
+	}
+
 	id := helper.GenerateID()
 	socialMedia := model.SocialMedia{
 		ID:             id,
-		Name:           request.Name,
-		SocialMediaURL: request.SocialMediaURL,
+		Name:           socialMediaName(request.Name, link.Platform),
+		SocialMediaURL: link.URL,
+		Platform:       link.Platform,
+		Handle:         link.Handle,
+		Position:       position,
+		Visibility:     visibility,
 		UserID:         userId,
 	}
 
 	res, err := sms.SocialMediaRepository.Save(socialMedia)
 	if err != nil {
-		if err != model.ErrorNotFound {
-			return model.SocialMediaCreateResponse{}, model.ErrorNotFound
-		}
 		return model.SocialMediaCreateResponse{}, err
 	}
 
@@ -83,6 +165,11 @@ func (sms *SocialMediaService) Add(request model.SocialMediaCreateRequest, userI
 		UserID:         res.UserID,
 		Name:           res.Name,
 		SocialMediaURL: res.SocialMediaURL,
+		Platform:       res.Platform,
+		Handle:         res.Handle,
+		DisplayURL:     model.DisplaySocialMediaURL(res.SocialMediaURL),
+		Position:       res.Position,
+		Visibility:     res.Visibility,
 		CreatedAt:      res.CreatedAt,
 	}, nil
 
@@ -91,7 +178,7 @@ func (sms *SocialMediaService) Add(request model.SocialMediaCreateRequest, userI
 func (sms *SocialMediaService) UpdateById(request model.SocialMediaUpdateRequest, id string, userId string) (model.SocialMediaUpdateResponse, error) {
 	getById, err := sms.SocialMediaRepository.GetOne(id)
 	if err != nil {
-		if err != model.ErrorNotFound {
+		if !errors.Is(err, model.ErrorNotFound) {
 			return model.SocialMediaUpdateResponse{}, err
 		}
 		return model.SocialMediaUpdateResponse{}, model.ErrorNotFound
@@ -101,9 +188,31 @@ func (sms *SocialMediaService) UpdateById(request model.SocialMediaUpdateRequest
 		return model.SocialMediaUpdateResponse{}, model.ErrorForbiddenAccess
 	}
 
+	visibility := request.Visibility
+	if visibility == "" {
+		visibility = getById.Visibility
+	}
+	if !model.ValidSocialMediaVisibility(visibility) {
+		return model.SocialMediaUpdateResponse{}, model.ErrorInvalidSocialMediaVisibility
+	}
+
+	link, _, err := sms.resolveLink(request.Platform, request.SocialMediaURL, userId, id)
+	if err != nil {
+		return model.SocialMediaUpdateResponse{}, err
+	}
+
 	socialMedia := model.SocialMedia{
-		Name:           request.Name,
-		SocialMediaURL: request.SocialMediaURL,
+		Name:           socialMediaName(request.Name, link.Platform),
+		SocialMediaURL: link.URL,
+		Platform:       link.Platform,
+		Handle:         link.Handle,
+		Visibility:     visibility,
+	}
+	// A verification only holds for the URL it was made for.
+	if link.URL == getById.SocialMediaURL {
+		socialMedia.VerificationToken = getById.VerificationToken
+		socialMedia.VerifiedAt = getById.VerifiedAt
+		socialMedia.VerificationCheckedAt = getById.VerificationCheckedAt
 	}
 
 	res, err := sms.SocialMediaRepository.Update(socialMedia, id)
@@ -116,6 +225,12 @@ func (sms *SocialMediaService) UpdateById(request model.SocialMediaUpdateRequest
 		UserID:         res.UserID,
 		Name:           res.Name,
 		SocialMediaURL: res.SocialMediaURL,
+		Platform:       res.Platform,
+		Handle:         res.Handle,
+		DisplayURL:     model.DisplaySocialMediaURL(res.SocialMediaURL),
+		Position:       res.Position,
+		Visibility:     res.Visibility,
+		VerifiedAt:     res.VerifiedAt,
 
 		CreatedAt: res.CreatedAt,
 		UpdatedAt: res.UpdatedAt,
@@ -125,7 +240,7 @@ func (sms *SocialMediaService) UpdateById(request model.SocialMediaUpdateRequest
 func (sms *SocialMediaService) DeleteById(id string, userId string) error {
 	getById, err := sms.SocialMediaRepository.GetOne(id)
 	if err != nil {
-		if err != model.ErrorNotFound {
+		if !errors.Is(err, model.ErrorNotFound) {
 			return err
 		}
 		return model.ErrorNotFound
@@ -143,3 +258,292 @@ func (sms *SocialMediaService) DeleteById(id string, userId string) error {
 
 	return nil
 }
+
+// StartVerification issues a new challenge for a link of userId, to be placed
+// on the linked page before calling Verify. An earlier verification of the
+// link is dropped.
+func (sms *SocialMediaService) StartVerification(id string, userId string) (model.SocialMediaVerificationResponse, error) {
+	socialMedia, err := sms.getOwned(id, userId)
+	if err != nil {
+		return model.SocialMediaVerificationResponse{}, err
+	}
+
+	token := strings.ReplaceAll(helper.GenerateID(), "-", "")
+	err = sms.SocialMediaRepository.UpdateVerificationToken(socialMedia.ID, token)
+	if err != nil {
+		return model.SocialMediaVerificationResponse{}, err
+	}
+
+	return model.SocialMediaVerificationResponse{
+		SocialMediaID:  socialMedia.ID,
+		SocialMediaURL: socialMedia.SocialMediaURL,
+		Challenge:      model.SocialMediaChallenge(token),
+	}, nil
+}
+
+// Verify fetches the linked page and marks the link verified when it shows
+// the challenge.
+func (sms *SocialMediaService) Verify(id string, userId string) (model.SocialMediaVerificationResponse, error) {
+	socialMedia, err := sms.getOwned(id, userId)
+	if err != nil {
+		return model.SocialMediaVerificationResponse{}, err
+	}
+
+	found, err := sms.Verifier.Check(context.Background(), socialMedia)
+	if err != nil {
+		return model.SocialMediaVerificationResponse{}, err
+	}
+	if !found {
+		return model.SocialMediaVerificationResponse{}, model.ErrorChallengeNotFound
+	}
+
+	now := time.Now()
+	err = sms.SocialMediaRepository.UpdateVerification(socialMedia.ID, &now, now)
+	if err != nil {
+		return model.SocialMediaVerificationResponse{}, err
+	}
+
+	return model.SocialMediaVerificationResponse{
+		SocialMediaID:  socialMedia.ID,
+		SocialMediaURL: socialMedia.SocialMediaURL,
+		Challenge:      model.SocialMediaChallenge(socialMedia.VerificationToken),
+		VerifiedAt:     &now,
+	}, nil
+}
+
+func (sms *SocialMediaService) getOwned(id string, userId string) (model.SocialMedia, error) {
+	socialMedia, err := sms.SocialMediaRepository.GetOne(id)
+	if err != nil {
+		return model.SocialMedia{}, err
+	}
+	if socialMedia.UserID != userId {
+		return model.SocialMedia{}, model.ErrorForbiddenAccess
+	}
+	return socialMedia, nil
+}
+
+// Reorder moves the links of userId into the order of request, which must
+// list every one of them once.
+func (sms *SocialMediaService) Reorder(request model.SocialMediaOrderRequest, userId string) ([]model.SocialMediaResponse, error) {
+	existing, err := sms.SocialMediaRepository.GetByUserID(userId)
+	if err != nil {
+		return []model.SocialMediaResponse{}, err
+	}
+
+	byId := make(map[string]model.SocialMedia, len(existing))
+	for _, socialMedia := range existing {
+		byId[socialMedia.ID] = socialMedia
+	}
+	if len(request.SocialMediaIDs) != len(existing) || len(uniqueIDs(request.SocialMediaIDs)) != len(existing) {
+		return []model.SocialMediaResponse{}, model.ErrorInvalidSocialMediaOrder
+	}
+	for _, id := range request.SocialMediaIDs {
+		if _, ok := byId[id]; !ok {
+			return []model.SocialMediaResponse{}, model.ErrorInvalidSocialMediaOrder
+		}
+	}
+
+	err = sms.SocialMediaRepository.UpdatePositions(userId, request.SocialMediaIDs)
+	if err != nil {
+		return []model.SocialMediaResponse{}, err
+	}
+
+	socialMediaResponse := make([]model.SocialMediaResponse, 0, len(existing))
+	for position, id := range request.SocialMediaIDs {
+		val := byId[id]
+		socialMediaResponse = append(socialMediaResponse, model.SocialMediaResponse{
+			ID:             val.ID,
+			UserID:         val.UserID,
+			Name:           val.Name,
+			SocialMediaURL: val.SocialMediaURL,
+			Platform:       val.Platform,
+			Handle:         val.Handle,
+			DisplayURL:     model.DisplaySocialMediaURL(val.SocialMediaURL),
+			Position:       position,
+			Visibility:     val.Visibility,
+			VerifiedAt:     val.VerifiedAt,
+			CreatedAt:      val.CreatedAt,
+			UpdatedAt:      val.UpdatedAt,
+		})
+	}
+	return socialMediaResponse, nil
+}
+
+// Click returns where to send viewerId for a link and counts the click for
+// the day. Only catalog links matching their platform are redirected to, so
+// the endpoint cannot be used to bounce visitors to arbitrary sites. Clicks of
+// the owner are not counted, and a failure to count does not stop the redirect.
+func (sms *SocialMediaService) Click(id string, viewerId string) (model.SocialMediaRedirect, error) {
+	socialMedia, err := sms.getVisible(id, viewerId)
+	if err != nil {
+		return model.SocialMediaRedirect{}, err
+	}
+
+	if socialMedia.UserID != viewerId {
+		err = sms.SocialMediaRepository.SaveClick(socialMedia.ID, clickDay(time.Now()))
+		if err != nil {
+			log.Printf("social media clicks: %v", err)
+		}
+	}
+	return model.SocialMediaRedirect{
+		URL:          socialMedia.SocialMediaURL,
+		Interstitial: !model.IsCatalogSocialMediaLink(socialMedia.Platform, socialMedia.SocialMediaURL),
+	}, nil
+}
+
+// Stats returns the daily clicks of every link of userId over the last
+// request.Days days, today included.
+func (sms *SocialMediaService) Stats(request model.SocialMediaStatsRequest, userId string) (model.SocialMediaStatsListResponse, error) {
+	days := request.Days
+	if days < 1 {
+		days = model.DefaultSocialMediaStatsDays
+	}
+	if days > model.MaxSocialMediaStatsDays {
+		days = model.MaxSocialMediaStatsDays
+	}
+	to := clickDay(time.Now())
+	from := to.AddDate(0, 0, -(days - 1))
+
+	links, err := sms.SocialMediaRepository.GetByUserID(userId)
+	if err != nil {
+		return model.SocialMediaStatsListResponse{}, err
+	}
+	ids := make([]string, 0, len(links))
+	for _, link := range links {
+		ids = append(ids, link.ID)
+	}
+
+	clicks, err := sms.SocialMediaRepository.GetClicks(ids, from)
+	if err != nil {
+		return model.SocialMediaStatsListResponse{}, err
+	}
+	clicksOf := make(map[string]map[string]int64)
+	for _, click := range clicks {
+		if clicksOf[click.SocialMediaID] == nil {
+			clicksOf[click.SocialMediaID] = make(map[string]int64)
+		}
+		clicksOf[click.SocialMediaID][click.Day.Format(time.DateOnly)] += click.Clicks
+	}
+
+	stats := make([]model.SocialMediaStatsResponse, 0, len(links))
+	for _, link := range links {
+		linkStats := model.SocialMediaStatsResponse{
+			SocialMediaID: link.ID,
+			Name:          link.Name,
+			Platform:      link.Platform,
+			DisplayURL:    model.DisplaySocialMediaURL(link.SocialMediaURL),
+			Daily:         make([]model.SocialMediaDailyClicksResponse, 0, days),
+		}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			date := day.Format(time.DateOnly)
+			count := clicksOf[link.ID][date]
+			linkStats.TotalClicks += count
+			linkStats.Daily = append(linkStats.Daily, model.SocialMediaDailyClicksResponse{
+				Date:   date,
+				Clicks: count,
+			})
+		}
+		stats = append(stats, linkStats)
+	}
+
+	return model.SocialMediaStatsListResponse{
+		From:        from.Format(time.DateOnly),
+		To:          to.Format(time.DateOnly),
+		SocialMedia: stats,
+	}, nil
+}
+
+// getVisible returns the link if viewerId may see it, ErrorNotFound otherwise.
+func (sms *SocialMediaService) getVisible(id string, viewerId string) (model.SocialMedia, error) {
+	socialMedia, err := sms.SocialMediaRepository.GetOne(id)
+	if err != nil {
+		return model.SocialMedia{}, err
+	}
+
+	visible, err := sms.VisibilityPolicy.FilterSocialMedia(viewerId, []model.SocialMedia{socialMedia})
+	if err != nil {
+		return model.SocialMedia{}, err
+	}
+	if len(visible) == 0 {
+		return model.SocialMedia{}, model.ErrorNotFound
+	}
+	return socialMedia, nil
+}
+
+// clickDay is the UTC day clicks at t are counted for.
+func clickDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Platforms lists the catalog, custom links last.
+func (sms *SocialMediaService) Platforms() []model.SocialMediaPlatformResponse {
+	platforms := make([]model.SocialMediaPlatformResponse, 0, len(model.SocialMediaPlatforms)+1)
+	for _, platform := range model.SocialMediaPlatforms {
+		platforms = append(platforms, model.SocialMediaPlatformResponse{
+			Name:  platform.Name,
+			Label: platform.Label,
+		})
+	}
+	return append(platforms, model.SocialMediaPlatformResponse{
+		Name:  model.SocialMediaCustom,
+		Label: socialMediaName("", model.SocialMediaCustom),
+	})
+}
+
+// resolveLink normalizes a link of userId and rejects it when the user already
+// has a link for the same catalog platform or the same URL. excludeId is the
+// link being updated. The user's current links are returned along.
+func (sms *SocialMediaService) resolveLink(platform string, rawURL string, userId string, excludeId string) (model.SocialMediaLink, []model.SocialMedia, error) {
+	link, err := model.ResolveSocialMediaLink(platform, rawURL)
+	if err != nil {
+		return model.SocialMediaLink{}, nil, err
+	}
+
+	existing, err := sms.SocialMediaRepository.GetByUserID(userId)
+	if err != nil {
+		return model.SocialMediaLink{}, nil, err
+	}
+	for _, socialMedia := range existing {
+		if socialMedia.ID == excludeId {
+			continue
+		}
+		samePlatform := socialMedia.Platform == link.Platform && link.Platform != model.SocialMediaCustom
+		if samePlatform || socialMedia.SocialMediaURL == link.URL {
+			return model.SocialMediaLink{}, nil, model.ErrorDuplicateSocialMedia
+		}
+	}
+	return link, existing, nil
+}
+
+// socialMediaName is the given name, or the platform label when left empty.
+func socialMediaName(name string, platform string) string {
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	if catalog, ok := model.FindSocialMediaPlatform(platform); ok {
+		return catalog.Label
+	}
+	return "Website"
+}
+
+func toSocialMediaResponses(socialMedia []model.SocialMedia) []model.SocialMediaResponse {
+	socialMediaResponse := make([]model.SocialMediaResponse, 0, len(socialMedia))
+	for _, val := range socialMedia {
+		socialMediaResponse = append(socialMediaResponse, model.SocialMediaResponse{
+			ID:             val.ID,
+			UserID:         val.UserID,
+			Name:           val.Name,
+			SocialMediaURL: val.SocialMediaURL,
+			Platform:       val.Platform,
+			Handle:         val.Handle,
+			DisplayURL:     model.DisplaySocialMediaURL(val.SocialMediaURL),
+			Position:       val.Position,
+			Visibility:     val.Visibility,
+			VerifiedAt:     val.VerifiedAt,
+			CreatedAt:      val.CreatedAt,
+			UpdatedAt:      val.UpdatedAt,
+		})
+	}
+	return socialMediaResponse
+}
@@ -1,10 +1,15 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"mygram/model"
 	"mygram/repository/mocks"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -24,6 +29,7 @@ func TestSocialMediaService_GetAll(t *testing.T) {
 			name: "Case #1 - Success",
 			sms: &SocialMediaService{
 				SocialMediaRepository: socialMediaRepository,
+				VisibilityPolicy:      &VisibilityPolicy{},
 			},
 			want: []model.SocialMediaResponse{
 				{
@@ -31,12 +37,14 @@ func TestSocialMediaService_GetAll(t *testing.T) {
 					UserID:         "1",
 					Name:           "Twitter",
 					SocialMediaURL: "twitter.com/adi",
+					DisplayURL:     "twitter.com/adi",
 				},
 				{
 					ID:             "2",
 					UserID:         "1",
 					Name:           "Telegram",
 					SocialMediaURL: "@adi",
+					DisplayURL:     "@adi",
 				},
 			},
 			mockFunc: func() {
@@ -61,6 +69,7 @@ func TestSocialMediaService_GetAll(t *testing.T) {
 			name: "Case #2 - Success (Empty Data)",
 			sms: &SocialMediaService{
 				SocialMediaRepository: socialMediaRepository,
+				VisibilityPolicy:      &VisibilityPolicy{},
 			},
 			want: []model.SocialMediaResponse{},
 			mockFunc: func() {
@@ -72,7 +81,7 @@ func TestSocialMediaService_GetAll(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
-			got, err := tt.sms.GetAll()
+			got, err := tt.sms.GetAll("1")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SocialMediaService.GetAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -104,6 +113,7 @@ func TestSocialMediaService_GetById(t *testing.T) {
 			name: "Case #1 - Success",
 			sms: &SocialMediaService{
 				SocialMediaRepository: socialMediaRepository,
+				VisibilityPolicy:      &VisibilityPolicy{},
 			},
 			args: args{
 				id: "1",
@@ -113,6 +123,7 @@ func TestSocialMediaService_GetById(t *testing.T) {
 				UserID:         "1",
 				Name:           "Twitter",
 				SocialMediaURL: "twitter.com/adi",
+				DisplayURL:     "twitter.com/adi",
 			},
 			mockFunc: func() {
 				socialMediaRepository.On("GetOne", mock.Anything).Return(model.SocialMedia{
@@ -128,6 +139,7 @@ func TestSocialMediaService_GetById(t *testing.T) {
 			name: "Case #2 - Not Found (Failed)",
 			sms: &SocialMediaService{
 				SocialMediaRepository: socialMediaRepository,
+				VisibilityPolicy:      &VisibilityPolicy{},
 			},
 			args: args{
 				id: "1",
@@ -142,7 +154,7 @@ func TestSocialMediaService_GetById(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
-			got, err := tt.sms.GetById(tt.args.id)
+			got, err := tt.sms.GetById(tt.args.id, "1")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SocialMediaService.GetById() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -184,21 +196,61 @@ func TestSocialMediaService_Add(t *testing.T) {
 				ID:             "1",
 				UserID:         "1",
 				Name:           "Twitter",
-				SocialMediaURL: "twitter.com/adiwahyudi",
+				SocialMediaURL: "https://x.com/adiwahyudi",
+				Platform:       model.SocialMediaX,
+				Handle:         "adiwahyudi",
+				DisplayURL:     "x.com/adiwahyudi",
 			},
 			mockFunc: func() {
-				socialMediaRepository.On("Save", mock.Anything).Return(model.SocialMedia{
+				socialMediaRepository.On("GetByUserID", "1").Return([]model.SocialMedia{}, nil).Once()
+				socialMediaRepository.On("Save", mock.MatchedBy(func(socialMedia model.SocialMedia) bool {
+					return socialMedia.SocialMediaURL == "https://x.com/adiwahyudi" && socialMedia.Handle == "adiwahyudi"
+				})).Return(model.SocialMedia{
 					ID:             "1",
 					UserID:         "1",
 					Name:           "Twitter",
-					SocialMediaURL: "twitter.com/adiwahyudi",
+					SocialMediaURL: "https://x.com/adiwahyudi",
+					Platform:       model.SocialMediaX,
+					Handle:         "adiwahyudi",
 				}, nil).Once()
 			},
 			wantErr: false,
 		},
+		{
+			name: "Case #2 - Fail (Duplicate Platform)",
+			sms:  &SocialMediaService{SocialMediaRepository: socialMediaRepository},
+			args: args{
+				userId: "1",
+				request: model.SocialMediaCreateRequest{
+					SocialMediaURL: "https://x.com/adi_w",
+					Platform:       "x",
+				},
+			},
+			want: model.SocialMediaCreateResponse{},
+			mockFunc: func() {
+				socialMediaRepository.On("GetByUserID", "1").Return([]model.SocialMedia{
+					{ID: "2", UserID: "1", Platform: model.SocialMediaX, SocialMediaURL: "https://x.com/adiwahyudi"},
+				}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case #3 - Fail (URL Of Another Platform)",
+			sms:  &SocialMediaService{SocialMediaRepository: socialMediaRepository},
+			args: args{
+				userId: "1",
+				request: model.SocialMediaCreateRequest{
+					SocialMediaURL: "https://github.com/adiwahyudi",
+					Platform:       "instagram",
+				},
+			},
+			want:     model.SocialMediaCreateResponse{},
+			mockFunc: func() {},
+			wantErr:  true,
+		},
 
 		// {
-		// 	name: "Case #2 - Fail (Bad Request)",
+		// 	name: "Case #4 - Fail (Bad Request)",
 		// 	sms:  &SocialMediaService{SocialMediaRepository: socialMediaRepository},
 		// 	want: model.SocialMediaCreateResponse{},
 		// 	mockFunc: func() {
@@ -221,3 +273,358 @@ func TestSocialMediaService_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveSocialMediaLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		url      string
+		want     model.SocialMediaLink
+		wantErr  error
+	}{
+		{name: "Instagram URL", url: "http://www.Instagram.com/adi.w/", want: model.SocialMediaLink{Platform: "instagram", Handle: "adi.w", URL: "https://www.instagram.com/adi.w"}},
+		{name: "GitHub without scheme", url: "github.com/adiwahyudi", want: model.SocialMediaLink{Platform: "github", Handle: "adiwahyudi", URL: "https://github.com/adiwahyudi"}},
+		{name: "GitHub repository", url: "https://github.com/adiwahyudi/mygram", wantErr: model.ErrorInvalidSocialMediaURL},
+		{name: "LinkedIn", platform: "LinkedIn", url: "https://linkedin.com/in/adi-wahyudi", want: model.SocialMediaLink{Platform: "linkedin", Handle: "adi-wahyudi", URL: "https://www.linkedin.com/in/adi-wahyudi"}},
+		{name: "Twitter becomes X", url: "https://mobile.twitter.com/adi_w", want: model.SocialMediaLink{Platform: "x", Handle: "adi_w", URL: "https://x.com/adi_w"}},
+		{name: "YouTube handle", url: "https://m.youtube.com/@adiwahyudi", want: model.SocialMediaLink{Platform: "youtube", Handle: "adiwahyudi", URL: "https://www.youtube.com/@adiwahyudi"}},
+		{name: "Discord invite", url: "https://discord.com/invite/mygram", want: model.SocialMediaLink{Platform: "discord", Handle: "mygram", URL: "https://discord.gg/mygram"}},
+		{name: "Custom", url: "HTTPS://Example.com/about/#team", want: model.SocialMediaLink{Platform: "custom", URL: "https://example.com/about"}},
+		{name: "Custom on a catalog host", platform: "custom", url: "https://github.com/adiwahyudi/mygram", want: model.SocialMediaLink{Platform: "custom", URL: "https://github.com/adiwahyudi/mygram"}},
+		{name: "Wrong platform", platform: "github", url: "https://x.com/adi", wantErr: model.ErrorInvalidSocialMediaURL},
+		{name: "Unknown platform", platform: "myspace", url: "https://myspace.com/adi", wantErr: model.ErrorInvalidSocialMediaPlatform},
+		{name: "Not http", url: "javascript:alert(1)", wantErr: model.ErrorInvalidSocialMediaURL},
+		{name: "No host", url: "@adi", wantErr: model.ErrorInvalidSocialMediaURL},
+		{name: "Bare handle", platform: "instagram", url: "adi.w", wantErr: model.ErrorInvalidSocialMediaURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.ResolveSocialMediaLink(tt.platform, tt.url)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveSocialMediaLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveSocialMediaLink() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocialMediaService_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/verified":
+			fmt.Fprint(w, "<html><body><p>Hi! mygram-verification=token1</p></body></html>")
+		case "/missing":
+			fmt.Fprint(w, "<html><body><p>Hi!</p></body></html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		link    model.SocialMedia
+		wantErr error
+	}{
+		{name: "Challenge found", link: model.SocialMedia{ID: "1", UserID: "1", SocialMediaURL: server.URL + "/verified", VerificationToken: "token1"}},
+		{name: "Challenge missing", link: model.SocialMedia{ID: "1", UserID: "1", SocialMediaURL: server.URL + "/missing", VerificationToken: "token1"}, wantErr: model.ErrorChallengeNotFound},
+		{name: "Page not found", link: model.SocialMedia{ID: "1", UserID: "1", SocialMediaURL: server.URL + "/gone", VerificationToken: "token1"}, wantErr: model.ErrorVerificationFetch},
+		{name: "Not started", link: model.SocialMedia{ID: "1", UserID: "1", SocialMediaURL: server.URL + "/verified"}, wantErr: model.ErrorVerificationNotStarted},
+		{name: "Other owner", link: model.SocialMedia{ID: "1", UserID: "2", SocialMediaURL: server.URL + "/verified", VerificationToken: "token1"}, wantErr: model.ErrorForbiddenAccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socialMediaRepository := mocks.NewISocialMediaRepository(t)
+			sms := &SocialMediaService{
+				SocialMediaRepository: socialMediaRepository,
+				Verifier: &SocialMediaVerifier{
+					SocialMediaRepository: socialMediaRepository,
+					Fetcher:               &HTTPFetcher{Client: server.Client(), MaxBytes: 1 << 20},
+				},
+			}
+
+			socialMediaRepository.On("GetOne", "1").Return(tt.link, nil).Once()
+			if tt.wantErr == nil {
+				socialMediaRepository.On("UpdateVerification", "1", mock.AnythingOfType("*time.Time"), mock.AnythingOfType("time.Time")).Return(nil).Once()
+			}
+
+			got, err := sms.Verify("1", "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SocialMediaService.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.VerifiedAt == nil {
+				t.Errorf("SocialMediaService.Verify() = %+v, want verified", got)
+			}
+		})
+	}
+}
+
+func TestSocialMediaService_UpdateById_ResetsVerification(t *testing.T) {
+	socialMediaRepository := mocks.NewISocialMediaRepository(t)
+	sms := &SocialMediaService{SocialMediaRepository: socialMediaRepository}
+
+	verifiedAt := time.Now()
+	link := model.SocialMedia{
+		ID:                "1",
+		UserID:            "1",
+		SocialMediaURL:    "https://github.com/adi",
+		Platform:          model.SocialMediaGitHub,
+		Visibility:        model.SocialMediaVisibilityPublic,
+		VerificationToken: "token1",
+		VerifiedAt:        &verifiedAt,
+	}
+	socialMediaRepository.On("GetOne", "1").Return(link, nil)
+	socialMediaRepository.On("GetByUserID", "1").Return([]model.SocialMedia{link}, nil)
+
+	for _, tt := range []struct {
+		url          string
+		wantVerified bool
+	}{
+		{url: "github.com/adi/", wantVerified: true},
+		{url: "github.com/adiwahyudi", wantVerified: false},
+	} {
+		socialMediaRepository.On("Update", mock.MatchedBy(func(socialMedia model.SocialMedia) bool {
+			return (socialMedia.VerifiedAt != nil) == tt.wantVerified && (socialMedia.VerificationToken != "") == tt.wantVerified
+		}), "1").Return(func(socialMedia model.SocialMedia, id string) (model.SocialMedia, error) {
+			socialMedia.ID = id
+			return socialMedia, nil
+		}).Once()
+
+		got, err := sms.UpdateById(model.SocialMediaUpdateRequest{SocialMediaURL: tt.url}, "1", "1")
+		if err != nil {
+			t.Fatalf("SocialMediaService.UpdateById(%q) error = %v", tt.url, err)
+		}
+		if (got.VerifiedAt != nil) != tt.wantVerified {
+			t.Errorf("SocialMediaService.UpdateById(%q) verified_at = %v, want verified %v", tt.url, got.VerifiedAt, tt.wantVerified)
+		}
+	}
+}
+
+func TestSocialMediaService_Reorder(t *testing.T) {
+	links := []model.SocialMedia{
+		{ID: "a", UserID: "1", Position: 0},
+		{ID: "b", UserID: "1", Position: 1},
+		{ID: "c", UserID: "1", Position: 2},
+	}
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantErr error
+	}{
+		{name: "New order", ids: []string{"c", "a", "b"}},
+		{name: "Missing link", ids: []string{"c", "a"}, wantErr: model.ErrorInvalidSocialMediaOrder},
+		{name: "Repeated link", ids: []string{"c", "a", "a"}, wantErr: model.ErrorInvalidSocialMediaOrder},
+		{name: "Link of another user", ids: []string{"c", "a", "x"}, wantErr: model.ErrorInvalidSocialMediaOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socialMediaRepository := mocks.NewISocialMediaRepository(t)
+			sms := &SocialMediaService{SocialMediaRepository: socialMediaRepository}
+
+			socialMediaRepository.On("GetByUserID", "1").Return(links, nil).Once()
+			if tt.wantErr == nil {
+				socialMediaRepository.On("UpdatePositions", "1", tt.ids).Return(nil).Once()
+			}
+
+			got, err := sms.Reorder(model.SocialMediaOrderRequest{SocialMediaIDs: tt.ids}, "1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SocialMediaService.Reorder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i, link := range got {
+				if link.ID != tt.ids[i] || link.Position != i {
+					t.Errorf("SocialMediaService.Reorder()[%d] = %s at %d, want %s at %d", i, link.ID, link.Position, tt.ids[i], i)
+				}
+			}
+		})
+	}
+}
+
+func TestSocialMediaService_Click(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility string
+		viewerId   string
+		following  []string
+		platform   string
+		url        string
+		wantCount  bool
+		// wantInterstitial is set for links that must not be redirected to.
+		wantInterstitial bool
+		wantErr          error
+	}{
+		{name: "Public link", visibility: model.SocialMediaVisibilityPublic, viewerId: "2", wantCount: true},
+		{name: "Anonymous on public link", visibility: model.SocialMediaVisibilityPublic, viewerId: "", wantCount: true},
+		{name: "Owner is not counted", visibility: model.SocialMediaVisibilityPrivate, viewerId: "1"},
+		{name: "Follower on followers only link", visibility: model.SocialMediaVisibilityFollowers, viewerId: "2", following: []string{"1"}, wantCount: true},
+		{name: "Non follower on followers only link", visibility: model.SocialMediaVisibilityFollowers, viewerId: "2", following: []string{}, wantErr: model.ErrorNotFound},
+		{name: "Private link", visibility: model.SocialMediaVisibilityPrivate, viewerId: "2", wantErr: model.ErrorNotFound},
+		{name: "Custom link is not redirected to", visibility: model.SocialMediaVisibilityPublic, viewerId: "2", platform: model.SocialMediaCustom, url: "https://evil.example/login", wantCount: true, wantInterstitial: true},
+		{name: "Platform link off its platform is not redirected to", visibility: model.SocialMediaVisibilityPublic, viewerId: "2", url: "https://evil.example/adi", wantCount: true, wantInterstitial: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.platform == "" {
+				tt.platform = model.SocialMediaGitHub
+			}
+			if tt.url == "" {
+				tt.url = "https://github.com/adi"
+			}
+
+			socialMediaRepository := mocks.NewISocialMediaRepository(t)
+			userRepository := mocks.NewIUserRepository(t)
+			followRepository := mocks.NewIFollowRepository(t)
+			blockRepository := mocks.NewIBlockRepository(t)
+
+			sms := &SocialMediaService{
+				SocialMediaRepository: socialMediaRepository,
+				VisibilityPolicy:      NewVisibilityPolicy(userRepository, followRepository, nil, blockRepository, nil),
+			}
+
+			socialMediaRepository.On("GetOne", "l1").Return(model.SocialMedia{
+				ID:             "l1",
+				UserID:         "1",
+				SocialMediaURL: tt.url,
+				Platform:       tt.platform,
+				Visibility:     tt.visibility,
+			}, nil).Once()
+			blockRepository.On("GetBlockedIDs", tt.viewerId, []string{"1"}).Return([]string{}, nil).Maybe()
+			userRepository.On("GetByIDs", []string{"1"}).Return([]model.User{{ID: "1"}}, nil).Maybe()
+			if tt.following != nil {
+				followRepository.On("GetAcceptedFollowingIDs", tt.viewerId, []string{"1"}).Return(tt.following, nil).Once()
+			}
+			if tt.wantCount {
+				socialMediaRepository.On("SaveClick", "l1", clickDay(time.Now())).Return(nil).Once()
+			}
+
+			got, err := sms.Click("l1", tt.viewerId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SocialMediaService.Click() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != (model.SocialMediaRedirect{URL: tt.url, Interstitial: tt.wantInterstitial}) {
+				t.Errorf("SocialMediaService.Click() = %+v, want %q with interstitial %v", got, tt.url, tt.wantInterstitial)
+			}
+		})
+	}
+}
+
+func TestSocialMediaService_Stats(t *testing.T) {
+	socialMediaRepository := mocks.NewISocialMediaRepository(t)
+	sms := &SocialMediaService{SocialMediaRepository: socialMediaRepository}
+
+	today := clickDay(time.Now())
+	from := today.AddDate(0, 0, -6)
+	socialMediaRepository.On("GetByUserID", "1").Return([]model.SocialMedia{
+		{ID: "a", UserID: "1", SocialMediaURL: "https://github.com/adi"},
+		{ID: "b", UserID: "1", SocialMediaURL: "https://x.com/adi"},
+	}, nil).Once()
+	socialMediaRepository.On("GetClicks", []string{"a", "b"}, from).Return([]model.SocialMediaClick{
+		{SocialMediaID: "a", Day: from, Clicks: 2},
+		{SocialMediaID: "a", Day: today, Clicks: 5},
+	}, nil).Once()
+
+	got, err := sms.Stats(model.SocialMediaStatsRequest{Days: 7}, "1")
+	if err != nil {
+		t.Fatalf("SocialMediaService.Stats() error = %v", err)
+	}
+
+	if got.From != from.Format(time.DateOnly) || got.To != today.Format(time.DateOnly) {
+		t.Errorf("SocialMediaService.Stats() range = %s..%s", got.From, got.To)
+	}
+	a, b := got.SocialMedia[0], got.SocialMedia[1]
+	if a.TotalClicks != 7 || len(a.Daily) != 7 || a.Daily[0].Clicks != 2 || a.Daily[6].Clicks != 5 {
+		t.Errorf("SocialMediaService.Stats() a = %+v", a)
+	}
+	if b.TotalClicks != 0 || len(b.Daily) != 7 {
+		t.Errorf("SocialMediaService.Stats() b = %+v", b)
+	}
+}
+
+func TestSocialMediaService_GetByUser(t *testing.T) {
+	links := []model.SocialMedia{
+		{ID: "a", UserID: "1", Position: 0, Visibility: model.SocialMediaVisibilityPublic},
+		{ID: "b", UserID: "1", Position: 1, Visibility: model.SocialMediaVisibilityFollowers},
+		{ID: "c", UserID: "1", Position: 2, Visibility: model.SocialMediaVisibilityPrivate},
+	}
+
+	tests := []struct {
+		name         string
+		usernameOrId string
+		viewerId     string
+		mockUser     func(userRepository *mocks.IUserRepository)
+		want         []string
+		wantErr      error
+	}{
+		{
+			name:         "Owner by username sees every link",
+			usernameOrId: "adi",
+			viewerId:     "1",
+			mockUser: func(userRepository *mocks.IUserRepository) {
+				userRepository.On("GetByUsername", "adi").Return(model.User{ID: "1"}, nil).Once()
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name:         "Other user by id sees public links",
+			usernameOrId: "1",
+			viewerId:     "2",
+			mockUser: func(userRepository *mocks.IUserRepository) {
+				userRepository.On("GetByUsername", "1").Return(model.User{}, model.ErrorNotFound).Once()
+				userRepository.On("GetOne", "1").Return(model.User{ID: "1"}, nil).Once()
+			},
+			want: []string{"a"},
+		},
+		{
+			name:         "Unknown user",
+			usernameOrId: "nobody",
+			viewerId:     "2",
+			mockUser: func(userRepository *mocks.IUserRepository) {
+				userRepository.On("GetByUsername", "nobody").Return(model.User{}, model.ErrorNotFound).Once()
+				userRepository.On("GetOne", "nobody").Return(model.User{}, model.ErrorNotFound).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socialMediaRepository := mocks.NewISocialMediaRepository(t)
+			userRepository := mocks.NewIUserRepository(t)
+			followRepository := mocks.NewIFollowRepository(t)
+			blockRepository := mocks.NewIBlockRepository(t)
+
+			sms := &SocialMediaService{
+				SocialMediaRepository: socialMediaRepository,
+				UserRepository:        userRepository,
+				VisibilityPolicy:      NewVisibilityPolicy(userRepository, followRepository, nil, blockRepository, nil),
+			}
+
+			tt.mockUser(userRepository)
+			if tt.wantErr == nil {
+				socialMediaRepository.On("GetByUserID", "1").Return(links, nil).Once()
+			}
+			blockRepository.On("GetBlockedIDs", tt.viewerId, []string{"1"}).Return([]string{}, nil).Maybe()
+			userRepository.On("GetByIDs", []string{"1"}).Return([]model.User{{ID: "1"}}, nil).Maybe()
+			followRepository.On("GetAcceptedFollowingIDs", tt.viewerId, []string{"1"}).Return([]string{}, nil).Maybe()
+
+			got, err := sms.GetByUser(tt.usernameOrId, tt.viewerId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SocialMediaService.GetByUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SocialMediaService.GetByUser() = %d links, want %d", len(got), len(tt.want))
+			}
+			for i, link := range got {
+				if link.ID != tt.want[i] {
+					t.Errorf("SocialMediaService.GetByUser()[%d] = %s, want %s", i, link.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"mygram/model"
+	"mygram/repository"
+	"time"
+)
+
+const socialMediaRecheckBatchSize = 50
+
+// SocialMediaVerifier checks that linked pages show their link's challenge.
+// Verified links are checked again once RecheckAfter has passed and lose
+// their verification when the challenge is gone.
+type SocialMediaVerifier struct {
+	SocialMediaRepository repository.ISocialMediaRepository
+	Fetcher               Fetcher
+	Interval              time.Duration
+	RecheckAfter          time.Duration
+}
+
+func NewSocialMediaVerifier(socialMediaRepository repository.ISocialMediaRepository, fetcher Fetcher, interval time.Duration, recheckAfter time.Duration) *SocialMediaVerifier {
+	return &SocialMediaVerifier{
+		SocialMediaRepository: socialMediaRepository,
+		Fetcher:               fetcher,
+		Interval:              interval,
+		RecheckAfter:          recheckAfter,
+	}
+}
+
+// Check fetches the linked page and reports whether it shows the challenge.
+// Fetch failures are returned as ErrorVerificationFetch.
+func (smv *SocialMediaVerifier) Check(ctx context.Context, socialMedia model.SocialMedia) (bool, error) {
+	if socialMedia.VerificationToken == "" {
+		return false, model.ErrorVerificationNotStarted
+	}
+
+	page, err := smv.Fetcher.Fetch(ctx, socialMedia.SocialMediaURL)
+	if err != nil {
+		log.Printf("social media verifier: %v", err)
+		return false, model.ErrorVerificationFetch
+	}
+	return bytes.Contains(page, []byte(model.SocialMediaChallenge(socialMedia.VerificationToken))), nil
+}
+
+// Recheck checks the verified links last checked before now - RecheckAfter
+// and returns how many lost their verification. Links whose page cannot be
+// fetched keep it and are retried on the next run.
+func (smv *SocialMediaVerifier) Recheck(ctx context.Context, now time.Time) (int, error) {
+	res, err := smv.SocialMediaRepository.GetDueForRecheck(now.Add(-smv.RecheckAfter), socialMediaRecheckBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, socialMedia := range res {
+		found, err := smv.Check(ctx, socialMedia)
+		if errors.Is(err, model.ErrorVerificationFetch) {
+			continue
+		}
+		if err != nil {
+			return revoked, err
+		}
+
+		verifiedAt := socialMedia.VerifiedAt
+		if !found {
+			verifiedAt = nil
+			revoked++
+		}
+		err = smv.SocialMediaRepository.UpdateVerification(socialMedia.ID, verifiedAt, now)
+		if err != nil {
+			return revoked, err
+		}
+	}
+	return revoked, nil
+}
+
+// Run rechecks verified links every Interval until ctx is done.
+func (smv *SocialMediaVerifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(smv.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			revoked, err := smv.Recheck(ctx, now)
+			if err != nil {
+				log.Printf("social media verifier: %v", err)
+				continue
+			}
+			if revoked > 0 {
+				log.Printf("social media verifier: revoked %d verifications", revoked)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSocialMediaVerifier_Recheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/kept":
+			fmt.Fprint(w, "mygram-verification=kept")
+		case "/removed":
+			fmt.Fprint(w, "nothing here")
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	socialMediaRepository := mocks.NewISocialMediaRepository(t)
+	smv := &SocialMediaVerifier{
+		SocialMediaRepository: socialMediaRepository,
+		Fetcher:               &HTTPFetcher{Client: server.Client(), MaxBytes: 1 << 20},
+		RecheckAfter:          24 * time.Hour,
+	}
+
+	now := time.Now()
+	verifiedAt := now.Add(-48 * time.Hour)
+	socialMediaRepository.On("GetDueForRecheck", now.Add(-24*time.Hour), socialMediaRecheckBatchSize).Return([]model.SocialMedia{
+		{ID: "1", SocialMediaURL: server.URL + "/kept", VerificationToken: "kept", VerifiedAt: &verifiedAt},
+		{ID: "2", SocialMediaURL: server.URL + "/removed", VerificationToken: "removed", VerifiedAt: &verifiedAt},
+		{ID: "3", SocialMediaURL: server.URL + "/down", VerificationToken: "down", VerifiedAt: &verifiedAt},
+	}, nil).Once()
+	socialMediaRepository.On("UpdateVerification", "1", &verifiedAt, now).Return(nil).Once()
+	socialMediaRepository.On("UpdateVerification", "2", (*time.Time)(nil), now).Return(nil).Once()
+
+	revoked, err := smv.Recheck(context.Background(), now)
+	if err != nil {
+		t.Fatalf("SocialMediaVerifier.Recheck() error = %v", err)
+	}
+	if revoked != 1 {
+		t.Errorf("SocialMediaVerifier.Recheck() = %d, want 1", revoked)
+	}
+}
+
+func TestHTTPFetcher_RefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "internal")
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPFetcher(time.Second, 1<<20).Fetch(context.Background(), server.URL)
+	if err == nil {
+		t.Errorf("HTTPFetcher.Fetch(%q) error = nil, want refused", server.URL)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"log"
+	"mygram/repository"
+	"time"
+)
+
+// storyReaperBatchSize caps the stories deleted per query.
+const storyReaperBatchSize = 100
+
+// StoryReaper deletes expired stories and their stored files.
+type StoryReaper struct {
+	StoryRepository repository.IStoryRepository
+	ImageRepository repository.IImageRepository
+	Interval        time.Duration
+}
+
+func NewStoryReaper(storyRepository repository.IStoryRepository, imageRepository repository.IImageRepository, interval time.Duration) *StoryReaper {
+	return &StoryReaper{
+		StoryRepository: storyRepository,
+		ImageRepository: imageRepository,
+		Interval:        interval,
+	}
+}
+
+// Reap deletes the stories expired at now and returns how many. Files go
+// first, so a failure leaves the story to be reaped again on the next run
+// rather than an orphaned file. Deleting is idempotent, instances running
+// the reaper at once do not get in each other's way.
+func (sr *StoryReaper) Reap(now time.Time) (int, error) {
+	reaped := 0
+	for {
+		res, err := sr.StoryRepository.GetExpired(now, storyReaperBatchSize)
+		if err != nil || len(res) == 0 {
+			return reaped, err
+		}
+
+		ids := make([]string, 0, len(res))
+		for _, story := range res {
+			err = sr.ImageRepository.Delete(story.FileName)
+			if err != nil {
+				return reaped, err
+			}
+			ids = append(ids, story.ID)
+		}
+
+		err = sr.StoryRepository.DeleteByIDs(ids)
+		if err != nil {
+			return reaped, err
+		}
+		reaped += len(ids)
+
+		if len(res) < storyReaperBatchSize {
+			return reaped, nil
+		}
+	}
+}
+
+// Run reaps expired stories every Interval until ctx is done. Failures are
+// logged and retried on the next tick.
+func (sr *StoryReaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(sr.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			reaped, err := sr.Reap(now)
+			if err != nil {
+				log.Printf("story reaper: %v", err)
+				continue
+			}
+			if reaped > 0 {
+				log.Printf("story reaper: deleted %d stories", reaped)
+			}
+		}
+	}
+}
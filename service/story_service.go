@@ -0,0 +1,266 @@
+package service
+
+import (
+	"mygram/helper"
+	"mygram/model"
+	"mygram/repository"
+	"sort"
+	"time"
+)
+
+type StoryService struct {
+	StoryRepository  repository.IStoryRepository
+	UserRepository   repository.IUserRepository
+	ImageRepository  repository.IImageRepository
+	VisibilityPolicy *VisibilityPolicy
+	// MaxUploadSize is the largest accepted upload in bytes.
+	MaxUploadSize int64
+}
+
+func NewStoryService(storyRepository repository.IStoryRepository, userRepository repository.IUserRepository, imageRepository repository.IImageRepository, visibilityPolicy *VisibilityPolicy, maxUploadSize int64) *StoryService {
+	return &StoryService{
+		StoryRepository:  storyRepository,
+		UserRepository:   userRepository,
+		ImageRepository:  imageRepository,
+		VisibilityPolicy: visibilityPolicy,
+		MaxUploadSize:    maxUploadSize,
+	}
+}
+
+// storyFileName is the name a story's file is stored under.
+func storyFileName(id string) string {
+	return "story-" + id + ".jpg"
+}
+
+// Upload stores an uploaded JPEG as a story expiring after StoryLifetime. The
+// file is processed like photo uploads, upright and without EXIF.
+func (ss *StoryService) Upload(request model.StoryUploadRequest, data []byte, userId string) (model.StoryResponse, error) {
+	if int64(len(data)) > ss.MaxUploadSize {
+		return model.StoryResponse{}, model.ErrorImageTooLarge
+	}
+
+	altText := model.NormalizeAltText(request.AltText)
+	err := model.ValidateAltText(altText)
+	if err != nil {
+		return model.StoryResponse{}, err
+	}
+
+	processed, err := ProcessJPEG(data)
+	if err != nil {
+		return model.StoryResponse{}, err
+	}
+
+	id := helper.GenerateID()
+	fileName := storyFileName(id)
+	mediaURL, err := ss.ImageRepository.Save(fileName, processed.Data)
+	if err != nil {
+		return model.StoryResponse{}, err
+	}
+
+	now := time.Now()
+	story := model.Story{
+		ID:        id,
+		UserID:    userId,
+		MediaURL:  mediaURL,
+		FileName:  fileName,
+		Caption:   request.Caption,
+		AltText:   altText,
+		ExpiresAt: now.Add(model.StoryLifetime),
+		CreatedAt: now,
+	}
+
+	res, err := ss.StoryRepository.Save(story)
+	if err != nil {
+		ss.ImageRepository.Delete(fileName)
+		return model.StoryResponse{}, err
+	}
+	return model.ToStoryResponse(res, false), nil
+}
+
+// GetById returns an unexpired story and records that viewerId saw it.
+// Owners viewing their own story are not recorded.
+func (ss *StoryService) GetById(id string, viewerId string) (model.StoryResponse, error) {
+	story, err := ss.getUnexpired(id)
+	if err != nil {
+		return model.StoryResponse{}, err
+	}
+
+	if story.UserID == viewerId {
+		return model.ToStoryResponse(story, true), nil
+	}
+
+	canView, err := ss.VisibilityPolicy.CanView(viewerId, story.UserID)
+	if err != nil {
+		return model.StoryResponse{}, err
+	}
+	if !canView {
+		return model.StoryResponse{}, model.ErrorNotFound
+	}
+
+	err = ss.StoryRepository.SaveView(model.StoryView{
+		StoryID:  story.ID,
+		ViewerID: viewerId,
+	})
+	if err != nil {
+		return model.StoryResponse{}, err
+	}
+	return model.ToStoryResponse(story, true), nil
+}
+
+// Feed groups the unexpired stories of the viewer and the users they follow
+// by user. The viewer's own stories come first, then users with stories left
+// to see, then the rest, each by their latest story.
+func (ss *StoryService) Feed(viewerId string) ([]model.StoryFeedResponse, error) {
+	feedResponse := make([]model.StoryFeedResponse, 0)
+
+	res, err := ss.StoryRepository.GetFeed(viewerId, time.Now())
+	if err != nil {
+		return []model.StoryFeedResponse{}, err
+	}
+
+	ownerIds := make([]string, 0, len(res))
+	for _, story := range res {
+		ownerIds = append(ownerIds, story.UserID)
+	}
+	visible, err := ss.VisibilityPolicy.VisibleOwners(viewerId, ownerIds)
+	if err != nil {
+		return []model.StoryFeedResponse{}, err
+	}
+	muted, err := ss.VisibilityPolicy.MutedUsers(viewerId, ownerIds)
+	if err != nil {
+		return []model.StoryFeedResponse{}, err
+	}
+
+	stories := make([]model.Story, 0, len(res))
+	storyIds := make([]string, 0, len(res))
+	userIds := make([]string, 0)
+	for _, story := range res {
+		if !visible[story.UserID] || muted[story.UserID] {
+			continue
+		}
+		stories = append(stories, story)
+		storyIds = append(storyIds, story.ID)
+		userIds = append(userIds, story.UserID)
+	}
+	if len(stories) == 0 {
+		return feedResponse, nil
+	}
+
+	seenIds, err := ss.StoryRepository.GetSeenIDs(viewerId, storyIds)
+	if err != nil {
+		return []model.StoryFeedResponse{}, err
+	}
+	seen := make(map[string]bool, len(seenIds))
+	for _, storyId := range seenIds {
+		seen[storyId] = true
+	}
+
+	users, err := ss.UserRepository.GetByIDs(uniqueIDs(userIds))
+	if err != nil {
+		return []model.StoryFeedResponse{}, err
+	}
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+
+	groups := make(map[string]*model.StoryFeedResponse)
+	latest := make(map[string]time.Time)
+	order := make([]string, 0)
+	for _, story := range stories {
+		group, ok := groups[story.UserID]
+		if !ok {
+			group = &model.StoryFeedResponse{
+				UserID:   story.UserID,
+				Username: usernames[story.UserID],
+				AllSeen:  true,
+				Stories:  make([]model.StoryResponse, 0),
+			}
+			groups[story.UserID] = group
+			order = append(order, story.UserID)
+		}
+		isSeen := story.UserID == viewerId || seen[story.ID]
+		group.AllSeen = group.AllSeen && isSeen
+		group.Stories = append(group.Stories, model.ToStoryResponse(story, isSeen))
+		latest[story.UserID] = story.CreatedAt
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := groups[order[i]], groups[order[j]]
+		if (a.UserID == viewerId) != (b.UserID == viewerId) {
+			return a.UserID == viewerId
+		}
+		if a.AllSeen != b.AllSeen {
+			return !a.AllSeen
+		}
+		return latest[a.UserID].After(latest[b.UserID])
+	})
+	for _, userId := range order {
+		feedResponse = append(feedResponse, *groups[userId])
+	}
+	return feedResponse, nil
+}
+
+// Viewers lists who saw the owner's story, most recent first. Users blocked
+// since are left out.
+func (ss *StoryService) Viewers(request model.PaginationRequest, id string, userId string) (model.StoryViewerListResponse, error) {
+	viewersResponse := make([]model.StoryViewerResponse, 0)
+	pagination := request.Normalize()
+
+	story, err := ss.StoryRepository.GetOne(id)
+	if err != nil {
+		return model.StoryViewerListResponse{}, err
+	}
+	if story.UserID != userId {
+		return model.StoryViewerListResponse{}, model.ErrorForbiddenAccess
+	}
+
+	res, total, err := ss.StoryRepository.GetViewers(id, userId, pagination)
+	if err != nil {
+		return model.StoryViewerListResponse{}, err
+	}
+
+	for _, view := range res {
+		viewersResponse = append(viewersResponse, model.StoryViewerResponse{
+			UserID:   view.ViewerID,
+			Username: view.Viewer.Username,
+			SeenAt:   view.CreatedAt,
+		})
+	}
+
+	return model.StoryViewerListResponse{
+		StoryID:    story.ID,
+		Viewers:    viewersResponse,
+		Pagination: model.ToPaginationResponse(pagination, total),
+	}, nil
+}
+
+// DeleteById removes the owner's story and its stored file before it expires.
+func (ss *StoryService) DeleteById(id string, userId string) error {
+	story, err := ss.StoryRepository.GetOne(id)
+	if err != nil {
+		return err
+	}
+	if story.UserID != userId {
+		return model.ErrorForbiddenAccess
+	}
+
+	err = ss.StoryRepository.DeleteByIDs([]string{id})
+	if err != nil {
+		return err
+	}
+	return ss.ImageRepository.Delete(story.FileName)
+}
+
+// getUnexpired returns the story, treating an expired one the reaper has not
+// deleted yet as gone.
+func (ss *StoryService) getUnexpired(id string) (model.Story, error) {
+	story, err := ss.StoryRepository.GetOne(id)
+	if err != nil {
+		return model.Story{}, err
+	}
+	if !story.ExpiresAt.After(time.Now()) {
+		return model.Story{}, model.ErrorNotFound
+	}
+	return story, nil
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"errors"
+	"mygram/model"
+	"mygram/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStoryService_Feed(t *testing.T) {
+	storyRepository := mocks.NewIStoryRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+	muteRepository := mocks.NewIMuteRepository(t)
+
+	ss := &StoryService{
+		StoryRepository:  storyRepository,
+		UserRepository:   userRepository,
+		VisibilityPolicy: NewVisibilityPolicy(userRepository, nil, nil, blockRepository, muteRepository),
+	}
+
+	now := time.Now()
+	stories := []model.Story{
+		{ID: "s1", UserID: "1", CreatedAt: now.Add(-5 * time.Hour)},
+		{ID: "s2", UserID: "2", CreatedAt: now.Add(-4 * time.Hour)},
+		{ID: "s3", UserID: "2", CreatedAt: now.Add(-time.Hour)},
+		{ID: "s4", UserID: "3", CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "s5", UserID: "4", CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "s6", UserID: "5", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	storyRepository.On("GetFeed", "1", mock.Anything).Return(stories, nil).Once()
+	blockRepository.On("GetBlockedIDs", "1", []string{"2", "3", "4", "5"}).Return([]string{"5"}, nil).Once()
+	userRepository.On("GetByIDs", []string{"2", "3", "4"}).Return([]model.User{{ID: "2"}, {ID: "3"}, {ID: "4"}}, nil).Once()
+	muteRepository.On("GetMutedIDs", "1", []string{"2", "3", "4", "5"}).Return([]string{"4"}, nil).Once()
+	storyRepository.On("GetSeenIDs", "1", []string{"s1", "s2", "s3", "s4"}).Return([]string{"s2", "s3"}, nil).Once()
+	userRepository.On("GetByIDs", []string{"1", "2", "3"}).Return([]model.User{
+		{ID: "1", Username: "me"}, {ID: "2", Username: "seen"}, {ID: "3", Username: "unseen"},
+	}, nil).Once()
+
+	got, err := ss.Feed("1")
+	if err != nil {
+		t.Fatalf("StoryService.Feed() error = %v", err)
+	}
+
+	want := []struct {
+		username string
+		allSeen  bool
+		stories  int
+	}{
+		{"me", true, 1},
+		{"unseen", false, 1},
+		{"seen", true, 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("StoryService.Feed() = %+v, want %d groups", got, len(want))
+	}
+	for i, group := range want {
+		if got[i].Username != group.username || got[i].AllSeen != group.allSeen || len(got[i].Stories) != group.stories {
+			t.Errorf("StoryService.Feed() group %d = %+v, want %+v", i, got[i], group)
+		}
+	}
+	if got[2].Stories[0].ID != "s2" {
+		t.Errorf("StoryService.Feed() stories of a user = %+v, want oldest first", got[2].Stories)
+	}
+}
+
+func TestStoryService_GetById(t *testing.T) {
+	storyRepository := mocks.NewIStoryRepository(t)
+	userRepository := mocks.NewIUserRepository(t)
+	blockRepository := mocks.NewIBlockRepository(t)
+
+	ss := &StoryService{
+		StoryRepository:  storyRepository,
+		VisibilityPolicy: NewVisibilityPolicy(userRepository, nil, nil, blockRepository, nil),
+	}
+
+	story := model.Story{ID: "s1", UserID: "1", ExpiresAt: time.Now().Add(time.Hour)}
+	expired := model.Story{ID: "s1", UserID: "1", ExpiresAt: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name     string
+		viewerId string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:     "Case #1 - Success (View recorded)",
+			viewerId: "2",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(story, nil).Once()
+				blockRepository.On("GetBlockedIDs", "2", []string{"1"}).Return([]string{}, nil).Once()
+				userRepository.On("GetByIDs", []string{"1"}).Return([]model.User{{ID: "1"}}, nil).Once()
+				storyRepository.On("SaveView", model.StoryView{StoryID: "s1", ViewerID: "2"}).Return(nil).Once()
+			},
+		},
+		{
+			name:     "Case #2 - Success (Owner not recorded)",
+			viewerId: "1",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(story, nil).Once()
+			},
+		},
+		{
+			name:     "Case #3 - Failed (Expired)",
+			viewerId: "2",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(expired, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+		{
+			name:     "Case #4 - Failed (Blocked)",
+			viewerId: "3",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(story, nil).Once()
+				blockRepository.On("GetBlockedIDs", "3", []string{"1"}).Return([]string{"1"}, nil).Once()
+			},
+			wantErr: model.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ss.GetById("s1", tt.viewerId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("StoryService.GetById() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !got.Seen {
+				t.Errorf("StoryService.GetById() seen = %v, want true", got.Seen)
+			}
+		})
+	}
+}
+
+func TestStoryService_Viewers(t *testing.T) {
+	storyRepository := mocks.NewIStoryRepository(t)
+
+	ss := &StoryService{
+		StoryRepository: storyRepository,
+	}
+
+	story := model.Story{ID: "s1", UserID: "1"}
+
+	tests := []struct {
+		name     string
+		userId   string
+		mockFunc func()
+		want     []string
+		wantErr  error
+	}{
+		{
+			name:   "Case #1 - Success (Viewers the owner has not blocked)",
+			userId: "1",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(story, nil).Once()
+				storyRepository.On("GetViewers", "s1", "1", mock.Anything).Return([]model.StoryView{
+					{StoryID: "s1", ViewerID: "2", Viewer: model.User{ID: "2", Username: "two"}},
+				}, int64(1), nil).Once()
+			},
+			want: []string{"two"},
+		},
+		{
+			name:   "Case #2 - Failed (Not the owner)",
+			userId: "2",
+			mockFunc: func() {
+				storyRepository.On("GetOne", "s1").Return(story, nil).Once()
+			},
+			wantErr: model.ErrorForbiddenAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := ss.Viewers(model.PaginationRequest{}, "s1", tt.userId)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("StoryService.Viewers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Viewers) != len(tt.want) || got.Pagination.Total != int64(len(tt.want)) {
+				t.Errorf("StoryService.Viewers() = %+v, want %v", got.Viewers, tt.want)
+				return
+			}
+			for i, username := range tt.want {
+				if got.Viewers[i].Username != username {
+					t.Errorf("StoryService.Viewers() = %+v, want %v", got.Viewers, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStoryReaper_Reap(t *testing.T) {
+	storyRepository := mocks.NewIStoryRepository(t)
+	imageRepository := mocks.NewIImageRepository(t)
+
+	sr := NewStoryReaper(storyRepository, imageRepository, time.Minute)
+	now := time.Now()
+	expired := []model.Story{
+		{ID: "s1", FileName: "story-s1.jpg"},
+		{ID: "s2", FileName: "story-s2.jpg"},
+	}
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     int
+		wantErr  bool
+	}{
+		{
+			name: "Case #1 - Success",
+			mockFunc: func() {
+				storyRepository.On("GetExpired", now, storyReaperBatchSize).Return(expired, nil).Once()
+				imageRepository.On("Delete", "story-s1.jpg").Return(nil).Once()
+				imageRepository.On("Delete", "story-s2.jpg").Return(nil).Once()
+				storyRepository.On("DeleteByIDs", []string{"s1", "s2"}).Return(nil).Once()
+			},
+			want: 2,
+		},
+		{
+			name: "Case #2 - Failed (Story kept when its file cannot be deleted)",
+			mockFunc: func() {
+				storyRepository.On("GetExpired", now, storyReaperBatchSize).Return(expired, nil).Once()
+				imageRepository.On("Delete", "story-s1.jpg").Return(model.MyError{Err: "disk error"}).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			got, err := sr.Reap(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StoryReaper.Reap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("StoryReaper.Reap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
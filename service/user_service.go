@@ -1,18 +1,26 @@
 package service
 
 import (
+	"errors"
 	"mygram/helper"
+	"mygram/i18n"
 	"mygram/model"
 	"mygram/repository"
+	"strings"
+	"time"
 )
 
 type UserService struct {
-	UserRepository repository.IUserRepository
+	UserRepository   repository.IUserRepository
+	FollowRepository repository.IFollowRepository
+	VisibilityPolicy *VisibilityPolicy
 }
 
-func NewUserService(userRepository repository.IUserRepository) *UserService {
+func NewUserService(userRepository repository.IUserRepository, followRepository repository.IFollowRepository, visibilityPolicy *VisibilityPolicy) *UserService {
 	return &UserService{
-		UserRepository: userRepository,
+		UserRepository:   userRepository,
+		FollowRepository: followRepository,
+		VisibilityPolicy: visibilityPolicy,
 	}
 }
 
@@ -51,6 +59,9 @@ func (us *UserService) Login(request model.UserLoginRequest) (model.UserLoginRes
 	result, err := us.UserRepository.GetByUsername(request.Username)
 
 	if err != nil {
+		if errors.Is(err, model.ErrorNotFound) {
+			return model.UserLoginResponse{}, model.ErrorInvalidEmailOrPassword
+		}
 		return model.UserLoginResponse{}, err
 	}
 
@@ -60,9 +71,13 @@ func (us *UserService) Login(request model.UserLoginRequest) (model.UserLoginRes
 		return model.UserLoginResponse{}, model.ErrorInvalidEmailOrPassword
 	}
 
+	if result.IsSuspended(time.Now()) {
+		return model.UserLoginResponse{}, model.ErrorAccountSuspended
+	}
+
 	token, err := helper.GenerateToken(result.ID)
 	if err != nil {
-		return model.UserLoginResponse{}, model.ErrorInvalidToken
+		return model.UserLoginResponse{}, err
 	}
 
 	return model.UserLoginResponse{
@@ -96,6 +111,12 @@ func (us *UserService) MyGram(id string) (model.UserGramResponse, error) {
 			ID:             val.ID,
 			Name:           val.Name,
 			SocialMediaURL: val.SocialMediaURL,
+			Platform:       val.Platform,
+			Handle:         val.Handle,
+			DisplayURL:     model.DisplaySocialMediaURL(val.SocialMediaURL),
+			Position:       val.Position,
+			Visibility:     val.Visibility,
+			VerifiedAt:     val.VerifiedAt,
 			CreatedAt:      val.CreatedAt,
 			UpdatedAt:      val.UpdatedAt,
 		})
@@ -114,6 +135,7 @@ func (us *UserService) MyGram(id string) (model.UserGramResponse, error) {
 		Email:        result.Email,
 		Username:     result.Username,
 		Age:          result.Age,
+		IsPrivate:    result.IsPrivate,
 		Photos:       photoResponse,
 		Comments:     commentResponse,
 		SocialMedias: socialMediaResponse,
@@ -122,3 +144,140 @@ func (us *UserService) MyGram(id string) (model.UserGramResponse, error) {
 	}, nil
 
 }
+
+// GetProfile shows a user's public profile. Photos and social medias are only
+// included when the viewer may see the user's content. Blocked users do not
+// see each other's profile at all.
+func (us *UserService) GetProfile(username string, viewerId string) (model.UserProfileResponse, error) {
+	user, err := us.UserRepository.GetByUsername(username)
+	if err != nil {
+		return model.UserProfileResponse{}, err
+	}
+
+	canInteract, err := us.VisibilityPolicy.CanInteract(viewerId, user.ID)
+	if err != nil {
+		return model.UserProfileResponse{}, err
+	}
+	if !canInteract {
+		return model.UserProfileResponse{}, model.ErrorNotFound
+	}
+
+	followersCount, err := us.FollowRepository.CountFollowers(user.ID)
+	if err != nil {
+		return model.UserProfileResponse{}, err
+	}
+
+	followingCount, err := us.FollowRepository.CountFollowing(user.ID)
+	if err != nil {
+		return model.UserProfileResponse{}, err
+	}
+
+	followStatus := ""
+	if user.ID != viewerId {
+		follow, err := us.FollowRepository.GetOne(viewerId, user.ID)
+		if err != nil && !errors.Is(err, model.ErrorNotFound) {
+			return model.UserProfileResponse{}, err
+		}
+		followStatus = follow.Status
+	}
+
+	canView, err := us.VisibilityPolicy.CanView(viewerId, user.ID)
+	if err != nil {
+		return model.UserProfileResponse{}, err
+	}
+
+	photoResponse := make([]model.ListPhotoResponse, 0)
+	socialMediaResponse := make([]model.ListSocialMediasResponse, 0)
+	if canView {
+		detail, err := us.UserRepository.GetDetailUser(user.ID)
+		if err != nil {
+			return model.UserProfileResponse{}, err
+		}
+
+		for _, val := range detail.Photos {
+			if isHiddenFrom(viewerId, val.UserID, val.Hidden) || !val.IsPublished() {
+				continue
+			}
+			photoResponse = append(photoResponse, model.ListPhotoResponse{
+				ID:        val.ID,
+				Title:     val.Title,
+				Caption:   val.Caption,
+				PhotoURL:  val.PhotoURL,
+				CreatedAt: val.CreatedAt,
+				UpdatedAt: val.UpdatedAt,
+			})
+		}
+		socialMedias, err := us.VisibilityPolicy.FilterSocialMedia(viewerId, detail.SocialMedias)
+		if err != nil {
+			return model.UserProfileResponse{}, err
+		}
+		for _, val := range socialMedias {
+			socialMediaResponse = append(socialMediaResponse, model.ListSocialMediasResponse{
+				ID:             val.ID,
+				Name:           val.Name,
+				SocialMediaURL: val.SocialMediaURL,
+				Platform:       val.Platform,
+				Handle:         val.Handle,
+				DisplayURL:     model.DisplaySocialMediaURL(val.SocialMediaURL),
+				Position:       val.Position,
+				Visibility:     val.Visibility,
+				VerifiedAt:     val.VerifiedAt,
+				CreatedAt:      val.CreatedAt,
+				UpdatedAt:      val.UpdatedAt,
+			})
+		}
+	}
+
+	return model.UserProfileResponse{
+		ID:             user.ID,
+		Username:       user.Username,
+		IsPrivate:      user.IsPrivate,
+		FollowersCount: followersCount,
+		FollowingCount: followingCount,
+		FollowStatus:   followStatus,
+		CanViewContent: canView,
+		Photos:         photoResponse,
+		SocialMedias:   socialMediaResponse,
+		CreatedAt:      user.CreatedAt,
+	}, nil
+}
+
+// UpdatePrivacy switches the account between public and private. Going public
+// approves every pending follow request.
+func (us *UserService) UpdatePrivacy(request model.UserPrivacyRequest, userId string) (model.UserPrivacyResponse, error) {
+	err := us.UserRepository.UpdatePrivacy(userId, request.IsPrivate)
+	if err != nil {
+		return model.UserPrivacyResponse{}, err
+	}
+
+	if !request.IsPrivate {
+		err = us.FollowRepository.AcceptAllPending(userId)
+		if err != nil {
+			return model.UserPrivacyResponse{}, err
+		}
+	}
+
+	return model.UserPrivacyResponse{
+		ID:        userId,
+		IsPrivate: request.IsPrivate,
+	}, nil
+}
+
+// UpdateLocale stores the language the user is answered in. An empty locale
+// goes back to following the Accept-Language header.
+func (us *UserService) UpdateLocale(request model.UserLocaleRequest, userId string) (model.UserLocaleResponse, error) {
+	locale := strings.ToLower(strings.TrimSpace(request.Locale))
+	if locale != "" && !i18n.Supported(locale) {
+		return model.UserLocaleResponse{}, model.ErrorUnsupportedLocale
+	}
+
+	err := us.UserRepository.UpdateLocale(userId, locale)
+	if err != nil {
+		return model.UserLocaleResponse{}, err
+	}
+
+	return model.UserLocaleResponse{
+		ID:     userId,
+		Locale: locale,
+	}, nil
+}
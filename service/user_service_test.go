@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"mygram/helper"
 	"mygram/model"
 	"mygram/repository/mocks"
@@ -214,3 +215,53 @@ func TestUserService_MyGram(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_UpdateLocale(t *testing.T) {
+	userRepository := mocks.NewIUserRepository(t)
+	us := &UserService{UserRepository: userRepository}
+
+	tests := []struct {
+		name     string
+		request  model.UserLocaleRequest
+		want     model.UserLocaleResponse
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name:    "Case #1 - Supported locale",
+			request: model.UserLocaleRequest{Locale: " ID "},
+			want:    model.UserLocaleResponse{ID: "u1", Locale: "id"},
+			mockFunc: func() {
+				userRepository.On("UpdateLocale", "u1", "id").Return(nil).Once()
+			},
+		},
+		{
+			name:    "Case #2 - Empty locale follows Accept-Language",
+			request: model.UserLocaleRequest{},
+			want:    model.UserLocaleResponse{ID: "u1"},
+			mockFunc: func() {
+				userRepository.On("UpdateLocale", "u1", "").Return(nil).Once()
+			},
+		},
+		{
+			name:     "Case #3 - Unsupported locale",
+			request:  model.UserLocaleRequest{Locale: "fr"},
+			mockFunc: func() {},
+			wantErr:  model.ErrorUnsupportedLocale,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			got, err := us.UpdateLocale(tt.request, "u1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UserService.UpdateLocale() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserService.UpdateLocale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"mygram/model"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request interface{}
+		want    []model.FieldError
+	}{
+		{
+			name:    "Valid register request",
+			request: &model.UserRegisterRequest{Email: "adi@mail.com", Username: "adi.w_01", Password: "secret", Age: 20},
+		},
+		{
+			name:    "Username with other characters",
+			request: &model.UserRegisterRequest{Email: "adi@mail.com", Username: "adi w!", Password: "secret", Age: 20},
+			want: []model.FieldError{
+				{Field: "username", Code: "matches", Message: "Username may only contain letters and digits and the characters . and _", Key: "validation.username_invalid_characters"},
+			},
+		},
+		{
+			name:    "Username longer than the column",
+			request: &model.UserRegisterRequest{Email: "adi@mail.com", Username: strings.Repeat("a", 31), Password: "secret", Age: 20},
+			want: []model.FieldError{
+				{Field: "username", Code: "maxstringlength", Message: "Username must be at most 30 characters", Key: "validation.username_too_long"},
+			},
+		},
+		{
+			name: "Every failed field of a photo by its JSON key",
+			request: &model.PhotoCreateRequest{
+				Caption:  strings.Repeat("a", 256),
+				PhotoURL: "not a url",
+				Location: &model.PhotoLocationRequest{PlaceName: strings.Repeat("b", 101)},
+			},
+			want: []model.FieldError{
+				{Field: "title", Code: "required", Message: "Title is required", Key: "validation.title_required"},
+				{Field: "caption", Code: "maxstringlength", Message: "Caption must be at most 255 characters", Key: "validation.caption_too_long"},
+				{Field: "photo_url", Code: "url", Message: "Photo URL must be a valid URL", Key: "validation.photo_url_invalid"},
+				{Field: "location.place_name", Code: "maxstringlength", Message: "Place name at most 100 characters", Key: "validation.place_name_too_long"},
+			},
+		},
+		{
+			name:    "Media item",
+			request: &model.PhotoCreateRequest{Title: "Sunset", Media: []model.PhotoMediaRequest{{URL: "https://cdn.mygram.dev/a.jpg"}, {URL: "not a url"}}},
+			want: []model.FieldError{
+				{Field: "media.1", Code: "url", Message: "Media URL must be a valid URL", Key: "validation.media_url_invalid"},
+			},
+		},
+		{
+			name:    "Social Media URL",
+			request: &model.SocialMediaCreateRequest{SocialMediaURL: "my github"},
+			want: []model.FieldError{
+				{Field: "social_media_url", Code: "url", Message: "Social Media URL must be a valid URL", Key: "validation.social_media_url_invalid"},
+			},
+		},
+		{
+			name:    "Form field by its form key",
+			request: &model.SearchRequest{},
+			want: []model.FieldError{
+				{Field: "q", Code: "required", Message: "Query is required", Key: "validation.query_required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := model.ValidateRequest(tt.request)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateRequest() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var myError model.MyError
+			if !errors.As(err, &myError) || !errors.Is(err, model.ErrorValidation) {
+				t.Fatalf("ValidateRequest() error = %v, want %v", err, model.ErrorValidation)
+			}
+			if !reflect.DeepEqual(myError.Details, tt.want) {
+				t.Errorf("ValidateRequest() details = %+v, want %+v", myError.Details, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"mygram/model"
+	"mygram/repository"
+	"time"
+)
+
+// VisibilityPolicy is the single place deciding whose content a viewer may
+// see. Every read path filters through it so a private account's photos,
+// comments and albums only reach the owner and accepted followers, blocked
+// users never see each other, and hidden content and suspended accounts only
+// remain visible to their owner, as do drafts and scheduled photos.
+type VisibilityPolicy struct {
+	UserRepository   repository.IUserRepository
+	FollowRepository repository.IFollowRepository
+	PhotoRepository  repository.IPhotoRepository
+	BlockRepository  repository.IBlockRepository
+	MuteRepository   repository.IMuteRepository
+}
+
+func NewVisibilityPolicy(userRepository repository.IUserRepository, followRepository repository.IFollowRepository, photoRepository repository.IPhotoRepository, blockRepository repository.IBlockRepository, muteRepository repository.IMuteRepository) *VisibilityPolicy {
+	return &VisibilityPolicy{
+		UserRepository:   userRepository,
+		FollowRepository: followRepository,
+		PhotoRepository:  photoRepository,
+		BlockRepository:  blockRepository,
+		MuteRepository:   muteRepository,
+	}
+}
+
+func (vp *VisibilityPolicy) CanView(viewerId string, ownerId string) (bool, error) {
+	visible, err := vp.VisibleOwners(viewerId, []string{ownerId})
+	if err != nil {
+		return false, err
+	}
+	return visible[ownerId], nil
+}
+
+// CanInteract reports whether userId may comment on, follow or otherwise reach
+// otherId, which is never the case when either one blocked the other.
+func (vp *VisibilityPolicy) CanInteract(userId string, otherId string) (bool, error) {
+	blocked, err := vp.BlockedUsers(userId, []string{otherId})
+	if err != nil {
+		return false, err
+	}
+	return !blocked[otherId], nil
+}
+
+// IsFollower reports whether followerId has an accepted follow on ownerId.
+func (vp *VisibilityPolicy) IsFollower(followerId string, ownerId string) (bool, error) {
+	following, err := vp.FollowRepository.GetAcceptedFollowingIDs(followerId, []string{ownerId})
+	if err != nil {
+		return false, err
+	}
+	return len(following) > 0, nil
+}
+
+// BlockedUsers returns the subset of userIds that have a block with viewerId
+// in either direction.
+func (vp *VisibilityPolicy) BlockedUsers(viewerId string, userIds []string) (map[string]bool, error) {
+	blocked := make(map[string]bool)
+
+	lookup := make([]string, 0, len(userIds))
+	for _, userId := range uniqueIDs(userIds) {
+		if userId != viewerId {
+			lookup = append(lookup, userId)
+		}
+	}
+	if len(lookup) == 0 {
+		return blocked, nil
+	}
+
+	res, err := vp.BlockRepository.GetBlockedIDs(viewerId, lookup)
+	if err != nil {
+		return blocked, err
+	}
+	for _, userId := range res {
+		blocked[userId] = true
+	}
+	return blocked, nil
+}
+
+// VisibleOwners returns the subset of ownerIds whose content viewerId may see.
+// Unknown, blocked and suspended users are treated as not visible.
+func (vp *VisibilityPolicy) VisibleOwners(viewerId string, ownerIds []string) (map[string]bool, error) {
+	visible := make(map[string]bool)
+
+	others := make([]string, 0, len(ownerIds))
+	for _, ownerId := range uniqueIDs(ownerIds) {
+		if ownerId == viewerId {
+			visible[ownerId] = true
+			continue
+		}
+		others = append(others, ownerId)
+	}
+	if len(others) == 0 {
+		return visible, nil
+	}
+
+	blocked, err := vp.BlockedUsers(viewerId, others)
+	if err != nil {
+		return visible, err
+	}
+	lookup := make([]string, 0, len(others))
+	for _, ownerId := range others {
+		if !blocked[ownerId] {
+			lookup = append(lookup, ownerId)
+		}
+	}
+	if len(lookup) == 0 {
+		return visible, nil
+	}
+
+	owners, err := vp.UserRepository.GetByIDs(lookup)
+	if err != nil {
+		return visible, err
+	}
+
+	now := time.Now()
+	private := make([]string, 0)
+	for _, owner := range owners {
+		if owner.IsSuspended(now) {
+			continue
+		}
+		if owner.IsPrivate {
+			private = append(private, owner.ID)
+			continue
+		}
+		visible[owner.ID] = true
+	}
+	if len(private) == 0 {
+		return visible, nil
+	}
+
+	following, err := vp.FollowRepository.GetAcceptedFollowingIDs(viewerId, private)
+	if err != nil {
+		return visible, err
+	}
+	for _, ownerId := range following {
+		visible[ownerId] = true
+	}
+
+	return visible, nil
+}
+
+// VisiblePhotoIDs returns the subset of photoIds viewerId may see.
+func (vp *VisibilityPolicy) VisiblePhotoIDs(viewerId string, photoIds []string) (map[string]bool, error) {
+	visible := make(map[string]bool)
+
+	photos, err := vp.PhotoRepository.GetByIDs(uniqueIDs(photoIds))
+	if err != nil {
+		return visible, err
+	}
+
+	ownerIds := make([]string, 0, len(photos))
+	for _, photo := range photos {
+		ownerIds = append(ownerIds, photo.UserID)
+	}
+	owners, err := vp.VisibleOwners(viewerId, ownerIds)
+	if err != nil {
+		return visible, err
+	}
+
+	for _, photo := range photos {
+		if owners[photo.UserID] && !isPhotoHiddenFrom(viewerId, photo) {
+			visible[photo.ID] = true
+		}
+	}
+	return visible, nil
+}
+
+// FilterPhotos drops hidden and unpublished photos and photos of owners the
+// viewer may not see, and hidden comments or comments of hidden authors on the
+// remaining photos.
+func (vp *VisibilityPolicy) FilterPhotos(viewerId string, photos []model.Photo) ([]model.Photo, error) {
+	ownerIds := make([]string, 0)
+	for _, photo := range photos {
+		ownerIds = append(ownerIds, photo.UserID)
+		for _, comment := range photo.Comments {
+			ownerIds = append(ownerIds, comment.UserID)
+		}
+	}
+
+	visible, err := vp.VisibleOwners(viewerId, ownerIds)
+	if err != nil {
+		return []model.Photo{}, err
+	}
+
+	filtered := make([]model.Photo, 0, len(photos))
+	for _, photo := range photos {
+		if !visible[photo.UserID] || isPhotoHiddenFrom(viewerId, photo) {
+			continue
+		}
+		comments := make([]model.Comment, 0, len(photo.Comments))
+		for _, comment := range photo.Comments {
+			if visible[comment.UserID] && !isHiddenFrom(viewerId, comment.UserID, comment.Hidden) {
+				comments = append(comments, comment)
+			}
+		}
+		photo.Comments = comments
+		filtered = append(filtered, photo)
+	}
+	return filtered, nil
+}
+
+// FilterComments drops hidden comments and comments whose author or photo the
+// viewer may not see.
+func (vp *VisibilityPolicy) FilterComments(viewerId string, comments []model.Comment) ([]model.Comment, error) {
+	authorIds := make([]string, 0, len(comments))
+	photoIds := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		authorIds = append(authorIds, comment.UserID)
+		photoIds = append(photoIds, comment.PhotoID)
+	}
+
+	authors, err := vp.VisibleOwners(viewerId, authorIds)
+	if err != nil {
+		return []model.Comment{}, err
+	}
+	photos, err := vp.VisiblePhotoIDs(viewerId, photoIds)
+	if err != nil {
+		return []model.Comment{}, err
+	}
+
+	filtered := make([]model.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if authors[comment.UserID] && photos[comment.PhotoID] && !isHiddenFrom(viewerId, comment.UserID, comment.Hidden) {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered, nil
+}
+
+// FilterFeedPhotos applies FilterPhotos and additionally drops photos and
+// comments of users the viewer muted. Mutes only shape the viewer's feed,
+// muted users stay reachable through direct reads.
+func (vp *VisibilityPolicy) FilterFeedPhotos(viewerId string, photos []model.Photo) ([]model.Photo, error) {
+	photos, err := vp.FilterPhotos(viewerId, photos)
+	if err != nil {
+		return []model.Photo{}, err
+	}
+
+	ownerIds := make([]string, 0)
+	for _, photo := range photos {
+		ownerIds = append(ownerIds, photo.UserID)
+		for _, comment := range photo.Comments {
+			ownerIds = append(ownerIds, comment.UserID)
+		}
+	}
+	muted, err := vp.MutedUsers(viewerId, ownerIds)
+	if err != nil {
+		return []model.Photo{}, err
+	}
+
+	filtered := make([]model.Photo, 0, len(photos))
+	for _, photo := range photos {
+		if muted[photo.UserID] {
+			continue
+		}
+		comments := make([]model.Comment, 0, len(photo.Comments))
+		for _, comment := range photo.Comments {
+			if !muted[comment.UserID] {
+				comments = append(comments, comment)
+			}
+		}
+		photo.Comments = comments
+		filtered = append(filtered, photo)
+	}
+	return filtered, nil
+}
+
+// FilterFeedComments applies FilterComments and additionally drops comments
+// written by users the viewer muted.
+func (vp *VisibilityPolicy) FilterFeedComments(viewerId string, comments []model.Comment) ([]model.Comment, error) {
+	comments, err := vp.FilterComments(viewerId, comments)
+	if err != nil {
+		return []model.Comment{}, err
+	}
+
+	authorIds := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		authorIds = append(authorIds, comment.UserID)
+	}
+	muted, err := vp.MutedUsers(viewerId, authorIds)
+	if err != nil {
+		return []model.Comment{}, err
+	}
+
+	filtered := make([]model.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if !muted[comment.UserID] {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered, nil
+}
+
+// FilterSocialMedia drops links the viewer may not see. Beyond seeing their
+// owner's content, followers only links need an accepted follow and private
+// links are only shown to their owner.
+func (vp *VisibilityPolicy) FilterSocialMedia(viewerId string, links []model.SocialMedia) ([]model.SocialMedia, error) {
+	ownerIds := make([]string, 0, len(links))
+	followerOnly := make([]string, 0)
+	for _, link := range links {
+		ownerIds = append(ownerIds, link.UserID)
+		if link.Visibility == model.SocialMediaVisibilityFollowers && link.UserID != viewerId {
+			followerOnly = append(followerOnly, link.UserID)
+		}
+	}
+
+	visible, err := vp.VisibleOwners(viewerId, ownerIds)
+	if err != nil {
+		return []model.SocialMedia{}, err
+	}
+	following := make(map[string]bool)
+	if len(followerOnly) > 0 {
+		res, err := vp.FollowRepository.GetAcceptedFollowingIDs(viewerId, uniqueIDs(followerOnly))
+		if err != nil {
+			return []model.SocialMedia{}, err
+		}
+		for _, ownerId := range res {
+			following[ownerId] = true
+		}
+	}
+
+	filtered := make([]model.SocialMedia, 0, len(links))
+	for _, link := range links {
+		if !visible[link.UserID] {
+			continue
+		}
+		if link.UserID != viewerId {
+			switch link.Visibility {
+			case model.SocialMediaVisibilityPrivate:
+				continue
+			case model.SocialMediaVisibilityFollowers:
+				if !following[link.UserID] {
+					continue
+				}
+			}
+		}
+		filtered = append(filtered, link)
+	}
+	return filtered, nil
+}
+
+// MutedUsers returns the subset of userIds muted by viewerId.
+func (vp *VisibilityPolicy) MutedUsers(viewerId string, userIds []string) (map[string]bool, error) {
+	muted := make(map[string]bool)
+
+	lookup := make([]string, 0, len(userIds))
+	for _, userId := range uniqueIDs(userIds) {
+		if userId != viewerId {
+			lookup = append(lookup, userId)
+		}
+	}
+	if len(lookup) == 0 {
+		return muted, nil
+	}
+
+	res, err := vp.MuteRepository.GetMutedIDs(viewerId, lookup)
+	if err != nil {
+		return muted, err
+	}
+	for _, userId := range res {
+		muted[userId] = true
+	}
+	return muted, nil
+}
+
+// isHiddenFrom reports whether content hidden by moderation must be kept from
+// viewerId. Owners keep seeing their own hidden content.
+func isHiddenFrom(viewerId string, ownerId string, hidden bool) bool {
+	return hidden && viewerId != ownerId
+}
+
+// isPhotoHiddenFrom reports whether the photo must be kept from viewerId,
+// either hidden by moderation or not published yet.
+func isPhotoHiddenFrom(viewerId string, photo model.Photo) bool {
+	return isHiddenFrom(viewerId, photo.UserID, photo.Hidden || !photo.IsPublished())
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}